@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/printer"
+)
+
+var (
+	blameFormat   string
+	blameRef      string
+	blameTimeline bool
+)
+
+var blameCmd = &cobra.Command{
+	Use:   "blame <feature>",
+	Short: "Show who last changed each field of a feature",
+	Long: `Show, for every field of a feature, the commit, author and date that last
+changed it.
+
+The feature file's Git history is walked back from --ref (default HEAD) and
+each revision is compared with the one before it. Renamed feature files are
+followed by ID, and changes merged in from other branches are credited to the
+commit that made them rather than the merge.
+
+Fields reported:
+  name, description, state, version
+  metadata.<key>                    (priority, type, category, ...)
+  tag:<tag>
+  file:<path>
+  relationship:<type>:<target id>
+
+Values that differ from the last commit are shown as uncommitted.
+
+Examples:
+  fogit blame "User Auth"
+  fogit blame "User Auth" --timeline
+  fogit blame "User Auth" --ref v1.2.0 --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBlame,
+}
+
+func init() {
+	blameCmd.Flags().StringVar(&blameFormat, "format", "text", "Output format: text, json")
+	blameCmd.Flags().StringVar(&blameRef, "ref", "HEAD", "Revision to blame from")
+	blameCmd.Flags().BoolVar(&blameTimeline, "timeline", false, "Show every change to each field")
+
+	rootCmd.AddCommand(blameCmd)
+}
+
+func runBlame(cmd *cobra.Command, args []string) error {
+	if blameFormat != "text" && blameFormat != "json" {
+		return fmt.Errorf("invalid format: must be one of text, json")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	if cmdCtx.Git == nil {
+		return fmt.Errorf("blame requires a git repository")
+	}
+	gitRepo := cmdCtx.Git.GetGitRepo()
+
+	ctx, cancel := WithBlameTimeout(cmd.Context())
+	defer cancel()
+
+	feature, err := FindFeatureCrossBranch(ctx, cmdCtx, args[0], "fogit blame <id>")
+	if err != nil {
+		return err
+	}
+
+	// Blame the feature as it is at the ref; the working copy only applies to HEAD
+	if blameRef != "HEAD" {
+		files, err := features.ListFeatureFilesAtRef(gitRepo, blameRef)
+		if err != nil {
+			return fmt.Errorf("failed to read features at %s: %w", blameRef, err)
+		}
+		var found bool
+		for _, file := range files {
+			if file.Feature.ID == feature.ID {
+				feature, found = file.Feature, true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("feature %q does not exist at %s", feature.Name, blameRef)
+		}
+	}
+
+	blame, err := features.BuildBlame(ctx, gitRepo, feature, features.BlameOptions{
+		Ref:      blameRef,
+		Timeline: blameTimeline,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to blame %s: %w", feature.Name, err)
+	}
+
+	if blameFormat == "json" {
+		return printer.OutputAsJSON(os.Stdout, blame)
+	}
+	return printer.OutputBlameText(os.Stdout, blame)
+}
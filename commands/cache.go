@@ -0,0 +1,166 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/storage"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the cross-branch feature catalogue",
+	Long: `Manage the catalogue of features on other branches, stored in
+.fogit/metadata/branch_catalogue.json.
+
+Cross-branch commands (list, search, show, ...) read features from every local
+and remote branch. The catalogue remembers each branch's tip commit and the
+features parsed from it, so unchanged branches are not read again and changed
+branches only re-parse the feature files that changed. It is updated
+automatically and never needs to be committed.
+
+Examples:
+  fogit cache status
+  fogit cache rebuild
+  fogit cache clear`,
+}
+
+var cacheRebuildCmd = &cobra.Command{
+	Use:   "rebuild",
+	Short: "Catalogue every branch from scratch",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheRebuild,
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the catalogued branches and whether they are up to date",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStatus,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the catalogue",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheClear,
+}
+
+var cacheFormat string
+
+// cacheStatusOutput is the JSON/YAML form of 'fogit cache status'
+type cacheStatusOutput struct {
+	Path     string                           `json:"path" yaml:"path"`
+	Size     int64                            `json:"size" yaml:"size"`
+	Blobs    int                              `json:"blobs" yaml:"blobs"`
+	Branches []features.CatalogueBranchStatus `json:"branches" yaml:"branches"`
+}
+
+func init() {
+	cacheStatusCmd.Flags().StringVar(&cacheFormat, "format", "text", "Output format: text, json, yaml")
+
+	cacheCmd.AddCommand(cacheRebuildCmd)
+	cacheCmd.AddCommand(cacheStatusCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// loadBranchCatalogue loads the branch catalogue of a .fogit directory
+func loadBranchCatalogue(fogitDir string) (*storage.BranchCatalogue, error) {
+	cat := storage.NewBranchCatalogue(fogitDir)
+	if err := cat.Load(); err != nil {
+		return nil, err
+	}
+	return cat, nil
+}
+
+func runCacheRebuild(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if cmdCtx.Git == nil || cmdCtx.Git.GetGitRepo() == nil {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	cat, err := loadBranchCatalogue(cmdCtx.FogitDir)
+	if err != nil {
+		return err
+	}
+	count, err := features.RebuildBranchCatalogue(cmd.Context(), cat, cmdCtx.Git.GetGitRepo())
+	if err != nil {
+		return fmt.Errorf("failed to rebuild catalogue: %w", err)
+	}
+
+	stats := cat.Stats()
+	fmt.Printf("✓ Catalogued %d branch(es), %d feature file(s)\n", count, stats.Blobs)
+	return nil
+}
+
+func runCacheStatus(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if cmdCtx.Git == nil || cmdCtx.Git.GetGitRepo() == nil {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	cat, err := loadBranchCatalogue(cmdCtx.FogitDir)
+	if err != nil {
+		return err
+	}
+	stats := cat.Stats()
+	output := cacheStatusOutput{
+		Path:     stats.Path,
+		Size:     stats.Size,
+		Blobs:    stats.Blobs,
+		Branches: features.BranchCatalogueStatus(cat, cmdCtx.Git.GetGitRepo()),
+	}
+
+	textFn := func(w io.Writer) error {
+		if stats.Size == 0 {
+			fmt.Fprintln(w, "No catalogue yet; it is built by the next cross-branch command")
+			fmt.Fprintln(w, "or with: fogit cache rebuild")
+			return nil
+		}
+		fmt.Fprintf(w, "Catalogue: %s (%d bytes)\n", output.Path, output.Size)
+		fmt.Fprintf(w, "Branches:  %d\n", len(output.Branches))
+		fmt.Fprintf(w, "Features:  %d parsed file(s)\n\n", output.Blobs)
+		for _, b := range output.Branches {
+			state := "stale"
+			switch {
+			case b.Gone:
+				state = "gone"
+			case b.Fresh:
+				state = "fresh"
+			}
+			tip := b.Tip
+			if len(tip) > 8 {
+				tip = tip[:8]
+			}
+			fmt.Fprintf(w, "  %-6s %-40s %s  %d feature(s)\n", state, b.Branch, tip, b.Features)
+		}
+		return nil
+	}
+
+	return printer.OutputFormatted(os.Stdout, cacheFormat, output, textFn)
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	if err := storage.NewBranchCatalogue(fogitDir).Clear(); err != nil {
+		return err
+	}
+	fmt.Println("✓ Cleared the branch catalogue")
+	return nil
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/printer"
+)
+
+var (
+	changelogFrom     string
+	changelogTo       string
+	changelogFormat   string
+	changelogGroupBy  string
+	changelogTemplate string
+	changelogOutput   string
+)
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generate release notes from features closed between two refs",
+	Long: `Generate release notes from the feature versions closed between two Git refs.
+
+A version is listed when it is closed at --to but was not closed at --from.
+Features are matched by ID, so renames and features merged in from other
+branches are picked up. Each entry includes the commits in the range that
+touched the feature.
+
+Entries are grouped by feature type unless --group-by or the changelog section
+of .fogit/config.yml says otherwise:
+
+  changelog:
+    entry_template: "- {{.Name}} (v{{.Version}})"
+    sections:
+      - title: Breaking changes
+        filter: "tags:breaking"
+      - title: Features
+        filter: "type:software-feature"
+      - title: Fixes
+        filter: "type:bugfix"
+        template: "- Fixed {{.Name}}"
+
+Each entry goes into the first section whose filter matches; the rest go into
+"Other". Templates use Go text/template syntax with the fields .ID, .Name,
+.Version, .Type, .Category, .Priority, .Branch, .ClosedAt, .Authors, .Notes
+and .Commits.
+
+Examples:
+  # Release notes between two tags
+  fogit changelog --from v1.2.0 --to v1.3.0
+
+  # Everything closed since the last release, grouped by category
+  fogit changelog --from v1.3.0 --group-by category
+
+  # Machine-readable output for a release pipeline
+  fogit changelog --from v1.2.0 --to v1.3.0 --format json -o notes.json`,
+	Args: cobra.NoArgs,
+	RunE: runChangelog,
+}
+
+func init() {
+	changelogCmd.Flags().StringVar(&changelogFrom, "from", "", "Start ref, exclusive (default: all history)")
+	changelogCmd.Flags().StringVar(&changelogTo, "to", "HEAD", "End ref, inclusive")
+	changelogCmd.Flags().StringVar(&changelogFormat, "format", printer.ChangelogFormatMarkdown, "Output format: markdown, json")
+	changelogCmd.Flags().StringVar(&changelogGroupBy, "group-by", "", "Group by type, category, priority or a metadata key (ignores configured sections)")
+	changelogCmd.Flags().StringVar(&changelogTemplate, "template", "", "Entry template (Go text/template), overrides changelog.entry_template")
+	changelogCmd.Flags().StringVarP(&changelogOutput, "output", "o", "", "Output file (default: stdout)")
+	rootCmd.AddCommand(changelogCmd)
+}
+
+func runChangelog(cmd *cobra.Command, args []string) error {
+	if changelogFormat != printer.ChangelogFormatMarkdown && changelogFormat != printer.ChangelogFormatJSON {
+		return fmt.Errorf("invalid format: must be one of markdown, json")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	if cmdCtx.Git == nil {
+		return fmt.Errorf("changelog requires a git repository")
+	}
+
+	opts := features.ChangelogOptions{
+		From:         changelogFrom,
+		To:           changelogTo,
+		GroupBy:      changelogGroupBy,
+		RelationType: cmdCtx.Config.ResolveRelationshipType,
+	}
+	entryTemplate := changelogTemplate
+	if cfg := cmdCtx.Config.Changelog; cfg != nil {
+		if changelogGroupBy == "" {
+			opts.Config = cfg
+		}
+		if entryTemplate == "" {
+			entryTemplate = cfg.EntryTemplate
+		}
+	}
+
+	changelog, err := features.BuildChangelog(cmdCtx.Git.GetGitRepo(), opts)
+	if err != nil {
+		return err
+	}
+
+	write := func(w io.Writer) error {
+		if changelogFormat == printer.ChangelogFormatJSON {
+			return printer.OutputAsJSON(w, changelog)
+		}
+		return printer.OutputChangelogMarkdown(w, changelog, entryTemplate)
+	}
+
+	if changelogOutput != "" {
+		if err := common.ValidateOutputPath(changelogOutput); err != nil {
+			return err
+		}
+		return common.AtomicWriteFile(changelogOutput, func(f *os.File) error {
+			return write(f)
+		})
+	}
+	return write(os.Stdout)
+}
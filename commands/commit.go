@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/eg3r/fogit/internal/config"
 	"github.com/eg3r/fogit/internal/features"
 	"github.com/eg3r/fogit/internal/git"
 )
@@ -28,6 +29,15 @@ This command:
 - Updates the feature file with new timestamps
 - Extracts and records commit authors
 - Optionally links changed files to the feature
+- Appends trailers linking the commit to each feature on the branch:
+
+    Fogit-Feature: <feature id>
+    Fogit-Version: <version>
+
+  'fogit log --feature' uses these to find a feature's commits, even after
+  renames. Change the keys with commit_trailers.feature_key and
+  commit_trailers.version_key ('-' omits the version), or turn them off with
+  commit_trailers.enabled false.
 
 Examples:
   fogit commit -m "Add authentication features"
@@ -73,12 +83,18 @@ func runCommit(cmd *cobra.Command, args []string) error {
 	// Open repository
 	repo := getRepository(fogitDir)
 
+	cfg, err := config.Load(fogitDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
 	// Prepare commit options
 	opts := features.CommitOptions{
 		Message:    commitMessage,
 		Author:     commitAuthor,
 		AutoLink:   commitAutoLink,
 		AllowDirty: commitAllowDirty,
+		Trailers:   cfg.CommitTrailers,
 	}
 
 	// Execute commit
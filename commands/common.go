@@ -20,6 +20,7 @@ type CommandContext struct {
 	Repo     fogit.Repository
 	Config   *fogit.Config
 	Git      *features.GitIntegration // nil if not in git repo
+	At       string                   // Commit features are read from (--at); empty for the working tree
 }
 
 // GetCommandContext initializes all common dependencies for a command
@@ -30,7 +31,7 @@ func GetCommandContext() (*CommandContext, error) {
 		return nil, err
 	}
 
-	repo := storage.NewFileRepository(fogitDir)
+	var repo fogit.Repository = storage.NewFileRepository(fogitDir)
 
 	cfg, err := config.Load(fogitDir)
 	if err != nil {
@@ -45,11 +46,26 @@ func GetCommandContext() (*CommandContext, error) {
 		logger.Debug("git integration not available", "error", gitErr)
 	}
 
+	// With --at, features come from a commit instead of the working tree
+	var at string
+	if atRef != "" {
+		if gitIntegration == nil || gitIntegration.GetGitRepo() == nil {
+			return nil, fmt.Errorf("--at requires a git repository")
+		}
+		at, err = features.ResolvePointInTime(gitIntegration.GetGitRepo(), atRef)
+		if err != nil {
+			return nil, err
+		}
+		logger.Debug("reading features at commit", "at", atRef, "commit", at)
+		repo = features.NewTreeRepository(gitIntegration.GetGitRepo(), at)
+	}
+
 	return &CommandContext{
 		FogitDir: fogitDir,
 		Repo:     repo,
 		Config:   cfg,
 		Git:      gitIntegration,
+		At:       at,
 	}, nil
 }
 
@@ -69,6 +85,12 @@ func GetCommandContextWithoutConfig() (*CommandContext, error) {
 	}, nil
 }
 
+// CrossBranch reports whether features should be discovered across branches:
+// in branch-per-feature mode with git available, unless reading a single commit via --at
+func (c *CommandContext) CrossBranch() bool {
+	return c.At == "" && c.Config.Workflow.Mode == "branch-per-feature" && c.Git != nil && c.Git.GetGitRepo() != nil
+}
+
 // getRepository creates a file repository for the given .fogit directory
 // This is the legacy helper - prefer using GetCommandContext for new code
 func getRepository(fogitDir string) fogit.Repository {
@@ -133,7 +155,7 @@ func FindFeatureCrossBranchWithResult(ctx context.Context, cmdCtx *CommandContex
 	cfg := cmdCtx.Config
 
 	// Use cross-branch discovery in branch-per-feature mode
-	if cfg.Workflow.Mode == "branch-per-feature" && cmdCtx.Git != nil && cmdCtx.Git.GetGitRepo() != nil {
+	if cmdCtx.CrossBranch() {
 		result, err := features.FindAcrossBranches(ctx, cmdCtx.Repo, cmdCtx.Git.GetGitRepo(), identifier, cfg)
 		if err != nil {
 			if err == fogit.ErrNotFound && result != nil && len(result.Suggestions) > 0 {
@@ -177,10 +199,8 @@ func FindFeatureCrossBranchWithResult(ctx context.Context, cmdCtx *CommandContex
 // ListFeaturesCrossBranch lists all features using cross-branch discovery in branch-per-feature mode.
 // Falls back to current-branch-only listing in trunk-based mode or if git is not available.
 func ListFeaturesCrossBranch(ctx context.Context, cmdCtx *CommandContext, filter *fogit.Filter) ([]*fogit.Feature, error) {
-	cfg := cmdCtx.Config
-
 	// Use cross-branch discovery in branch-per-feature mode
-	if cfg.Workflow.Mode == "branch-per-feature" && cmdCtx.Git != nil && cmdCtx.Git.GetGitRepo() != nil {
+	if cmdCtx.CrossBranch() {
 		crossBranchFeatures, err := features.ListFeaturesAcrossBranches(ctx, cmdCtx.Repo, cmdCtx.Git.GetGitRepo())
 		if err != nil {
 			return nil, fmt.Errorf("failed to list features across branches: %w", err)
@@ -199,3 +219,21 @@ func ListFeaturesCrossBranch(ctx context.Context, cmdCtx *CommandContext, filter
 	// Fallback to current branch only
 	return cmdCtx.Repo.List(ctx, filter)
 }
+
+// listAllFeatures lists every known feature: across branches when
+// cmdCtx.CrossBranch(), otherwise from the current branch only
+func listAllFeatures(ctx context.Context, cmdCtx *CommandContext) ([]*fogit.Feature, error) {
+	if cmdCtx.CrossBranch() {
+		featuresList, err := ListFeaturesCrossBranch(ctx, cmdCtx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list features across branches: %w", err)
+		}
+		return featuresList, nil
+	}
+
+	featuresList, err := cmdCtx.Repo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features: %w", err)
+	}
+	return featuresList, nil
+}
@@ -35,6 +35,9 @@ Supported configuration keys:
   feature_search.min_similarity     - Minimum similarity threshold (0.0-1.0)
   feature_search.max_suggestions    - Maximum suggestions to show
   default_priority                  - Default feature priority
+  commit_trailers.enabled           - Append feature trailers to commits (true/false)
+  commit_trailers.feature_key       - Trailer key for the feature ID
+  commit_trailers.version_key       - Trailer key for the version ('-' to omit)
 
 Examples:
   fogit config --list
@@ -134,6 +137,13 @@ func listConfig(cfg *fogit.Config) error {
 	fmt.Printf("  max_suggestions = %d\n", cfg.FeatureSearch.MaxSuggestions)
 	fmt.Println()
 
+	// Commit trailer settings
+	fmt.Println("[commit_trailers]")
+	fmt.Printf("  enabled = %v\n", cfg.CommitTrailers.IsEnabled())
+	fmt.Printf("  feature_key = %s\n", cfg.CommitTrailers.FeatureTrailerKey())
+	fmt.Printf("  version_key = %s\n", cfg.CommitTrailers.VersionKey)
+	fmt.Println()
+
 	// Default priority
 	if cfg.DefaultPriority != "" {
 		fmt.Println("[defaults]")
@@ -207,6 +217,12 @@ func getConfigValue(cfg *fogit.Config, key string) (string, error) {
 			return "", fmt.Errorf("key not set: %s", key)
 		}
 		return cfg.DefaultPriority, nil
+	case "commit_trailers.enabled":
+		return fmt.Sprintf("%v", cfg.CommitTrailers.IsEnabled()), nil
+	case "commit_trailers.feature_key":
+		return cfg.CommitTrailers.FeatureTrailerKey(), nil
+	case "commit_trailers.version_key":
+		return cfg.CommitTrailers.VersionKey, nil
 	default:
 		return "", fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -267,6 +283,15 @@ func setConfigValue(cfg *fogit.Config, key, value string) error {
 			return fmt.Errorf("invalid priority: %s (must be one of: low, medium, high, critical)", value)
 		}
 		cfg.DefaultPriority = value
+	case "commit_trailers.enabled":
+		enabled := parseBool(value)
+		cfg.CommitTrailers.Enabled = &enabled
+	case "commit_trailers.feature_key":
+		cfg.CommitTrailers.FeatureKey = value
+		return cfg.Validate()
+	case "commit_trailers.version_key":
+		cfg.CommitTrailers.VersionKey = value
+		return cfg.Validate()
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
@@ -294,6 +319,12 @@ func unsetConfigValue(cfg *fogit.Config, key string) error {
 		cfg.FeatureSearch.MaxSuggestions = 5 // Reset to default
 	case "default_priority":
 		cfg.DefaultPriority = ""
+	case "commit_trailers.enabled":
+		cfg.CommitTrailers.Enabled = nil // Reset to default
+	case "commit_trailers.feature_key":
+		cfg.CommitTrailers.FeatureKey = fogit.DefaultFeatureTrailer // Reset to default
+	case "commit_trailers.version_key":
+		cfg.CommitTrailers.VersionKey = fogit.DefaultVersionTrailer // Reset to default
 	default:
 		return fmt.Errorf("unknown configuration key: %s", key)
 	}
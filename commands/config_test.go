@@ -404,6 +404,45 @@ func TestConfigSet(t *testing.T) {
 			value:   "super-high",
 			wantErr: true,
 		},
+		{
+			name:    "set commit_trailers.enabled false",
+			key:     "commit_trailers.enabled",
+			value:   "false",
+			wantErr: false,
+			check: func(t *testing.T, cfg *fogit.Config) {
+				if cfg.CommitTrailers.IsEnabled() {
+					t.Error("CommitTrailers.IsEnabled() = true, want false")
+				}
+			},
+		},
+		{
+			name:    "set commit_trailers.feature_key",
+			key:     "commit_trailers.feature_key",
+			value:   "Feature-Id",
+			wantErr: false,
+			check: func(t *testing.T, cfg *fogit.Config) {
+				if cfg.CommitTrailers.FeatureTrailerKey() != "Feature-Id" {
+					t.Errorf("FeatureTrailerKey() = %s, want Feature-Id", cfg.CommitTrailers.FeatureTrailerKey())
+				}
+			},
+		},
+		{
+			name:    "set commit_trailers.feature_key invalid",
+			key:     "commit_trailers.feature_key",
+			value:   "Feature Id:",
+			wantErr: true,
+		},
+		{
+			name:    "set commit_trailers.version_key omitted",
+			key:     "commit_trailers.version_key",
+			value:   "-",
+			wantErr: false,
+			check: func(t *testing.T, cfg *fogit.Config) {
+				if cfg.CommitTrailers.VersionTrailerKey() != "" {
+					t.Errorf("VersionTrailerKey() = %s, want empty", cfg.CommitTrailers.VersionTrailerKey())
+				}
+			},
+		},
 		{
 			name:    "set unknown key",
 			key:     "unknown.key",
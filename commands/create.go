@@ -27,6 +27,9 @@ Examples:
   # Create with organization metadata
   fogit create "Payment Integration" --category billing --team payments --epic checkout
 
+  # Create from a template
+  fogit create "Get Orders" --template api-endpoint
+
   # Create on isolated branch (new Git branch)
   fogit create "Experimental Feature" --isolate
 
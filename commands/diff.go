@@ -14,7 +14,7 @@ import (
 
 var diffCmd = &cobra.Command{
 	Use:   "diff <feature> [version1] [version2]",
-	Short: "Compare feature versions",
+	Short: "Compare feature versions or the features at two refs",
 	Long: `Compare two versions of a feature to see what changed.
 
 If no versions are specified, compares the current version with the previous one.
@@ -26,33 +26,52 @@ The command shows differences in:
 - Authors
 - Notes
 
+With --refs, the whole feature set is compared between two Git revisions
+instead: features added, removed or renamed, state changes, metadata, tag
+and relationship changes. Features are matched by ID, so renamed files show
+up as renames. Use <from>..<to> to compare the two revisions directly, or
+<from>...<to> to compare <to> with the point where it branched off <from>,
+which is what a pull request changes. An omitted side means HEAD.
+
 Examples:
   fogit diff "User Authentication"           # Compare current vs previous version
   fogit diff "User Auth" 1 2                 # Compare version 1 and version 2
   fogit diff "API Core" 1.0.0 2.0.0          # Compare semantic versions
   fogit diff "Feature" --format json         # Output as JSON
-  fogit diff "Feature" --format yaml         # Output as YAML`,
-	Args: cobra.RangeArgs(1, 3),
+  fogit diff "Feature" --format yaml         # Output as YAML
+  fogit diff --refs main...feature/x         # Feature-level impact of a branch
+  fogit diff --refs v1.0.0..v1.1.0 --format json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if diffRefs != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.RangeArgs(1, 3)(cmd, args)
+	},
 	RunE: runDiff,
 }
 
 var (
 	diffFormat string
+	diffRefs   string
 )
 
 func init() {
 	diffCmd.Flags().StringVar(&diffFormat, "format", "text", "Output format (text, json, yaml)")
+	diffCmd.Flags().StringVar(&diffRefs, "refs", "", "Compare all features between two revisions (<from>..<to> or <from>...<to>)")
 	rootCmd.AddCommand(diffCmd)
 }
 
 func runDiff(cmd *cobra.Command, args []string) error {
-	nameOrID := args[0]
-
 	// Validate format
 	if diffFormat != "text" && diffFormat != "json" && diffFormat != "yaml" {
 		return fmt.Errorf("unsupported format: %s (use text, json, or yaml)", diffFormat)
 	}
 
+	if diffRefs != "" {
+		return runDiffRefs()
+	}
+	nameOrID := args[0]
+
 	// Get command context
 	cmdCtx, err := GetCommandContext()
 	if err != nil {
@@ -155,3 +174,77 @@ func outputDiffText(w io.Writer, diff *features.VersionDiff) error {
 
 	return nil
 }
+
+func runDiffRefs() error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if cmdCtx.Git == nil {
+		return fmt.Errorf("--refs requires a git repository")
+	}
+
+	diff, err := features.DiffRefs(cmdCtx.Git.GetGitRepo(), diffRefs)
+	if err != nil {
+		return err
+	}
+
+	textFn := func(w io.Writer) error {
+		return outputFeatureSetDiffText(w, diffRefs, diff)
+	}
+	return printer.OutputFormatted(os.Stdout, diffFormat, diff, textFn)
+}
+
+func outputFeatureSetDiffText(w io.Writer, spec string, diff *features.FeatureSetDiff) error {
+	fmt.Fprintf(w, "Feature diff: %s (%.8s..%.8s)\n", spec, diff.FromCommit, diff.ToCommit)
+	fmt.Fprintln(w, strings.Repeat("─", 60))
+
+	if !diff.HasDifferences() {
+		fmt.Fprintln(w, "\nNo feature changes.")
+		return nil
+	}
+
+	if len(diff.Added) > 0 {
+		fmt.Fprintf(w, "\nAdded (%d):\n", len(diff.Added))
+		for _, f := range diff.Added {
+			fmt.Fprintf(w, "  + %s [%s]\n", f.Name, f.State)
+		}
+	}
+
+	if len(diff.Removed) > 0 {
+		fmt.Fprintf(w, "\nRemoved (%d):\n", len(diff.Removed))
+		for _, f := range diff.Removed {
+			fmt.Fprintf(w, "  - %s [%s]\n", f.Name, f.State)
+		}
+	}
+
+	if len(diff.Changed) > 0 {
+		fmt.Fprintf(w, "\nChanged (%d):\n", len(diff.Changed))
+		for _, f := range diff.Changed {
+			fmt.Fprintf(w, "  ~ %s\n", f.Name)
+			if f.OldPath != "" {
+				fmt.Fprintf(w, "      renamed: %s -> %s\n", f.OldPath, f.Path)
+			}
+			if f.NewState != "" {
+				fmt.Fprintf(w, "      state: %s -> %s\n", f.OldState, f.NewState)
+			}
+			for _, change := range f.Changes {
+				if change.Field == "state" {
+					continue // Shown above
+				}
+				line := features.GetChangeSymbol(change.ChangeType) + " " + change.Field
+				switch {
+				case change.ChangeType == "modified":
+					line += ": " + change.OldValue + " -> " + change.NewValue
+				case change.NewValue != "":
+					line += ": " + change.NewValue
+				case change.OldValue != "":
+					line += ": " + change.OldValue
+				}
+				fmt.Fprintf(w, "      %s\n", line)
+			}
+		}
+	}
+
+	return nil
+}
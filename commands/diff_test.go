@@ -54,6 +54,17 @@ func TestDiffCommandArgs(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("refs takes no feature", func(t *testing.T) {
+		diffRefs = "main..feature/x"
+		defer func() { diffRefs = "" }()
+		if err := diffCmd.Args(diffCmd, []string{}); err != nil {
+			t.Errorf("Args() with --refs error = %v", err)
+		}
+		if err := diffCmd.Args(diffCmd, []string{"Feature Name"}); err == nil {
+			t.Error("Args() with --refs and a feature should fail")
+		}
+	})
 }
 
 // TestDiffCommandFlags tests that diff command flags are defined correctly
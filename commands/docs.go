@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/docs"
+)
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate feature documentation",
+}
+
+var (
+	docsOut     string
+	docsFormat  string
+	docsGroupBy string
+	docsClean   bool
+)
+
+var docsBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Render a static documentation site for all features",
+	Long: `Render a static documentation site from the features on the current branch.
+
+The site has one page per feature (description, metadata, version history,
+files, and incoming and outgoing relationships with links) plus an index and
+one page per group. Features are grouped by --group-by, which defaults to
+ui.default_group_by from the config. Use state, tag, priority, or any metadata key.
+
+Output is deterministic: building unchanged features produces identical files,
+so the site can be committed or published from CI.
+
+Examples:
+  # Markdown into site/
+  fogit docs build
+
+  # HTML grouped by state, removing stale pages first. Only files listed in
+  # the .fogit-docs manifest of an earlier build are removed.
+  fogit docs build --out public --format html --group-by state --clean`,
+	Args: cobra.NoArgs,
+	RunE: runDocsBuild,
+}
+
+func init() {
+	docsBuildCmd.Flags().StringVar(&docsOut, "out", "site", "Output directory")
+	docsBuildCmd.Flags().StringVar(&docsFormat, "format", docs.FormatMarkdown, "Output format: markdown, html")
+	docsBuildCmd.Flags().StringVar(&docsGroupBy, "group-by", "", "Group index pages by state, tag, priority or a metadata key (default: ui.default_group_by)")
+	docsBuildCmd.Flags().BoolVar(&docsClean, "clean", false, "Remove the files of the previous build before building")
+
+	docsCmd.AddCommand(docsBuildCmd)
+	rootCmd.AddCommand(docsCmd)
+}
+
+func runDocsBuild(cmd *cobra.Command, args []string) error {
+	if docsFormat != docs.FormatMarkdown && docsFormat != docs.FormatHTML {
+		return fmt.Errorf("invalid format: must be one of markdown, html")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	groupBy := docsGroupBy
+	if groupBy == "" {
+		groupBy = cmdCtx.Config.UI.DefaultGroupBy
+	}
+
+	ctx, cancel := WithListTimeout(cmd.Context())
+	defer cancel()
+
+	featuresList, err := cmdCtx.Repo.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list features: %w", err)
+	}
+
+	if docsClean {
+		if err := docs.Clean(docsOut); err != nil {
+			return err
+		}
+	}
+
+	result, err := docs.Build(featuresList, docs.Options{OutDir: docsOut, Format: docsFormat, GroupBy: groupBy})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote %d feature pages and %d group pages to %s\n", result.FeaturePages, result.GroupPages, docsOut)
+	if result.BrokenLinks > 0 {
+		fmt.Printf("Warning: %d relationships point to features that don't exist\n", result.BrokenLinks)
+	}
+	return nil
+}
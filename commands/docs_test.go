@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/config"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestDocsBuildCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	fogitDir := filepath.Join(tmpDir, ".fogit")
+	if err := os.MkdirAll(fogitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := fogit.DefaultConfig()
+	cfg.UI.DefaultGroupBy = "priority"
+	if err := config.Save(fogitDir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := storage.NewFileRepository(fogitDir)
+	feature := fogit.NewFeature("Checkout")
+	feature.SetPriority(fogit.PriorityHigh)
+	if err := repo.Create(context.Background(), feature); err != nil {
+		t.Fatal(err)
+	}
+
+	outDir := filepath.Join(tmpDir, "site")
+	build := func(args ...string) error {
+		ResetFlags()
+		rootCmd.SetArgs(append([]string{"-C", tmpDir, "docs", "build", "--out", outDir}, args...))
+		var err error
+		captureStdout(t, func() { err = ExecuteRootCmd() })
+		return err
+	}
+
+	// A page from an earlier build of a feature that has since been deleted
+	removed := fogit.NewFeature("Removed")
+	if err := repo.Create(context.Background(), removed); err != nil {
+		t.Fatal(err)
+	}
+	if err := build(); err != nil {
+		t.Fatalf("docs build error = %v", err)
+	}
+	if err := repo.Delete(context.Background(), removed.ID); err != nil {
+		t.Fatal(err)
+	}
+	stale := filepath.Join(outDir, "features", "removed.md")
+	if _, err := os.Stat(stale); err != nil {
+		t.Fatalf("expected page of the first build: %v", err)
+	}
+	own := filepath.Join(outDir, "CNAME")
+	if err := os.WriteFile(own, []byte("docs.example.com"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := build("--clean"); err != nil {
+		t.Fatalf("docs build --clean error = %v", err)
+	}
+	for _, path := range []string{"index.md", "features/checkout.md", "groups/high.md", ".fogit-docs"} {
+		if _, err := os.Stat(filepath.Join(outDir, path)); err != nil {
+			t.Errorf("expected %s: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Error("--clean should remove pages from earlier builds")
+	}
+	if _, err := os.Stat(own); err != nil {
+		t.Errorf("--clean should keep files it didn't write: %v", err)
+	}
+
+	// A directory that wasn't built by fogit is never cleaned
+	outDir = filepath.Join(tmpDir, "notes")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	notes := filepath.Join(outDir, "todo.txt")
+	if err := os.WriteFile(notes, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := build("--clean"); err == nil {
+		t.Error("expected --clean to refuse a directory without a build manifest")
+	}
+	if _, err := os.Stat(notes); err != nil {
+		t.Errorf("refused --clean removed files: %v", err)
+	}
+}
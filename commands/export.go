@@ -32,7 +32,8 @@ Examples:
   fogit export json > features.json     # Export to file
   fogit export yaml --output data.yaml  # Export to file
   fogit export csv --state open         # Export only open features
-  fogit export json --tag security      # Export features with tag`,
+  fogit export json --tag security      # Export features with tag
+  fogit export json --filter "priority>=high AND has(metadata.jira)"`,
 	Args: cobra.ExactArgs(1),
 	RunE: runExport,
 }
@@ -43,6 +44,7 @@ var (
 	exportType     string
 	exportCategory string
 	exportTags     []string
+	exportFilter   string
 	exportPretty   bool
 )
 
@@ -52,6 +54,7 @@ func init() {
 	exportCmd.Flags().StringVar(&exportType, "type", "", "Filter by type")
 	exportCmd.Flags().StringVar(&exportCategory, "category", "", "Filter by category")
 	exportCmd.Flags().StringSliceVar(&exportTags, "tag", nil, "Filter by tag (can be repeated)")
+	exportCmd.Flags().StringVar(&exportFilter, "filter", "", "Filter expression (see 'fogit filter --help')")
 	exportCmd.Flags().BoolVar(&exportPretty, "pretty", true, "Pretty-print output (default: true)")
 	rootCmd.AddCommand(exportCmd)
 }
@@ -77,6 +80,11 @@ func runExport(cmd *cobra.Command, args []string) error {
 		Tags:     exportTags,
 	}
 
+	expr, err := parseFilterExpression(exportFilter, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
 	// Apply timeout for export operation
 	ctx, cancel := WithExportTimeout(cmd.Context())
 	defer cancel()
@@ -86,6 +94,10 @@ func runExport(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to list features: %w", err)
 	}
+	featuresList, err = applyFilterExpression(ctx, cmdCtx, expr, featuresList, nil)
+	if err != nil {
+		return err
+	}
 
 	// Export using service with pre-loaded features
 	opts := exchange.ExportOptions{
@@ -36,12 +36,18 @@ Examples:
   # Create with organization metadata
   fogit feature "Payment Integration" --category billing --team payments --epic checkout
 
+  # Create from a template (flags override template defaults)
+  fogit feature "Get Orders" --template api-endpoint --metadata owner=alice
+
   # Create on isolated branch (new Git branch)
   fogit feature "Experimental Feature" --isolate
 
   # Create on same branch (shared strategy)
   fogit feature "Quick Fix" --same
 
+  # Build on a feature that isn't merged yet (stacked branch)
+  fogit feature "Login Page" --stack-on "User Authentication"
+
   # Reopen a closed feature with new version
   fogit feature "User Auth" --minor
   fogit feature "User Auth" --version 2.0.0`,
@@ -76,9 +82,11 @@ var (
 	featureTags        []string
 	featureMetadata    []string // key=value pairs
 	featureParent      string
+	featureTemplate    string // Template name from .fogit/templates
 	featureSame        bool   // Stay on current branch (shared strategy)
 	featureIsolate     bool   // Create new branch (isolated strategy)
 	featureFromCurrent bool   // Override create_branch_from, create from current branch
+	featureStackOn     string // Unmerged feature to depend on and branch from
 	featureVersion     string // Explicit version number (e.g., "5" or "2.0.0")
 	featurePatch       bool   // Increment patch version (semantic only)
 	featureMinor       bool   // Increment minor version
@@ -100,9 +108,11 @@ func registerFeatureFlags(cmd *cobra.Command) {
 	cmd.Flags().StringSliceVar(&featureTags, "tags", []string{}, "Comma-separated tags")
 	cmd.Flags().StringSliceVar(&featureMetadata, "metadata", []string{}, "Custom metadata (key=value, repeatable)")
 	cmd.Flags().StringVar(&featureParent, "parent", "", "Parent feature ID or name")
+	cmd.Flags().StringVar(&featureTemplate, "template", "", "Apply defaults from .fogit/templates/<name>.yml (see 'fogit template list')")
 	cmd.Flags().BoolVar(&featureSame, "same", false, "Stay on current branch (shared strategy, requires allow_shared_branches: true)")
 	cmd.Flags().BoolVar(&featureIsolate, "isolate", false, "Create new branch (isolated strategy, overrides default)")
 	cmd.Flags().BoolVar(&featureFromCurrent, "from-current", false, "Create branch from current branch (overrides workflow.create_branch_from)")
+	cmd.Flags().StringVar(&featureStackOn, "stack-on", "", "Depend on an unmerged feature and create the branch from its branch (see 'fogit restack')")
 }
 
 func init() {
@@ -144,7 +154,7 @@ func runFeature(cmd *cobra.Command, args []string) error {
 		if strings.EqualFold(f.Name, name) {
 			// Feature exists
 			state := f.DeriveState()
-			if state == fogit.StateClosed {
+			if f.IsClosed() {
 				// Feature is closed - handle versioning per spec
 				return handleClosedFeature(cmd, f, cmdCtx)
 			}
@@ -294,12 +304,26 @@ func runFeatureCreate(cmd *cobra.Command, args []string) error {
 }
 
 func createFeature(cmd *cobra.Command, name string, cmdCtx *CommandContext) error {
+	var tpl *fogit.FeatureTemplate
+	priority := featurePriority
+	if featureTemplate != "" {
+		var err error
+		tpl, err = storage.LoadTemplate(cmdCtx.FogitDir, featureTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to load template: %w", err)
+		}
+		// The template priority wins over the flag default, but not over an explicit flag
+		if tpl.Priority != "" && !cmd.Flags().Changed("priority") {
+			priority = tpl.Priority
+		}
+	}
+
 	// Prepare options
 	opts := features.CreateOptions{
 		Name:          name,
 		Description:   featureDescription,
 		Type:          featureType,
-		Priority:      featurePriority,
+		Priority:      priority,
 		Category:      featureCategory,
 		Domain:        featureDomain,
 		Team:          featureTeam,
@@ -307,11 +331,20 @@ func createFeature(cmd *cobra.Command, name string, cmdCtx *CommandContext) erro
 		Module:        featureModule,
 		Tags:          featureTags,
 		ParentID:      featureParent,
+		Template:      tpl,
 		SameBranch:    featureSame,
 		IsolateBranch: featureIsolate,
 		FromCurrent:   featureFromCurrent,
 	}
 
+	if featureStackOn != "" {
+		dependency, err := FindFeatureCrossBranch(cmd.Context(), cmdCtx, featureStackOn, "fogit feature <name> --stack-on <id>")
+		if err != nil {
+			return err
+		}
+		opts.StackOn = dependency
+	}
+
 	// Parse metadata key=value pairs
 	if len(featureMetadata) > 0 {
 		opts.Metadata = make(map[string]interface{})
@@ -348,6 +381,9 @@ func createFeature(cmd *cobra.Command, name string, cmdCtx *CommandContext) erro
 	if len(feature.Tags) > 0 {
 		fmt.Printf("  Tags: %v\n", feature.Tags)
 	}
+	if cv := feature.GetCurrentVersion(); cv != nil && cv.ParentBranch != "" {
+		fmt.Printf("  Stacked on: %s\n", cv.ParentBranch)
+	}
 
 	// Calculate the filename that was used
 	existingFiles := make(map[string]bool)
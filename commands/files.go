@@ -57,7 +57,7 @@ func runFiles(cmd *cobra.Command, args []string) error {
 		State: fogit.State(filesState),
 	}
 
-	if validateErr := filter.Validate(); validateErr != nil {
+	if validateErr := filter.ValidateWith(cmdCtx.Config.Workflow.StateMachine()); validateErr != nil {
 		return validateErr
 	}
 
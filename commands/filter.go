@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -25,8 +28,12 @@ Expression syntax:
   Core field matching:    state:open, name:*auth*, tags:security
   Metadata fields:        metadata.priority:high, metadata.category:auth
   Shorthand aliases:      priority:high → metadata.priority:high
-  Logical operators:      AND, OR, NOT
-  Comparisons:            created:>2025-01-01, priority:>=medium
+  Logical operators:      AND, OR, NOT (or !)
+  Comparisons:            created>2025-01-01, priority>=medium, metadata.points>3
+  Inequality:             state!=closed
+  Lists:                  state IN (open, in-progress), priority NOT IN (low)
+  Regular expressions:    name~/^api/ (case-insensitive)
+  Existence:              has(metadata.jira), has(depends-on)
   Wildcards:              name:*auth* (contains "auth")
   Grouping:               (priority:high OR priority:critical) AND state:open
 
@@ -34,11 +41,25 @@ Shorthand aliases (metadata.*):
   priority, type, category, domain, team, epic, module
 
 Comparison operators:
-  :    equals (or contains for arrays/wildcards)
-  :>   greater than
-  :<   less than
-  :>=  greater or equal
-  :<=  less or equal
+  : or =     equals (or contains for arrays/wildcards)
+  !=         not equal
+  > or :>    greater than
+  < or :<    less than
+  >= or :>=  greater or equal
+  <= or :<=  less or equal
+  ~          matches regular expression
+  IN (a, b)  equals one of the values
+
+Numbers are compared by value when both sides are numbers, dates (created,
+modified) as YYYY-MM-DD and priorities by rank.
+
+Relationship predicates start with a relationship type (or alias):
+  depends-on:"Auth"             has a depends-on relationship to "Auth" (name or ID)
+  blocked-by.state=open         a blocked-by target is open
+  incoming(required-by) > 3     more than 3 features have required-by relationships to it
+  outgoing() = 0                has no relationships
+
+The same expressions are accepted by --filter on list, search, export, tree and graph.
 
 Examples:
   # Filter by priority and state
@@ -64,6 +85,15 @@ Examples:
 
   # Priority comparison
   fogit filter "priority:>=medium AND state:open"
+
+  # Numeric metadata and regular expressions
+  fogit filter "metadata.points>=5 AND name~/^api/"
+
+  # Features depending on an open feature
+  fogit filter "depends-on.state=open"
+
+  # Widely required features
+  fogit filter "incoming(required-by) > 3"
 `,
 	Args: cobra.ExactArgs(1),
 	RunE: runFilter,
@@ -80,12 +110,6 @@ func init() {
 func runFilter(cmd *cobra.Command, args []string) error {
 	expression := args[0]
 
-	// Parse the filter expression
-	expr, err := fogit.ParseFilterExpr(expression)
-	if err != nil {
-		return fmt.Errorf("invalid filter expression: %w", err)
-	}
-
 	// Validate format
 	if !printer.IsValidFormat(filterFormat) {
 		return fmt.Errorf("invalid format: must be one of table, json, csv")
@@ -103,6 +127,12 @@ func runFilter(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Parse the filter expression
+	expr, err := parseFilterExpression(expression, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
 	// List all features using cross-branch discovery (no filter - we'll apply expression filter)
 	allFeatures, err := ListFeaturesCrossBranch(cmd.Context(), cmdCtx, nil)
 	if err != nil {
@@ -110,11 +140,9 @@ func runFilter(cmd *cobra.Command, args []string) error {
 	}
 
 	// Apply expression filter
-	var features []*fogit.Feature
-	for _, f := range allFeatures {
-		if expr.Matches(f) {
-			features = append(features, f)
-		}
+	features, err := applyFilterExpression(cmd.Context(), cmdCtx, expr, allFeatures, allFeatures)
+	if err != nil {
+		return err
 	}
 
 	// Sort features
@@ -140,3 +168,64 @@ func runFilter(cmd *cobra.Command, args []string) error {
 		return printer.OutputTable(os.Stdout, features)
 	}
 }
+
+// parseFilterExpression parses a filter expression. Relationship types and
+// aliases in it are resolved against the config, and syntax errors point at
+// the offending position.
+func parseFilterExpression(raw string, cfg *fogit.Config) (fogit.FilterExpr, error) {
+	var opts fogit.FilterParseOptions
+	if cfg != nil {
+		opts.RelationType = cfg.ResolveRelationshipType
+	}
+
+	expr, err := fogit.ParseFilterExprWith(raw, opts)
+	if err != nil {
+		if !errors.Is(err, fogit.ErrInvalidExpression) {
+			err = fmt.Errorf("invalid filter expression: %w", err)
+		}
+		var syntaxErr *fogit.FilterSyntaxError
+		if errors.As(err, &syntaxErr) {
+			pointer := strings.ReplaceAll(syntaxErr.Pointer(), "\n", "\n  ")
+			return nil, fmt.Errorf("%w\n  %s", err, pointer)
+		}
+		return nil, err
+	}
+	return expr, nil
+}
+
+// applyFilterExpression returns the features matching expr. Relationship
+// predicates are evaluated against all features, which are listed if nil.
+func applyFilterExpression(ctx context.Context, cmdCtx *CommandContext, expr fogit.FilterExpr, features, all []*fogit.Feature) ([]*fogit.Feature, error) {
+	if expr == nil {
+		return features, nil
+	}
+	if err := bindFilterExpression(ctx, cmdCtx, expr, all); err != nil {
+		return nil, err
+	}
+
+	var matched []*fogit.Feature
+	for _, f := range features {
+		if expr.Matches(f) {
+			matched = append(matched, f)
+		}
+	}
+	return matched, nil
+}
+
+// bindFilterExpression makes all features available to the relationship
+// predicates of expr, listing them if nil. Does nothing for expressions
+// without such predicates.
+func bindFilterExpression(ctx context.Context, cmdCtx *CommandContext, expr fogit.FilterExpr, all []*fogit.Feature) error {
+	if !fogit.UsesFilterGraph(expr) {
+		return nil
+	}
+	if all == nil {
+		var err error
+		all, err = ListFeaturesCrossBranch(ctx, cmdCtx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list features: %w", err)
+		}
+	}
+	fogit.BindFilterGraph(expr, all)
+	return nil
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+var (
+	graphFormat     string
+	graphTypes      []string
+	graphCategories []string
+	graphDepth      int
+	graphFilter     string
+	graphOutput     string
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph [feature]",
+	Short: "Export the relationship graph as DOT, Mermaid or GraphML",
+	Long: `Export the relationship graph for pasting into design docs and pull requests.
+
+Without a feature, the graph covers all features. With a feature, it covers the
+features within --depth relationships of it, in either direction.
+Nodes are styled by derived state and edges are labeled with the relationship type.
+When a relationship and its inverse both exist, only one edge is drawn.
+
+Formats:
+  dot      Graphviz (render with: dot -Tsvg)
+  mermaid  Mermaid flowchart (renders in GitHub and GitLab markdown)
+  graphml  GraphML (yEd, Gephi, networkx)
+  json     Nodes, edges and categories as JSON
+
+Examples:
+  # Whole graph as Graphviz SVG
+  fogit graph | dot -Tsvg -o features.svg
+
+  # Mermaid diagram of everything within two hops of a feature
+  fogit graph "User Authentication" --format mermaid --depth 2
+
+  # Only structural relationships between open features
+  fogit graph --category structural --filter "NOT state:closed"
+
+  # Hierarchy only, written to a file
+  fogit graph --type contains --format graphml -o hierarchy.graphml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runGraph,
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", "dot", "Output format: dot, mermaid, graphml, json")
+	graphCmd.Flags().StringSliceVar(&graphTypes, "type", nil, "Only include these relationship types (repeatable)")
+	graphCmd.Flags().StringSliceVar(&graphCategories, "category", nil, "Only include relationships in these categories (repeatable)")
+	graphCmd.Flags().IntVar(&graphDepth, "depth", -1, "Maximum distance from the feature (-1 for unlimited)")
+	graphCmd.Flags().StringVar(&graphFilter, "filter", "", "Only include features matching a filter expression (see 'fogit filter --help')")
+	graphCmd.Flags().StringVarP(&graphOutput, "output", "o", "", "Output file (default: stdout)")
+	rootCmd.AddCommand(graphCmd)
+}
+
+func runGraph(cmd *cobra.Command, args []string) error {
+	if !printer.IsValidGraphFormat(graphFormat) {
+		return fmt.Errorf("invalid format: must be one of dot, mermaid, graphml, json")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	cfg := cmdCtx.Config
+
+	var expr fogit.FilterExpr
+	if graphFilter != "" {
+		expr, err = parseFilterExpression(graphFilter, cfg)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, t := range graphTypes {
+		if _, ok := cfg.Relationships.Types[t]; !ok {
+			return fmt.Errorf("relationship type '%s' not defined in config", t)
+		}
+	}
+	for _, c := range graphCategories {
+		if _, ok := cfg.Relationships.Categories[c]; !ok {
+			return fmt.Errorf("relationship category '%s' not defined in config", c)
+		}
+	}
+
+	ctx, cancel := WithListTimeout(cmd.Context())
+	defer cancel()
+
+	allFeatures, err := ListFeaturesCrossBranch(ctx, cmdCtx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to list features: %w", err)
+	}
+
+	var root *fogit.Feature
+	if len(args) > 0 {
+		root, err = FindFeatureCrossBranch(ctx, cmdCtx, args[0], "fogit graph <id>")
+		if err != nil {
+			return err
+		}
+	}
+
+	included := allFeatures
+	if expr != nil {
+		fogit.BindFilterGraph(expr, allFeatures)
+		included = nil
+		for _, f := range allFeatures {
+			// The root stays in the graph even if it doesn't match
+			if expr.Matches(f) || (root != nil && f.ID == root.ID) {
+				included = append(included, f)
+			}
+		}
+	}
+
+	graph := features.BuildGraph(included, cfg, features.GraphOptions{Types: graphTypes, Categories: graphCategories})
+	if root != nil {
+		graph = graph.Subgraph(root.ID, graphDepth)
+	}
+
+	if graphOutput != "" {
+		if err := common.ValidateOutputPath(graphOutput); err != nil {
+			return err
+		}
+		return common.AtomicWriteFile(graphOutput, func(f *os.File) error {
+			return printer.OutputGraph(f, graphFormat, graph)
+		})
+	}
+
+	return printer.OutputGraph(os.Stdout, graphFormat, graph)
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/config"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestGraphCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	fogitDir := filepath.Join(tmpDir, ".fogit")
+	if err := os.MkdirAll(fogitDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.Save(fogitDir, fogit.DefaultConfig()); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := storage.NewFileRepository(fogitDir)
+	ctx := context.Background()
+
+	epic := fogit.NewFeature("Checkout")
+	cart := fogit.NewFeature("Cart")
+	payments := fogit.NewFeature("Payments")
+	unrelated := fogit.NewFeature("Reporting")
+	for _, f := range []*fogit.Feature{epic, cart, payments, unrelated} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cart.AddRelationship(fogit.NewRelationship("contained-by", epic.ID, epic.Name))
+	cart.AddRelationship(fogit.NewRelationship("depends-on", payments.ID, payments.Name))
+	if err := repo.Update(ctx, cart); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "dot",
+			args: []string{"graph"},
+			want: []string{"digraph features {", `[label="contained-by"]`, `[label="depends-on"]`, "Reporting"},
+		},
+		{
+			name:    "mermaid subgraph",
+			args:    []string{"graph", "Checkout", "--format", "mermaid", "--depth", "1"},
+			want:    []string{"flowchart LR", "Checkout", "Cart", "-->|contained-by|"},
+			notWant: []string{"Payments", "Reporting"},
+		},
+		{
+			name:    "relationship type",
+			args:    []string{"graph", "--type", "depends-on"},
+			want:    []string{`[label="depends-on"]`},
+			notWant: []string{"contained-by"},
+		},
+		{
+			name:    "filter expression",
+			args:    []string{"graph", "--format", "graphml", "--filter", "NOT name:Reporting"},
+			want:    []string{"<graphml", "Payments"},
+			notWant: []string{"Reporting"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ResetFlags()
+			rootCmd.SetArgs(append([]string{"-C", tmpDir}, tt.args...))
+
+			var err error
+			out := captureStdout(t, func() {
+				err = ExecuteRootCmd()
+			})
+			if err != nil {
+				t.Fatalf("graph command error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(out, want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(out, notWant) {
+					t.Errorf("output contains %q:\n%s", notWant, out)
+				}
+			}
+		})
+	}
+
+	ResetFlags()
+	rootCmd.SetArgs([]string{"-C", tmpDir, "graph", "--format", "svg"})
+	if err := ExecuteRootCmd(); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/printer"
+)
+
+var (
+	historyFormat  string
+	historyLimit   int
+	historyNoFiles bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <feature>",
+	Short: "Show every commit that touched a feature",
+	Long: `Show every commit that touched a feature, across all branches.
+
+The feature is followed by its ID rather than its file name, so the history
+survives renamed feature files, and commits on other branches or merged in
+from them are included. A commit is listed when it:
+
+  - changes the feature's YAML file (under any name it ever had)
+  - changes a file linked to the feature (now or in any earlier revision)
+  - carries the feature's commit trailer (see commit_trailers in the config)
+
+Examples:
+  fogit history "User Auth"
+  fogit history "User Auth" --no-files
+  fogit history 8f3c2a1e --format json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runHistory,
+}
+
+func init() {
+	historyCmd.Flags().StringVar(&historyFormat, "format", "text", "Output format: text, json")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 0, "Limit number of commits")
+	historyCmd.Flags().BoolVar(&historyNoFiles, "no-files", false, "Don't follow the feature's linked files")
+
+	rootCmd.AddCommand(historyCmd)
+}
+
+func runHistory(cmd *cobra.Command, args []string) error {
+	if historyFormat != "text" && historyFormat != "json" {
+		return fmt.Errorf("invalid format: must be one of text, json")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	if cmdCtx.Git == nil {
+		return fmt.Errorf("history requires a git repository")
+	}
+
+	ctx, cancel := WithHistoryTimeout(cmd.Context())
+	defer cancel()
+
+	feature, err := FindFeatureCrossBranch(ctx, cmdCtx, args[0], "fogit history <id>")
+	if err != nil {
+		return err
+	}
+
+	opts := features.HistoryOptions{
+		TrailerKey:   cmdCtx.Config.CommitTrailers.FeatureTrailerKey(),
+		IncludeFiles: !historyNoFiles,
+		Limit:        historyLimit,
+	}
+
+	history, err := features.BuildHistory(ctx, cmdCtx.Git.GetGitRepo(), feature, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build history: %w", err)
+	}
+
+	if historyFormat == "json" {
+		return printer.OutputAsJSON(os.Stdout, history)
+	}
+
+	if len(history.Commits) == 0 {
+		fmt.Printf("No commits found for %s\n", feature.Name)
+		return nil
+	}
+
+	for i, entry := range history.Commits {
+		if i > 0 {
+			fmt.Println()
+		}
+		header := "commit " + entry.Hash
+		if entry.Merge {
+			header += " (merge)"
+		}
+		fmt.Println(header)
+		fmt.Printf("Author: %s <%s>\n", entry.Author, entry.Email)
+		fmt.Printf("Date:   %s\n", entry.Date.Format(time.RFC1123))
+		fmt.Printf("\n    %s\n\n", entry.Subject)
+		switch {
+		case entry.RenamedFrom != "":
+			fmt.Printf("    renamed: %s -> %s\n", entry.RenamedFrom, entry.FeatureFile)
+		case entry.Deleted:
+			fmt.Printf("    deleted: %s\n", entry.FeatureFile)
+		case entry.FeatureFile != "":
+			fmt.Printf("    feature: %s\n", entry.FeatureFile)
+		}
+		if len(entry.Files) > 0 {
+			fmt.Printf("    files:   %s\n", strings.Join(entry.Files, ", "))
+		}
+		if entry.Trailer {
+			fmt.Println("    trailer")
+		}
+	}
+
+	fmt.Printf("\nTotal: %d commits\n", len(history.Commits))
+	return nil
+}
@@ -72,16 +72,25 @@ func runImpacts(cmd *cobra.Command, args []string) error {
 	// Determine which categories to include using the service
 	includedCategories := features.GetIncludedCategories(cfg, opts)
 
-	// Get all features using cross-branch discovery for impact analysis
-	allFeatures, err := ListFeaturesCrossBranch(cmd.Context(), cmdCtx, nil)
-	if err != nil {
-		return fmt.Errorf("failed to list features: %w", err)
-	}
+	var result *features.ImpactResult
+	if cmdCtx.CrossBranch() {
+		// Get all features using cross-branch discovery for impact analysis
+		allFeatures, err := ListFeaturesCrossBranch(cmd.Context(), cmdCtx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to list features: %w", err)
+		}
 
-	// Build impact analysis using the service with cross-branch features
-	result, err := features.AnalyzeImpactsWithFeatures(cmd.Context(), feature, allFeatures, cfg, includedCategories, impactsDepth)
-	if err != nil {
-		return fmt.Errorf("failed to analyze impacts: %w", err)
+		// Build impact analysis using the service with cross-branch features
+		result, err = features.AnalyzeImpactsWithFeatures(cmd.Context(), feature, allFeatures, cfg, includedCategories, impactsDepth)
+		if err != nil {
+			return fmt.Errorf("failed to analyze impacts: %w", err)
+		}
+	} else {
+		// Current branch only - follow the reverse-relationship index
+		result, err = features.AnalyzeImpacts(cmd.Context(), feature, cmdCtx.Repo, cfg, includedCategories, impactsDepth)
+		if err != nil {
+			return fmt.Errorf("failed to analyze impacts: %w", err)
+		}
 	}
 
 	// Format and output
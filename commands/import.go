@@ -21,7 +21,9 @@ Conflict Handling:
   --overwrite: Replace existing features with imported data
 
 The import validates that all relationship targets exist (either in the
-repository or in the import file) before making any changes.
+repository or in the import file) before making any changes. Features whose
+metadata does not match metadata_schema in config are reported as errors and
+not imported; schema defaults are filled in.
 
 Examples:
   fogit import features.json             # Import with error on conflicts
@@ -73,6 +75,7 @@ func runImport(cmd *cobra.Command, args []string) error {
 		Merge:     importMerge,
 		Overwrite: importOverwrite,
 		DryRun:    importDryRun,
+		Schema:    cmdCtx.Config.MetadataSchema,
 	}
 
 	result, err := exchange.Import(ctx, cmdCtx.Repo, importData, opts)
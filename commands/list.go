@@ -3,6 +3,8 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strings"
+	"text/template"
 
 	"github.com/spf13/cobra"
 
@@ -21,8 +23,12 @@ var (
 	listParent      string
 	listTags        []string
 	listContributor string
+	listFilter      string
 	listFormat      string
 	listSort        string
+	listOrder       string
+	listColumns     []string
+	listGroupBy     string
 	listAllBranches bool // Cross-branch discovery per spec
 )
 
@@ -56,10 +62,26 @@ Examples:
   fogit list --contributor alice@example.com
 
   # Sort and format
-  fogit list --sort priority --format json
+  fogit list --sort priority --order desc --format json
 
   # Multiple filters
   fogit list --state open --team security-team --epic user-management
+
+  # Choose columns and group by team, with per-group counts
+  fogit list --columns name,state,metadata.team,relationships.count --group-by metadata.team
+
+  # Group by ui.default_group_by from the config
+  fogit list --group-by default
+
+  # Go template output for scripting (\t and \n are expanded)
+  fogit list --format '{{.Name}}\t{{.GetPriority}}'
+  fogit list --format '{{.ID}} {{field . "metadata.jira"}}'
+
+  # Filter expression (see 'fogit filter --help')
+  fogit list --filter "priority IN (high, critical) AND depends-on.state!=closed"
+
+  # Save the listing as a shared view (see 'fogit view --help')
+  fogit view save my-sprint --state open --team backend --sort priority
 `,
 	RunE: runList,
 }
@@ -68,7 +90,7 @@ func init() {
 	rootCmd.AddCommand(listCmd)
 
 	// Filter flags
-	listCmd.Flags().StringVar(&listState, "state", "", "Filter by state (open, in-progress, closed)")
+	listCmd.Flags().StringVar(&listState, "state", "", "Filter by state (open, in-progress, closed, or a configured workflow state)")
 	listCmd.Flags().StringVar(&listPriority, "priority", "", "Filter by priority (low, medium, high, critical)")
 	listCmd.Flags().StringVar(&listType, "type", "", "Filter by type")
 	listCmd.Flags().StringVar(&listCategory, "category", "", "Filter by category")
@@ -78,10 +100,14 @@ func init() {
 	listCmd.Flags().StringVar(&listParent, "parent", "", "Show children of feature")
 	listCmd.Flags().StringSliceVar(&listTags, "tag", []string{}, "Filter by tag (can be used multiple times, AND logic)")
 	listCmd.Flags().StringVar(&listContributor, "contributor", "", "Filter by contributor email")
+	listCmd.Flags().StringVar(&listFilter, "filter", "", "Filter expression (see 'fogit filter --help')")
 
 	// Output flags
-	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table, json, csv")
+	listCmd.Flags().StringVar(&listFormat, "format", "table", "Output format: table, json, csv, or a Go template such as '{{.Name}}\\t{{.GetPriority}}'")
+	listCmd.Flags().StringSliceVar(&listColumns, "columns", nil, "Columns to show, e.g. name,state,metadata.team,relationships.count")
+	listCmd.Flags().StringVar(&listGroupBy, "group-by", "", "Group features by a field, with per-group counts (\"default\": ui.default_group_by)")
 	listCmd.Flags().StringVar(&listSort, "sort", "created", "Sort by field: name, priority, created, modified")
+	listCmd.Flags().StringVar(&listOrder, "order", "", "Sort order: asc, desc (default: asc)")
 
 	// Cross-branch discovery is automatic in branch-per-feature mode per spec/specification/07-git-integration.md
 	// This flag allows overriding to only show current branch
@@ -89,29 +115,33 @@ func init() {
 }
 
 func runList(cmd *cobra.Command, args []string) error {
-	// Build filter from flags
-	filter := &fogit.Filter{
-		State:       fogit.State(listState),
-		Priority:    fogit.Priority(listPriority),
-		Type:        listType,
-		Category:    listCategory,
-		Domain:      listDomain,
-		Team:        listTeam,
-		Epic:        listEpic,
-		Parent:      listParent,
-		Tags:        listTags,
-		Contributor: listContributor,
-		SortBy:      fogit.SortField(listSort),
-	}
-
-	// Validate filter
-	if err := filter.Validate(); err != nil {
-		return err
-	}
+	return runListView(cmd, &fogit.SavedView{
+		Filter:        listFilter,
+		State:         listState,
+		Priority:      listPriority,
+		Type:          listType,
+		Category:      listCategory,
+		Domain:        listDomain,
+		Team:          listTeam,
+		Epic:          listEpic,
+		Parent:        listParent,
+		Tags:          listTags,
+		Contributor:   listContributor,
+		CurrentBranch: listAllBranches,
+		Sort:          listSort,
+		Order:         listOrder,
+		Columns:       listColumns,
+		GroupBy:       listGroupBy,
+		Format:        listFormat,
+	})
+}
 
-	// Validate format
-	if !printer.IsValidFormat(listFormat) {
-		return fmt.Errorf("invalid format: must be one of table, json, csv")
+// runListView lists the features of a view. 'fogit list' runs an unnamed view
+// built from its flags, so a saved view lists exactly what its flags would.
+func runListView(cmd *cobra.Command, view *fogit.SavedView) error {
+	filter := view.ListFilter()
+	if filter.SortBy == "" {
+		filter.SortBy = fogit.SortByCreated
 	}
 
 	// Get command context
@@ -120,6 +150,21 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 
+	out, err := parseListPresentation(view, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
+	// Validate filter against the configured workflow states
+	if err := filter.ValidateWith(cmdCtx.Config.Workflow.StateMachine()); err != nil {
+		return err
+	}
+
+	expr, err := parseFilterExpression(view.Filter, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
 	// Apply timeout to prevent hanging on slow filesystems
 	ctx, cancel := WithListTimeout(cmd.Context())
 	defer cancel()
@@ -129,7 +174,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	// Per spec/specification/07-git-integration.md#cross-branch-feature-discovery:
 	// In branch-per-feature mode, cross-branch discovery is AUTOMATIC
 	// Use --current-branch to override and only show current branch
-	if listAllBranches {
+	if view.CurrentBranch {
 		// --current-branch flag: list features on current branch only
 		featuresList, err = cmdCtx.Repo.List(ctx, filter)
 		if err != nil {
@@ -143,12 +188,17 @@ func runList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	featuresList, err = applyFilterExpression(ctx, cmdCtx, expr, featuresList, nil)
+	if err != nil {
+		return err
+	}
+
 	// Sort features
 	fogit.SortFeatures(featuresList, filter)
 
 	// Check if empty
 	if len(featuresList) == 0 {
-		if printer.HasActiveFilters(filter) {
+		if printer.HasActiveFilters(filter) || view.Filter != "" {
 			fmt.Println("No features found matching filters")
 		} else {
 			fmt.Println("No features found")
@@ -156,13 +206,106 @@ func runList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Format output
-	switch listFormat {
+	return out.write(featuresList)
+}
+
+// listPresentation is how 'fogit list' prints features
+type listPresentation struct {
+	format  string
+	columns []string
+	groupBy string
+	tmpl    *template.Template
+}
+
+// parseListPresentation checks the columns, group field and format of a view.
+// A group-by of "default" means ui.default_group_by from the config.
+func parseListPresentation(view *fogit.SavedView, cfg *fogit.Config) (*listPresentation, error) {
+	out := &listPresentation{format: view.Format, groupBy: view.GroupBy}
+	if out.format == "" {
+		out.format = "table"
+	}
+
+	if len(view.Columns) > 0 {
+		columns, err := printer.ParseColumns(strings.Join(view.Columns, ","))
+		if err != nil {
+			return nil, err
+		}
+		out.columns = columns
+	}
+
+	if out.groupBy == "default" {
+		out.groupBy = cfg.UI.DefaultGroupBy
+	}
+	if out.groupBy != "" && !printer.IsColumn(out.groupBy) {
+		return nil, fmt.Errorf("invalid group-by field %q", out.groupBy)
+	}
+
+	if printer.IsTemplateFormat(out.format) {
+		if out.groupBy != "" {
+			return nil, fmt.Errorf("--group-by cannot be used with a template format")
+		}
+		tmpl, err := printer.ParseListTemplate(out.format)
+		if err != nil {
+			return nil, err
+		}
+		out.tmpl = tmpl
+	} else if !printer.IsValidFormat(out.format) {
+		return nil, fmt.Errorf("invalid format: must be one of table, json, csv, or a Go template")
+	}
+
+	return out, nil
+}
+
+// write prints the features. Without columns or grouping the standard
+// table, JSON and CSV output is used.
+func (o *listPresentation) write(features []*fogit.Feature) error {
+	if o.tmpl != nil {
+		return printer.OutputTemplate(os.Stdout, features, o.tmpl)
+	}
+
+	if o.groupBy != "" {
+		groups := printer.GroupFeatures(features, o.groupBy)
+		switch o.format {
+		case "json":
+			return printer.OutputGroupedJSON(os.Stdout, groups, o.columns)
+		case "csv":
+			return printer.OutputGroupedCSV(os.Stdout, groups, o.groupBy, o.csvColumns())
+		default:
+			return printer.OutputGroupedTable(os.Stdout, groups, o.groupBy, o.tableColumns())
+		}
+	}
+
+	if len(o.columns) > 0 {
+		switch o.format {
+		case "json":
+			return printer.OutputColumnsJSON(os.Stdout, features, o.columns)
+		case "csv":
+			return printer.OutputColumnsCSV(os.Stdout, features, o.columns)
+		default:
+			return printer.OutputColumnsTable(os.Stdout, features, o.columns)
+		}
+	}
+
+	switch o.format {
 	case "json":
-		return printer.OutputJSON(os.Stdout, featuresList)
+		return printer.OutputJSON(os.Stdout, features)
 	case "csv":
-		return printer.OutputCSV(os.Stdout, featuresList)
+		return printer.OutputCSV(os.Stdout, features)
 	default:
-		return printer.OutputTable(os.Stdout, featuresList)
+		return printer.OutputTable(os.Stdout, features)
+	}
+}
+
+func (o *listPresentation) tableColumns() []string {
+	if len(o.columns) > 0 {
+		return o.columns
+	}
+	return printer.DefaultColumns
+}
+
+func (o *listPresentation) csvColumns() []string {
+	if len(o.columns) > 0 {
+		return o.columns
 	}
+	return printer.DefaultCSVColumns
 }
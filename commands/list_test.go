@@ -1,13 +1,23 @@
 package commands
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"github.com/eg3r/fogit/pkg/fogit"
 )
 
 // TestListCommandFlags tests flag configuration for the list command
 func TestListCommandFlags(t *testing.T) {
 	// Verify important flags exist
-	flags := []string{"state", "priority", "type", "category", "domain", "team", "epic", "tag", "format", "sort"}
+	flags := []string{"state", "priority", "type", "category", "domain", "team", "epic", "tag", "format", "sort", "columns", "group-by"}
 
 	for _, flag := range flags {
 		t.Run("has "+flag+" flag", func(t *testing.T) {
@@ -19,8 +29,142 @@ func TestListCommandFlags(t *testing.T) {
 	}
 }
 
+// TestParseListPresentation tests validation of the list columns, grouping and format
+func TestParseListPresentation(t *testing.T) {
+	cfg := fogit.DefaultConfig()
+	cfg.UI.DefaultGroupBy = "priority"
+
+	tests := []struct {
+		name        string
+		view        fogit.SavedView
+		wantGroupBy string
+		wantErr     bool
+	}{
+		{"defaults", fogit.SavedView{}, "", false},
+		{"columns", fogit.SavedView{Columns: []string{"name", "metadata.team", "relationships.count"}}, "", false},
+		{"unknown column", fogit.SavedView{Columns: []string{"name", "bogus"}}, "", true},
+		{"group by", fogit.SavedView{GroupBy: "metadata.team"}, "metadata.team", false},
+		{"default group by", fogit.SavedView{GroupBy: "default"}, "priority", false},
+		{"unknown group by", fogit.SavedView{GroupBy: "bogus"}, "", true},
+		{"template", fogit.SavedView{Format: "{{.Name}}"}, "", false},
+		{"broken template", fogit.SavedView{Format: "{{.Name"}, "", true},
+		{"template with group by", fogit.SavedView{Format: "{{.Name}}", GroupBy: "state"}, "", true},
+		{"unknown format", fogit.SavedView{Format: "xml"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := parseListPresentation(&tt.view, cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseListPresentation() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && out.groupBy != tt.wantGroupBy {
+				t.Errorf("groupBy = %q, want %q", out.groupBy, tt.wantGroupBy)
+			}
+		})
+	}
+}
+
 // Note: Storage and filtering tests are covered in:
 // - internal/storage/repository_test.go (TestFileRepository_List, TestFileRepository_ListEmpty)
 // - pkg/fogit/filter_test.go (TestFilter_Matches, TestFilter_Matches_EdgeCases)
 // - pkg/fogit/sort_test.go (TestSortFeatures)
 // - internal/printer/list_test.go (TestIsValidFormat)
+
+// TestListAt tests that --at lists features from a past commit
+func TestListAt(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	gitRepo, err := gogit.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatalf("Failed to init git: %v", err)
+	}
+	w, err := gitRepo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fogitDir := filepath.Join(tmpDir, ".fogit")
+	if err := os.MkdirAll(filepath.Join(fogitDir, "features"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	repo := getRepository(fogitDir)
+
+	commitFeature := func(name string, when time.Time) {
+		t.Helper()
+		if err := repo.Create(context.Background(), fogit.NewFeature(name)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Add(".fogit"); err != nil {
+			t.Fatal(err)
+		}
+		sig := &object.Signature{Name: "Test", Email: "test@example.com", When: when}
+		if _, err := w.Commit("Add "+name, &gogit.CommitOptions{Author: sig, Committer: sig}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commitFeature("Old Feature", time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC))
+	head, err := gitRepo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gitRepo.CreateTag("v1.0.0", head.Hash(), nil); err != nil {
+		t.Fatal(err)
+	}
+	commitFeature("New Feature", time.Date(2025, 2, 10, 12, 0, 0, 0, time.UTC))
+
+	list := func(args ...string) (string, error) {
+		ResetFlags()
+		rootCmd.SetArgs(append([]string{"-C", tmpDir, "list", "--format", "json"}, args...))
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = ExecuteRootCmd()
+		})
+		return out, runErr
+	}
+
+	tests := []struct {
+		name    string
+		at      string
+		wantOld bool
+		wantNew bool
+	}{
+		{"working tree", "", true, true},
+		{"tag", "v1.0.0", true, false},
+		{"date", "2025-01-31", true, false},
+		{"later date", "2025-02-10", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var args []string
+			if tt.at != "" {
+				args = []string{"--at", tt.at}
+			}
+			out, err := list(args...)
+			if err != nil {
+				t.Fatalf("list failed: %v", err)
+			}
+			if got := strings.Contains(out, "Old Feature"); got != tt.wantOld {
+				t.Errorf("Old Feature listed = %v, want %v", got, tt.wantOld)
+			}
+			if got := strings.Contains(out, "New Feature"); got != tt.wantNew {
+				t.Errorf("New Feature listed = %v, want %v", got, tt.wantNew)
+			}
+		})
+	}
+
+	t.Run("date before history", func(t *testing.T) {
+		if _, err := list("--at", "2024-12-31"); err == nil {
+			t.Error("expected error for a date before the first commit")
+		}
+	})
+
+	t.Run("write commands are rejected", func(t *testing.T) {
+		ResetFlags()
+		rootCmd.SetArgs([]string{"-C", tmpDir, "update", "Old Feature", "--priority", "high", "--at", "v1.0.0"})
+		if err := ExecuteRootCmd(); err == nil || !strings.Contains(err.Error(), "--at") {
+			t.Errorf("expected --at to be rejected, got %v", err)
+		}
+	})
+}
@@ -2,12 +2,12 @@ package commands
 
 import (
 	"fmt"
-	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/internal/git"
 )
 
 var (
@@ -26,6 +26,11 @@ var logCmd = &cobra.Command{
 Can filter by feature, author, date, and limit results.
 Uses git log under the hood.
 
+With --feature, commits are matched by the Fogit-Feature trailer that
+'fogit commit' adds (see commit_trailers in the config), so a feature's
+history survives renames. Commits made without the trailer are not shown;
+use 'fogit history' to also find commits that touched the feature's files.
+
 Examples:
   fogit log
   fogit log --feature "User Auth"
@@ -51,26 +56,10 @@ func runLog(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	gitRepo := cmdCtx.Git.GetGitRepo()
-
-	// If feature is specified, get commits for that feature's file
-	var featurePath string
-	if logFeature != "" {
-		// Find the feature using cross-branch discovery
-		feature, findErr := FindFeatureCrossBranch(cmd.Context(), cmdCtx, logFeature, "fogit log --feature <id>")
-		if findErr != nil {
-			return findErr
-		}
-
-		// Get feature file path
-		opts := storage.SlugifyOptions{
-			MaxLength:        50,
-			AllowSlashes:     false,
-			NormalizeUnicode: true,
-			EmptyFallback:    "unnamed",
-		}
-		featurePath = filepath.Join("features", storage.Slugify(feature.Name, opts)+".yml")
+	if cmdCtx.Git == nil {
+		return fmt.Errorf("not in a git repository")
 	}
+	gitRepo := cmdCtx.Git.GetGitRepo()
 
 	// Parse since date if provided
 	var sinceTime *time.Time
@@ -82,8 +71,20 @@ func runLog(cmd *cobra.Command, args []string) error {
 		sinceTime = &parsedTime
 	}
 
-	// Get commit log from git
-	commits, err := gitRepo.GetLog(featurePath, logAuthor, sinceTime, logLimit)
+	var commits []git.CommitLog
+	if logFeature != "" {
+		// Find the feature using cross-branch discovery
+		feature, findErr := FindFeatureCrossBranch(cmd.Context(), cmdCtx, logFeature, "fogit log --feature <id>")
+		if findErr != nil {
+			return findErr
+		}
+
+		// Commits are linked to features by trailer, which survives renames
+		trailerKey := cmdCtx.Config.CommitTrailers.FeatureTrailerKey()
+		commits, err = gitRepo.GetLogByTrailer(trailerKey, feature.ID, logAuthor, sinceTime, logLimit)
+	} else {
+		commits, err = gitRepo.GetLog("", logAuthor, sinceTime, logLimit)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to get log: %w", err)
 	}
@@ -97,7 +98,8 @@ func runLog(cmd *cobra.Command, args []string) error {
 	switch logFormat {
 	case "oneline":
 		for _, commit := range commits {
-			fmt.Printf("%s %s (%s)\n", commit.Hash[:8], commit.Message, commit.Author)
+			subject := strings.SplitN(commit.Message, "\n", 2)[0]
+			fmt.Printf("%s %s (%s)\n", commit.Hash[:8], subject, commit.Author)
 		}
 	case "short":
 		for _, commit := range commits {
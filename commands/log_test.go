@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -126,6 +127,54 @@ func TestLogCommand(t *testing.T) {
 		}
 	})
 
+	t.Run("filter by feature trailer after rename", func(t *testing.T) {
+		if err := os.WriteFile(testFile, []byte("linked"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Add("test.txt"); err != nil {
+			t.Fatal(err)
+		}
+		message := "Linked commit\n\nFogit-Feature: " + feature.ID + "\nFogit-Version: 1"
+		if _, err := w.Commit(message, &gogit.CommitOptions{
+			Author: &object.Signature{Name: "alice", Email: "alice@example.com", When: time.Now()},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		feature.Name = "Renamed Feature"
+		if err := repo.Update(context.Background(), feature); err != nil {
+			t.Fatal(err)
+		}
+
+		ResetFlags()
+		rootCmd.SetArgs([]string{"-C", tmpDir, "log", "--feature", "Renamed Feature", "--format", "oneline"})
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = ExecuteRootCmd()
+		})
+		if runErr != nil {
+			t.Fatalf("log with feature filter failed: %v", runErr)
+		}
+		if !strings.Contains(out, "Linked commit") || strings.Contains(out, "Commit 1") || !strings.Contains(out, "Total: 1 commits") {
+			t.Errorf("expected only the trailer-linked commit, got:\n%s", out)
+		}
+	})
+
+	t.Run("history follows trailer after rename", func(t *testing.T) {
+		ResetFlags()
+		rootCmd.SetArgs([]string{"-C", tmpDir, "history", "Renamed Feature", "--format", "json"})
+		var runErr error
+		out := captureStdout(t, func() {
+			runErr = ExecuteRootCmd()
+		})
+		if runErr != nil {
+			t.Fatalf("history failed: %v", runErr)
+		}
+		if !strings.Contains(out, `"subject": "Linked commit"`) || !strings.Contains(out, `"trailer": true`) || strings.Contains(out, "Commit 1") {
+			t.Errorf("expected only the trailer-linked commit, got:\n%s", out)
+		}
+	})
+
 	t.Run("invalid date format", func(t *testing.T) {
 		ResetFlags()
 		rootCmd.SetArgs([]string{"-C", tmpDir, "log", "--since", "invalid-date", "--format", "oneline"})
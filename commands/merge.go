@@ -11,14 +11,17 @@ import (
 	"github.com/eg3r/fogit/internal/config"
 	"github.com/eg3r/fogit/internal/features"
 	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/interactive"
 	"github.com/eg3r/fogit/pkg/fogit"
 )
 
 var (
-	mergeNoDelete bool
-	mergeSquash   bool
-	mergeContinue bool
-	mergeAbort    bool
+	mergeNoDelete  bool
+	mergeSquash    bool
+	mergeContinue  bool
+	mergeAbort     bool
+	mergeResolve   bool
+	mergeNoRestack bool
 )
 
 var mergeCmd = &cobra.Command{
@@ -36,6 +39,9 @@ In branch-per-feature mode:
 - Merges feature branch into main
 - Closes all features on the current branch
 
+Branches stacked on the merged branch (see 'fogit feature --stack-on') are
+rebased onto the base branch afterwards, as with 'fogit restack'.
+
 In trunk-based mode:
 - Simply closes the feature (no merge needed)
 
@@ -46,12 +52,20 @@ Conflict Resolution:
   3. Run 'fogit merge --continue' to complete
   4. Or 'fogit merge --abort' to cancel
 
+  Or run 'fogit merge --resolve' to walk through each conflicted feature
+  file: both sides are shown field by field, you keep ours, theirs or type
+  a new value, and the merge is completed once every file is resolved.
+
+  Run 'fogit merge-driver install' once to have Git merge feature files
+  field by field, so only genuinely conflicting fields need resolving.
+
 Examples:
   fogit merge
   fogit finish
   fogit merge --no-delete
   fogit merge --squash
   fogit merge --continue   # After resolving conflicts
+  fogit merge --resolve    # Resolve feature conflicts interactively
   fogit merge --abort      # Cancel merge`,
 	Aliases: []string{"finish"},
 	RunE:    runMerge,
@@ -62,6 +76,9 @@ func init() {
 	mergeCmd.Flags().BoolVar(&mergeSquash, "squash", false, "Squash commits")
 	mergeCmd.Flags().BoolVar(&mergeContinue, "continue", false, "Continue merge after resolving conflicts")
 	mergeCmd.Flags().BoolVar(&mergeAbort, "abort", false, "Abort the current merge")
+	mergeCmd.Flags().BoolVar(&mergeResolve, "resolve", false, "Resolve conflicted feature files interactively, then continue")
+	mergeCmd.Flags().BoolVar(&mergeNoRestack, "no-restack", false, "Don't rebase branches stacked on the merged branch")
+	mergeCmd.MarkFlagsMutuallyExclusive("resolve", "abort")
 
 	rootCmd.AddCommand(mergeCmd)
 }
@@ -99,6 +116,31 @@ func runMerge(cmd *cobra.Command, args []string) error {
 	// Open repository
 	repo := getRepository(fogitDir)
 
+	// Resolve conflicted feature files first; the merge then continues as with --continue
+	if mergeResolve {
+		state, err := features.LoadMergeState(fogitDir)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return &fogit.NoMergeInProgressError{Message: features.ErrNoMergeInProgress.Error()}
+		}
+
+		unresolved, err := resolveMergeConflicts(gitRepo, cfg, interactive.NewPrompter(), "ours ("+state.BaseBranch+")", "theirs ("+state.FeatureBranch+")")
+		if err != nil {
+			return err
+		}
+		if len(unresolved) > 0 {
+			fmt.Println("\n⚠ These files need manual resolution:")
+			for _, path := range unresolved {
+				fmt.Printf("  %s\n", path)
+			}
+			fmt.Println("\nResolve and stage them (git add <files>), then run 'fogit merge --continue'.")
+			return fogit.NewMergeConflictError(unresolved)
+		}
+		fmt.Println()
+	}
+
 	// Prepare merge options
 	var featureName string
 	if len(args) > 0 {
@@ -109,10 +151,11 @@ func runMerge(cmd *cobra.Command, args []string) error {
 		FeatureName: featureName,
 		NoDelete:    mergeNoDelete,
 		Squash:      mergeSquash,
-		Continue:    mergeContinue,
+		Continue:    mergeContinue || mergeResolve,
 		Abort:       mergeAbort,
 		FogitDir:    fogitDir,
 		BaseBranch:  cfg.Workflow.BaseBranch,
+		NoRestack:   mergeNoRestack,
 	}
 
 	// Execute merge
@@ -169,6 +212,13 @@ func runMerge(cmd *cobra.Command, args []string) error {
 		} else if !result.NoDelete {
 			fmt.Printf("  Branch '%s' kept (use --no-delete to suppress this)\n", result.Branch)
 		}
+		for _, r := range result.Restacked {
+			fmt.Printf("✓ Restacked %s onto %s\n", r.Branch, r.NewParent)
+		}
+		if result.RestackErr != nil {
+			fmt.Printf("⚠ Could not restack branches stacked on %s: %v\n", result.Branch, result.RestackErr)
+			fmt.Println("  Rebase them manually, or fix the cause and run 'fogit restack'")
+		}
 	}
 
 	fmt.Println("\nFeature(s) completed! 🎉")
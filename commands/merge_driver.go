@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/logger"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// mergeDriverName is the merge driver name used in .gitattributes and git config
+const mergeDriverName = "fogit"
+
+// mergeDriverAttributes route feature files, under either YAML extension, to the
+// driver in .gitattributes
+var mergeDriverAttributes = []string{
+	".fogit/features/*.yml merge=" + mergeDriverName,
+	".fogit/features/*.yaml merge=" + mergeDriverName,
+}
+
+var mergeDriverCmd = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs> [path]",
+	Short: "Git merge driver for feature YAML files",
+	Long: `Merge a feature file semantically instead of line by line.
+
+Git runs this command when both branches changed the same feature file, with
+the common ancestor, our version and their version. The merged result is
+written back to <ours>:
+
+  - tags, files and version authors are merged as sets
+  - relationships are merged by ID
+  - version maps are unioned; timestamps take the latest value
+  - name, description, notes, state, branch and metadata values are merged
+    three-way and only conflict when both sides changed them differently
+
+Conflicting fields are written with conflict markers and the command exits
+non-zero so Git reports the conflict. Files that can't be parsed fall back to
+a regular textual merge (git merge-file).
+
+Run 'fogit merge-driver install' to register the driver for this repository.
+It adds to .gitattributes:
+
+  .fogit/features/*.yml merge=fogit
+  .fogit/features/*.yaml merge=fogit
+
+and to .git/config:
+
+  [merge "fogit"]
+      name = FoGit feature merge
+      driver = fogit merge-driver %O %A %B %P`,
+	Args: cobra.RangeArgs(3, 4),
+	RunE: runMergeDriver,
+}
+
+var mergeDriverInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Register the merge driver for this repository",
+	Args:  cobra.NoArgs,
+	RunE:  runMergeDriverInstall,
+}
+
+var mergeDriverUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Unregister the merge driver",
+	Args:  cobra.NoArgs,
+	RunE:  runMergeDriverUninstall,
+}
+
+func init() {
+	mergeDriverCmd.AddCommand(mergeDriverInstallCmd)
+	mergeDriverCmd.AddCommand(mergeDriverUninstallCmd)
+	rootCmd.AddCommand(mergeDriverCmd)
+}
+
+func runMergeDriver(cmd *cobra.Command, args []string) error {
+	basePath, oursPath, theirsPath := args[0], args[1], args[2]
+	label := "feature file"
+	if len(args) == 4 {
+		label = args[3]
+	}
+
+	base, err := os.ReadFile(basePath)
+	if err != nil {
+		return fmt.Errorf("failed to read base: %w", err)
+	}
+	ours, err := os.ReadFile(oursPath)
+	if err != nil {
+		return fmt.Errorf("failed to read ours: %w", err)
+	}
+	theirs, err := os.ReadFile(theirsPath)
+	if err != nil {
+		return fmt.Errorf("failed to read theirs: %w", err)
+	}
+
+	merged, conflicts, err := features.MergeFeatureFiles(base, ours, theirs, "ours", "theirs")
+	if err != nil {
+		logger.Warn("semantic merge not possible, falling back to text merge", "path", label, "error", err)
+		return textMerge(oursPath, basePath, theirsPath, label)
+	}
+
+	if err := os.WriteFile(oursPath, merged, 0644); err != nil { //nolint:gosec // feature files are not secret
+		return fmt.Errorf("failed to write merge result: %w", err)
+	}
+
+	if len(conflicts) > 0 {
+		fields := make([]string, len(conflicts))
+		for i, c := range conflicts {
+			fields[i] = c.Field
+		}
+		return &fogit.MergeConflictError{
+			ConflictFiles: []string{label},
+			Message:       fmt.Sprintf("conflicting changes to %s in %s", strings.Join(fields, ", "), label),
+		}
+	}
+	return nil
+}
+
+// textMerge runs git's line-based three-way merge into oursPath
+func textMerge(oursPath, basePath, theirsPath, label string) error {
+	cmd := exec.Command("git", "merge-file", "-L", "ours", "-L", "base", "-L", "theirs", oursPath, basePath, theirsPath) // #nosec G204 - paths come from git
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+			return fogit.NewMergeConflictError([]string{label})
+		}
+		return fmt.Errorf("git merge-file failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func runMergeDriverInstall(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+	if _, err := os.Stat(filepath.Join(cwd, ".git")); os.IsNotExist(err) {
+		return fmt.Errorf("not a Git repository (missing .git directory)")
+	}
+
+	for _, kv := range [][2]string{
+		{"merge." + mergeDriverName + ".name", "FoGit feature merge"},
+		{"merge." + mergeDriverName + ".driver", "fogit merge-driver %O %A %B %P"},
+	} {
+		if out, err := exec.Command("git", "config", kv[0], kv[1]).CombinedOutput(); err != nil { // #nosec G204 - constant arguments
+			return fmt.Errorf("failed to set %s: %w: %s", kv[0], err, strings.TrimSpace(string(out)))
+		}
+	}
+
+	attrPath := filepath.Join(cwd, ".gitattributes")
+	content, err := os.ReadFile(attrPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read .gitattributes: %w", err)
+	}
+	text := string(content)
+	for _, attr := range mergeDriverAttributes {
+		if containsLine(text, attr) {
+			continue
+		}
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		text += attr + "\n"
+	}
+	if text != string(content) {
+		if err := os.WriteFile(attrPath, []byte(text), 0644); err != nil { //nolint:gosec // .gitattributes is committed
+			return fmt.Errorf("failed to write .gitattributes: %w", err)
+		}
+	}
+
+	fmt.Println("Merge driver installed:")
+	fmt.Println("  ✓ .git/config     merge.fogit.driver")
+	for _, attr := range mergeDriverAttributes {
+		fmt.Println("  ✓ .gitattributes  " + attr)
+	}
+	fmt.Println()
+	fmt.Println("Commit .gitattributes so the driver is used for everyone;")
+	fmt.Println("each clone still needs 'fogit merge-driver install' for the git config.")
+	return nil
+}
+
+func runMergeDriverUninstall(cmd *cobra.Command, args []string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get current directory: %w", err)
+	}
+
+	// Ignore "section not found" so uninstall is idempotent
+	_ = exec.Command("git", "config", "--remove-section", "merge."+mergeDriverName).Run() // #nosec G204 - constant arguments
+
+	attrPath := filepath.Join(cwd, ".gitattributes")
+	content, err := os.ReadFile(attrPath)
+	if err == nil {
+		var kept []string
+		for _, line := range strings.SplitAfter(string(content), "\n") {
+			if !slices.Contains(mergeDriverAttributes, strings.TrimSpace(line)) {
+				kept = append(kept, line)
+			}
+		}
+		if text := strings.Join(kept, ""); text != string(content) {
+			if err := os.WriteFile(attrPath, []byte(text), 0644); err != nil { //nolint:gosec // .gitattributes is committed
+				return fmt.Errorf("failed to write .gitattributes: %w", err)
+			}
+		}
+	}
+
+	fmt.Println("Merge driver uninstalled.")
+	return nil
+}
+
+// containsLine reports whether text has a line equal to line, ignoring surrounding whitespace
+func containsLine(text, line string) bool {
+	for _, l := range strings.Split(text, "\n") {
+		if strings.TrimSpace(l) == line {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestMergeDriverCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	const base = "id: f-1\nname: Login\ntags: [auth]\nmetadata:\n  priority: low\n"
+	write := func(name, content string) string {
+		t.Helper()
+		path := filepath.Join(tmpDir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	run := func(ours, theirs string) (string, error) {
+		t.Helper()
+		basePath, oursPath, theirsPath := write("base", base), write("ours", ours), write("theirs", theirs)
+		ResetFlags()
+		rootCmd.SetArgs([]string{"-C", tmpDir, "merge-driver", basePath, oursPath, theirsPath, ".fogit/features/login.yml"})
+		err := ExecuteRootCmd()
+		merged, readErr := os.ReadFile(oursPath)
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+		return string(merged), err
+	}
+
+	t.Run("clean merge", func(t *testing.T) {
+		merged, err := run(
+			strings.Replace(base, "tags: [auth]", "tags: [auth, ui]", 1),
+			strings.Replace(base, "priority: low", "priority: high", 1),
+		)
+		if err != nil {
+			t.Fatalf("merge-driver failed: %v", err)
+		}
+		if !strings.Contains(merged, "- ui") || !strings.Contains(merged, "priority: high") {
+			t.Errorf("expected both changes, got:\n%s", merged)
+		}
+	})
+
+	t.Run("conflict", func(t *testing.T) {
+		merged, err := run(
+			strings.Replace(base, "name: Login", "name: Sign in", 1),
+			strings.Replace(base, "name: Login", "name: Log on", 1),
+		)
+		if !fogit.IsMergeConflictError(err) {
+			t.Fatalf("expected merge conflict error, got %v", err)
+		}
+		if !strings.Contains(merged, "<<<<<<< ours\nname: Sign in\n=======\nname: Log on\n>>>>>>> theirs") {
+			t.Errorf("expected conflict markers, got:\n%s", merged)
+		}
+	})
+
+	t.Run("unparseable falls back to text merge", func(t *testing.T) {
+		if _, err := exec.LookPath("git"); err != nil {
+			t.Skip("git not available")
+		}
+		merged, err := run(base+"not: [valid\n", base)
+		if err != nil {
+			t.Fatalf("text merge failed: %v", err)
+		}
+		if !strings.Contains(merged, "not: [valid") {
+			t.Errorf("expected ours to be kept, got:\n%s", merged)
+		}
+	})
+}
+
+func TestMergeDriverInstall(t *testing.T) {
+	tmpDir := t.TempDir()
+	if out, err := exec.Command("git", "init", tmpDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	attrPath := filepath.Join(tmpDir, ".gitattributes")
+	// An earlier install registered only the .yml pattern
+	if err := os.WriteFile(attrPath, []byte("*.png binary\n"+mergeDriverAttributes[0]), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ { // Installing twice must not duplicate the attribute
+		ResetFlags()
+		rootCmd.SetArgs([]string{"-C", tmpDir, "merge-driver", "install"})
+		if err := ExecuteRootCmd(); err != nil {
+			t.Fatalf("install failed: %v", err)
+		}
+	}
+
+	attrs, err := os.ReadFile(attrPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "*.png binary\n.fogit/features/*.yml merge=fogit\n.fogit/features/*.yaml merge=fogit\n"
+	if string(attrs) != want {
+		t.Errorf(".gitattributes = %q", attrs)
+	}
+
+	cmd := exec.Command("git", "config", "merge.fogit.driver")
+	cmd.Dir = tmpDir
+	out, err := cmd.Output()
+	if err != nil || strings.TrimSpace(string(out)) != "fogit merge-driver %O %A %B %P" {
+		t.Errorf("merge.fogit.driver = %q, %v", out, err)
+	}
+
+	ResetFlags()
+	rootCmd.SetArgs([]string{"-C", tmpDir, "merge-driver", "uninstall"})
+	if err := ExecuteRootCmd(); err != nil {
+		t.Fatalf("uninstall failed: %v", err)
+	}
+	if attrs, _ := os.ReadFile(attrPath); string(attrs) != "*.png binary\n" {
+		t.Errorf(".gitattributes after uninstall = %q", attrs)
+	}
+}
@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/interactive"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// resolveMergeConflicts walks the conflicted feature files of an in-progress
+// merge, resolving each field by field with the prompter, then writes and
+// stages the result. Values that break the workflow or metadata schema of cfg
+// are asked for again. Returns the conflicted files left for manual resolution.
+func resolveMergeConflicts(gitRepo *git.Repository, cfg *fogit.Config, prompter interactive.Prompter, oursLabel, theirsLabel string) ([]string, error) {
+	files, err := gitRepo.ConflictedFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var unresolved []string
+	for _, path := range files {
+		if !features.IsFeatureFilePath(path) {
+			unresolved = append(unresolved, path)
+			continue
+		}
+
+		base, err := gitRepo.ReadConflictStage(path, 1)
+		if err != nil && !errors.Is(err, git.ErrFileNotFound) {
+			return nil, err
+		}
+		ours, oursErr := gitRepo.ReadConflictStage(path, 2)
+		theirs, theirsErr := gitRepo.ReadConflictStage(path, 3)
+		if oursErr != nil || theirsErr != nil {
+			// Deleted on one side; keeping or removing the whole file is a manual decision
+			unresolved = append(unresolved, path)
+			continue
+		}
+
+		fmt.Printf("\nConflicts in %s:\n", path)
+		resolved, err := features.ResolveFeatureConflict(base, ours, theirs, cfg, func(c features.FieldConflict, rejected error) (features.FieldResolution, error) {
+			if rejected != nil {
+				fmt.Printf("  ⚠ %v\n", rejected)
+			}
+			return promptFieldResolution(prompter, c, oursLabel, theirsLabel)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+		}
+
+		if err := os.WriteFile(filepath.Join(gitRepo.Path(), path), resolved, 0644); err != nil { //nolint:gosec // feature files are not secret
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		if err := gitRepo.StageFile(path); err != nil {
+			return nil, err
+		}
+		fmt.Printf("✓ Resolved %s\n", path)
+	}
+	return unresolved, nil
+}
+
+// promptFieldResolution shows both sides of a conflicting field and asks which to keep
+func promptFieldResolution(prompter interactive.Prompter, c features.FieldConflict, oursLabel, theirsLabel string) (features.FieldResolution, error) {
+	width := max(len(oursLabel), len(theirsLabel), len("base"))
+	fmt.Printf("\n  %s\n", c.Field)
+	fmt.Printf("    %-*s  %s\n", width, "base", displayConflictValue(c.Base))
+	fmt.Printf("    %-*s  %s\n", width, oursLabel, displayConflictValue(c.Ours))
+	fmt.Printf("    %-*s  %s\n", width, theirsLabel, displayConflictValue(c.Theirs))
+
+	for {
+		choice, err := prompter.ReadLine("  Keep [O]urs, [t]heirs or [e]dit? ")
+		if err != nil {
+			return features.FieldResolution{}, fmt.Errorf("failed to read choice: %w", err)
+		}
+		switch strings.ToLower(strings.TrimSpace(choice)) {
+		case "", "o", "ours":
+			return features.FieldResolution{}, nil
+		case "t", "theirs":
+			return features.FieldResolution{UseTheirs: true}, nil
+		case "e", "edit":
+			value, err := prompter.ReadLine("  New value: ")
+			if err != nil {
+				return features.FieldResolution{}, fmt.Errorf("failed to read value: %w", err)
+			}
+			return features.FieldResolution{Edited: true, Value: value}, nil
+		default:
+			fmt.Println("  Please answer o, t or e.")
+		}
+	}
+}
+
+func displayConflictValue(v string) string {
+	if v == "" {
+		return "(none)"
+	}
+	return v
+}
@@ -1,7 +1,15 @@
 package commands
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/interactive"
+	"github.com/eg3r/fogit/pkg/fogit"
 )
 
 // TestMergeCommandArgs tests argument validation
@@ -41,6 +49,92 @@ func TestMergeCommandFlags(t *testing.T) {
 	if mergeCmd.Flags().Lookup("squash") == nil {
 		t.Error("--squash flag not defined")
 	}
+	if mergeCmd.Flags().Lookup("resolve") == nil {
+		t.Error("--resolve flag not defined")
+	}
+	if mergeCmd.Flags().Lookup("no-restack") == nil {
+		t.Error("--no-restack flag not defined")
+	}
+}
+
+func TestResolveMergeConflicts(t *testing.T) {
+	tmpDir := t.TempDir()
+	gitRun := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = tmpDir
+		// A failing merge is the point of this setup
+		if out, err := cmd.CombinedOutput(); err != nil && args[0] != "merge" {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(files map[string]string, msg string) {
+		t.Helper()
+		for name, content := range files {
+			path := filepath.Join(tmpDir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		gitRun("add", "-A")
+		gitRun("commit", "-m", msg)
+	}
+
+	const featurePath = ".fogit/features/login.yml"
+	const base = "id: f-1\nname: Login\ntags: [auth]\nmetadata:\n  priority: low\n"
+	gitRun("init", "-b", "main")
+	gitRun("config", "user.name", "Test")
+	gitRun("config", "user.email", "test@example.com")
+	commit(map[string]string{featurePath: base, "notes.txt": "base\n"}, "Initial")
+
+	gitRun("checkout", "-b", "feature/login")
+	commit(map[string]string{
+		featurePath: strings.NewReplacer("name: Login", "name: Log on", "tags: [auth]", "tags: [auth, ui]").Replace(base),
+		"notes.txt": "theirs\n",
+	}, "Rename on branch")
+	gitRun("checkout", "main")
+	commit(map[string]string{
+		featurePath: strings.NewReplacer("name: Login", "name: Sign in", "priority: low", "priority: high").Replace(base),
+		"notes.txt": "ours\n",
+	}, "Rename on main")
+	gitRun("merge", "feature/login")
+
+	gitRepo, err := git.OpenRepository(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prompter := interactive.NewMockPrompter()
+	prompter.ReadLineFunc = func(prompt string) (string, error) { return "t", nil }
+
+	var unresolved []string
+	captureStdout(t, func() {
+		unresolved, err = resolveMergeConflicts(gitRepo, fogit.DefaultConfig(), prompter, "ours", "theirs")
+	})
+	if err != nil {
+		t.Fatalf("resolveMergeConflicts() error = %v", err)
+	}
+	if strings.Join(unresolved, ",") != "notes.txt" {
+		t.Errorf("unresolved = %v, want notes.txt", unresolved)
+	}
+	if n := prompter.GetCallCount("ReadLine"); n != 1 {
+		t.Errorf("prompted %d times, want once for the name", n)
+	}
+
+	resolved, err := os.ReadFile(filepath.Join(tmpDir, featurePath))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"name: Log on", "- ui", "priority: high"} {
+		if !strings.Contains(string(resolved), want) {
+			t.Errorf("resolved file missing %q:\n%s", want, resolved)
+		}
+	}
+	if files, _ := gitRepo.ConflictedFiles(); strings.Join(files, ",") != "notes.txt" {
+		t.Errorf("conflicted files after resolve = %v, want notes.txt", files)
+	}
 }
 
 // Note: TestMergeFeatureNotFound is covered by internal/storage/repository_test.go
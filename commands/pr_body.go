@@ -0,0 +1,207 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/features/validator"
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+var (
+	prBodyBase     string
+	prBodyFormat   string
+	prBodyTemplate string
+	prBodyOutput   string
+	prBodyInit     bool
+)
+
+var prBodyCmd = &cobra.Command{
+	Use:   "pr-body [feature]",
+	Short: "Generate a pull request description from feature data",
+	Long: `Render a Markdown pull request description for the features on the current branch.
+
+The description covers each feature's description, state, versions, linked
+files and relationships, the features impacted by the change (see
+'fogit impacts') and the validation status of the features (see
+'fogit validate'). Give a feature to describe only that feature.
+
+The output is rendered with the Go text/template in .fogit/templates/pr.md.tmpl,
+or a built-in template if there is none. Run 'fogit pr-body --init' to write
+the built-in template there as a starting point. Templates get:
+
+  .Branch, .BaseBranch
+  .Features    each with .ID, .Name, .Description, .State, .Type, .Priority,
+               .Category, .Tags, .Files, .Versions (.Version, .Branch, .Authors,
+               .Notes, .CreatedAt, .ClosedAt), .Relationships (.Type, .TargetID,
+               .TargetName, .Description) and .Impacts (.Name, .ID,
+               .Relationship, .Depth, .Path, .Warning)
+  .Validation  .Passed, .Errors, .Warnings and .Issues (.Code, .Severity,
+               .Feature, .Message)
+
+and the functions join, lower, upper and date.
+
+Examples:
+  fogit pr-body
+  fogit pr-body "User Authentication"
+  fogit pr-body -o pr.md
+  fogit pr-body | gh pr create --body-file -
+  fogit pr-body --init`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPRBody,
+}
+
+func init() {
+	prBodyCmd.Flags().StringVar(&prBodyBase, "base", "", "Branch the pull request targets (default: workflow.base_branch)")
+	prBodyCmd.Flags().StringVar(&prBodyFormat, "format", printer.PRBodyFormatMarkdown, "Output format: markdown, json")
+	prBodyCmd.Flags().StringVar(&prBodyTemplate, "template", "", "Template file, overrides .fogit/templates/pr.md.tmpl")
+	prBodyCmd.Flags().StringVarP(&prBodyOutput, "output", "o", "", "Output file (default: stdout)")
+	prBodyCmd.Flags().BoolVar(&prBodyInit, "init", false, "Write the built-in template to .fogit/templates/pr.md.tmpl")
+	rootCmd.AddCommand(prBodyCmd)
+}
+
+func runPRBody(cmd *cobra.Command, args []string) error {
+	if prBodyFormat != printer.PRBodyFormatMarkdown && prBodyFormat != printer.PRBodyFormatJSON {
+		return fmt.Errorf("invalid format: must be one of markdown, json")
+	}
+
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if prBodyInit {
+		return initPRTemplate(cmdCtx.FogitDir)
+	}
+
+	ctx, cancel := WithListTimeout(cmd.Context())
+	defer cancel()
+
+	body := &features.PRBody{BaseBranch: prBodyBase}
+	if body.BaseBranch == "" {
+		body.BaseBranch = cmdCtx.Config.Workflow.BaseBranch
+	}
+	if cmdCtx.Git != nil && cmdCtx.Git.GetGitRepo() != nil {
+		// No branch yet (e.g. before the first commit) is fine when a feature is named
+		body.Branch, _ = cmdCtx.Git.GetGitRepo().GetCurrentBranch()
+	}
+
+	var prFeatures []*fogit.Feature
+	if len(args) > 0 {
+		feature, err := FindFeatureCrossBranch(ctx, cmdCtx, args[0], "fogit pr-body <id>")
+		if err != nil {
+			return err
+		}
+		prFeatures = []*fogit.Feature{feature}
+	} else {
+		if body.Branch == "" {
+			return fmt.Errorf("not on a branch; name the feature to describe")
+		}
+		prFeatures, err = features.FindPRFeatures(ctx, cmdCtx.Repo, body.Branch)
+		if err != nil {
+			return err
+		}
+		if len(prFeatures) == 0 {
+			return fmt.Errorf("no open features found for branch '%s'", body.Branch)
+		}
+	}
+
+	// Impacts and validation look at all features, including those on other branches
+	allFeatures, err := listAllFeatures(ctx, cmdCtx)
+	if err != nil {
+		return err
+	}
+	categories := features.GetIncludedCategories(cmdCtx.Config, features.ImpactOptions{})
+	for _, f := range prFeatures {
+		impacts, err := features.AnalyzeImpactsWithFeatures(ctx, f, allFeatures, cmdCtx.Config, categories, 0)
+		if err != nil {
+			return fmt.Errorf("impact analysis failed for %s: %w", f.Name, err)
+		}
+		body.Features = append(body.Features, features.NewPRFeature(f, impacts))
+	}
+
+	validation, err := validator.New(cmdCtx.Repo, cmdCtx.Config).ValidateFeatures(ctx, allFeatures)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	body.Validation = prValidation(validation, prFeatures)
+
+	tmplText := ""
+	if prBodyTemplate != "" {
+		data, err := os.ReadFile(prBodyTemplate)
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		tmplText = string(data)
+	} else if tmplText, err = storage.LoadPRTemplate(cmdCtx.FogitDir); err != nil {
+		return err
+	}
+
+	write := func(w io.Writer) error {
+		if prBodyFormat == printer.PRBodyFormatJSON {
+			return printer.OutputAsJSON(w, body)
+		}
+		return printer.OutputPRBody(w, tmplText, body)
+	}
+
+	if prBodyOutput != "" {
+		if err := common.ValidateOutputPath(prBodyOutput); err != nil {
+			return err
+		}
+		return common.AtomicWriteFile(prBodyOutput, func(f *os.File) error {
+			return write(f)
+		})
+	}
+	return write(os.Stdout)
+}
+
+// prValidation keeps the validation issues found on the given features
+func prValidation(result *validator.ValidationResult, prFeatures []*fogit.Feature) features.PRValidation {
+	ids := make(map[string]bool, len(prFeatures))
+	for _, f := range prFeatures {
+		ids[f.ID] = true
+	}
+
+	var v features.PRValidation
+	for _, issue := range result.Issues {
+		if !ids[issue.FeatureID] {
+			continue
+		}
+		if issue.Severity == validator.SeverityError {
+			v.Errors++
+		} else {
+			v.Warnings++
+		}
+		v.Issues = append(v.Issues, features.PRIssue{
+			Code:     string(issue.Code),
+			Severity: string(issue.Severity),
+			Feature:  issue.FeatureName,
+			Message:  issue.Message,
+		})
+	}
+	v.Passed = v.Errors == 0 && v.Warnings == 0
+	return v
+}
+
+// initPRTemplate writes the built-in PR template so it can be customized
+func initPRTemplate(fogitDir string) error {
+	path := filepath.Join(storage.TemplatesDir(fogitDir), storage.PRTemplateFile)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(printer.DefaultPRBodyTemplate), 0644); err != nil { //nolint:gosec // templates are committed
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	fmt.Printf("✓ Wrote %s\n", path)
+	return nil
+}
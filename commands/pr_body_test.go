@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestPRBodyCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	if out, err := exec.Command("git", "init", tmpDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	ResetFlags()
+	rootCmd.SetArgs([]string{"-C", tmpDir, "init"})
+	captureStdout(t, func() {
+		if err := ExecuteRootCmd(); err != nil {
+			t.Fatalf("init failed: %v", err)
+		}
+	})
+
+	fogitDir := filepath.Join(tmpDir, ".fogit")
+	repo := getRepository(fogitDir)
+	auth := fogit.NewFeature("Auth")
+	billing := fogit.NewFeature("Billing")
+	billing.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", auth.ID, auth.Name)}
+	auth.Relationships = []fogit.Relationship{fogit.NewRelationship("required-by", billing.ID, billing.Name)}
+	for _, f := range []*fogit.Feature{auth, billing} {
+		if err := repo.Create(context.Background(), f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	run := func(args ...string) string {
+		t.Helper()
+		ResetFlags()
+		rootCmd.SetArgs(append([]string{"-C", tmpDir, "pr-body"}, args...))
+		return captureStdout(t, func() {
+			if err := ExecuteRootCmd(); err != nil {
+				t.Fatalf("pr-body %v failed: %v", args, err)
+			}
+		})
+	}
+
+	if out := run("Auth"); !strings.Contains(out, "### Auth") || !strings.Contains(out, "- **Billing** (depends-on)") {
+		t.Errorf("expected Auth with Billing impacted, got:\n%s", out)
+	}
+
+	tmplPath := filepath.Join(fogitDir, "templates", "pr.md.tmpl")
+	if err := os.MkdirAll(filepath.Dir(tmplPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(tmplPath, []byte("{{range .Features}}{{.Name}}: {{len .Impacts}} impacted{{end}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out := run("Auth"); out != "Auth: 1 impacted" {
+		t.Errorf("custom template output = %q", out)
+	}
+}
@@ -24,6 +24,8 @@ This command:
 - Pushes feature metadata (.fogit/ directory)
 - Works in both branch-per-feature and trunk-based modes
 
+Use 'fogit sync' to fetch and integrate changes from the remote.
+
 Examples:
   fogit push
   fogit push --remote upstream
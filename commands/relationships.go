@@ -69,7 +69,7 @@ func runRelationships(cmd *cobra.Command, args []string) error {
 		outgoing = filterRelationshipsByTypesLocal(feature.GetRelationships(""), relTypes)
 	}
 
-	// Get incoming relationships (by scanning all features)
+	// Get incoming relationships (via the reverse-relationship index when available)
 	var incoming []features.RelationshipWithSource
 	if relDirection == "incoming" || relDirection == "both" {
 		incoming, err = features.FindIncomingRelationshipsMultiType(cmdCtx.Repo, ctx, feature.ID, relTypes)
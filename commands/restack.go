@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/git"
+)
+
+var restackDryRun bool
+
+var restackCmd = &cobra.Command{
+	Use:   "restack [branch]",
+	Short: "Rebase stacked feature branches onto their parents",
+	Long: `Rebase feature branches that are stacked on another feature's branch.
+
+A branch created with 'fogit feature <name> --stack-on <feature>' records the
+branch it was created from. This command:
+- Rebases branches whose parent was merged onto the base branch, and records
+  that they are no longer stacked
+- Rebases branches whose parent has new commits onto the parent's tip
+- Restacks parents before their children, so whole stacks move together
+
+Give a branch to only restack the branches stacked on it. 'fogit merge' does
+this automatically for the branch it merges.
+
+If a rebase stops on conflicts it is aborted, the branch is left as it was
+and the remaining branches are not restacked.
+
+Examples:
+  fogit restack
+  fogit restack feature/user-authentication
+  fogit restack --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRestack,
+}
+
+func init() {
+	restackCmd.Flags().BoolVar(&restackDryRun, "dry-run", false, "Show the branches that would be restacked")
+	rootCmd.AddCommand(restackCmd)
+}
+
+func runRestack(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if cmdCtx.Git == nil || cmdCtx.Git.GetGitRepo() == nil {
+		return fmt.Errorf("not in a git repository")
+	}
+
+	opts := features.RestackOptions{
+		BaseBranch: cmdCtx.Config.Workflow.BaseBranch,
+		DryRun:     restackDryRun,
+	}
+	if len(args) > 0 {
+		opts.Parent = args[0]
+	}
+
+	restacked, err := features.Restack(cmd.Context(), cmdCtx.Git.GetGitRepo(), opts)
+	for _, r := range restacked {
+		prefix := "✓ Restacked"
+		if restackDryRun {
+			prefix = "  Would restack"
+		}
+		if r.NewParent != r.OldParent {
+			fmt.Printf("%s %s onto %s (%s was merged)\n", prefix, r.Branch, r.NewParent, r.OldParent)
+		} else {
+			fmt.Printf("%s %s onto %s\n", prefix, r.Branch, r.NewParent)
+		}
+	}
+	if err != nil {
+		if errors.Is(err, git.ErrRebaseConflict) {
+			return fmt.Errorf("%w; the branch is unchanged. Rebase it manually, then run 'fogit restack' again", err)
+		}
+		return err
+	}
+
+	if len(restacked) == 0 {
+		fmt.Println("All stacked branches are up to date")
+	}
+	return nil
+}
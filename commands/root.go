@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -37,8 +38,27 @@ var (
 
 	// originalDir stores the directory before -C flag is applied (for restoration)
 	originalDir string
+
+	// atRef is the ref, tag or date from --at; features are read from that commit
+	atRef string
 )
 
+// pointInTimeCommands are the read-only commands that accept --at
+var pointInTimeCommands = map[string]bool{
+	"list":          true,
+	"show":          true,
+	"tree":          true,
+	"impacts":       true,
+	"stats":         true,
+	"filter":        true,
+	"search":        true,
+	"export":        true,
+	"graph":         true,
+	"versions":      true,
+	"relationships": true,
+	"docs build":    true,
+}
+
 // BuildVersion returns the full version string
 func BuildVersion() string {
 	return fmt.Sprintf("%s (commit: %.7s, built: %s)", Version, Commit, Date)
@@ -88,6 +108,10 @@ var rootCmd = &cobra.Command{
 		// Initialize logger based on flags
 		initLogger()
 
+		if atRef != "" && !pointInTimeCommands[strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")] {
+			return fmt.Errorf("--at is not supported by '%s': only read-only commands can query the past", cmd.CommandPath())
+		}
+
 		logger.Debug("starting command",
 			"command", cmd.Name(),
 			"args", args,
@@ -187,6 +211,7 @@ func ResetFlags() {
 	debugMode = false
 	verboseMode = false
 	globalConfig = nil
+	atRef = ""
 
 	// Reset all persistent flags on rootCmd
 	rootCmd.Flags().VisitAll(func(f *pflag.Flag) {
@@ -233,6 +258,7 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&workDir, "directory", "C", "", "Run as if fogit was started in `<path>` instead of the current directory")
 	rootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Enable debug logging (shows all diagnostic messages)")
 	rootCmd.PersistentFlags().BoolVar(&verboseMode, "verbose", false, "Enable verbose output (shows info-level messages)")
+	rootCmd.PersistentFlags().StringVar(&atRef, "at", "", "Read features as of a Git ref, tag, commit or date (YYYY-MM-DD) instead of the working tree")
 
 	// Per spec: -v is shorthand for --version
 	rootCmd.Flags().BoolP("version", "v", false, "version for fogit")
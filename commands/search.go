@@ -3,22 +3,43 @@ package commands
 import (
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/eg3r/fogit/internal/common"
 	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/search"
+	"github.com/eg3r/fogit/internal/storage"
 	"github.com/eg3r/fogit/pkg/fogit"
 )
 
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search features by text",
-	Long: `Search for features by text in name, description, type, category, and tags.
+	Long: `Search features by text, ranked by relevance.
 
-The search is case-insensitive and matches partial text.
-In branch-per-feature mode (default), automatically searches across all branches.
+Searches the name, description, tags, metadata values, version notes and
+relationship descriptions of features. Words are matched case-insensitively
+by their stem ("authenticating" finds "authentication") and as the start of
+longer words ("auth" finds "authentication"). Common words such as "the" are
+ignored.
+
+Query syntax:
+  word             Features containing the word (all words must match)
+  "some phrase"    Words next to each other, in this order
+  field:word       Only match in one field: name, description, tags,
+                   metadata, notes or relationships
+  -word            Leave out features containing the word
+
+Results are ranked with BM25. Matches in the name count most, then tags,
+then the other fields; --boost changes the weight of a field.
+
+The index is kept in .fogit/metadata/search_index.json and only features that
+changed since the last search are re-indexed.
+
+In branch-per-feature mode, automatically searches across all branches.
 In trunk-based mode, searches only the current branch.
 
 Examples:
@@ -31,9 +52,18 @@ Examples:
   # Search with multiple words (AND logic)
   fogit search "user authentication"
 
+  # Search for an exact phrase, only in descriptions
+  fogit search 'description:"single sign on"'
+
+  # Leave out legacy features and weigh tags higher
+  fogit search 'payment -legacy' --boost tags=5
+
   # Search and filter by state
   fogit search login --state open
 
+  # Search and filter with an expression (see 'fogit filter --help')
+  fogit search login --filter "has(metadata.jira) AND state!=closed"
+
   # Search and show in JSON format
   fogit search api --format json
 `,
@@ -47,9 +77,17 @@ var (
 	searchType        string
 	searchCategory    string
 	searchFormat      string
+	searchFilter      string
 	searchAllBranches bool // Cross-branch discovery per spec
+	searchBoosts      []string
+	searchLimit       int
 )
 
+// searchIndexProvider is implemented by repositories that persist a full-text search index
+type searchIndexProvider interface {
+	SearchIndex() *storage.SearchIndex
+}
+
 func init() {
 	rootCmd.AddCommand(searchCmd)
 
@@ -57,7 +95,10 @@ func init() {
 	searchCmd.Flags().StringVar(&searchPriority, "priority", "", "Filter by priority")
 	searchCmd.Flags().StringVar(&searchType, "type", "", "Filter by type")
 	searchCmd.Flags().StringVar(&searchCategory, "category", "", "Filter by category")
+	searchCmd.Flags().StringVar(&searchFilter, "filter", "", "Filter expression (see 'fogit filter --help')")
 	searchCmd.Flags().StringVar(&searchFormat, "format", "table", "Output format: table, json, csv")
+	searchCmd.Flags().StringSliceVar(&searchBoosts, "boost", nil, "Field weight as field=weight (repeatable), e.g. tags=5")
+	searchCmd.Flags().IntVarP(&searchLimit, "limit", "n", 0, "Show at most this many results (0 = all)")
 
 	// Cross-branch discovery is automatic in branch-per-feature mode per spec/specification/07-git-integration.md
 	// This flag allows overriding to only search current branch
@@ -67,15 +108,23 @@ func init() {
 func runSearch(cmd *cobra.Command, args []string) error {
 	query := args[0]
 
+	q := search.ParseQuery(query)
+	if q.IsEmpty() {
+		return fmt.Errorf("search query %q has no words to search for", query)
+	}
+	boosts, err := parseSearchBoosts(searchBoosts)
+	if err != nil {
+		return err
+	}
+
 	// Get command context
 	cmdCtx, err := GetCommandContext()
 	if err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 
-	// Build filter with search query
+	// Filter applied to the matching features
 	filter := &fogit.Filter{
-		Search:   query,
 		State:    fogit.State(searchState),
 		Priority: fogit.Priority(searchPriority),
 		Type:     searchType,
@@ -83,39 +132,75 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	}
 
 	// Validate filter
-	if validateErr := filter.Validate(); validateErr != nil {
+	if validateErr := filter.ValidateWith(cmdCtx.Config.Workflow.StateMachine()); validateErr != nil {
 		return validateErr
 	}
 
+	expr, err := parseFilterExpression(searchFilter, cmdCtx.Config)
+	if err != nil {
+		return err
+	}
+
 	// Apply timeout to prevent hanging on slow filesystems
 	ctx, cancel := WithSearchTimeout(cmd.Context())
 	defer cancel()
 
-	var featuresList []*fogit.Feature
+	var allFeatures []*fogit.Feature
 
 	// Per spec/specification/07-git-integration.md#cross-branch-feature-discovery:
 	// In branch-per-feature mode, cross-branch discovery is AUTOMATIC
 	// Use --current-branch to override and only search current branch
 	if searchAllBranches {
 		// --current-branch flag: search features on current branch only
-		featuresList, err = cmdCtx.Repo.List(ctx, filter)
+		allFeatures, err = cmdCtx.Repo.List(ctx, &fogit.Filter{})
 		if err != nil {
 			return fmt.Errorf("failed to search features: %w", err)
 		}
 	} else {
 		// Use shared cross-branch helper (handles mode check internally)
-		featuresList, err = ListFeaturesCrossBranch(ctx, cmdCtx, filter)
+		allFeatures, err = ListFeaturesCrossBranch(ctx, cmdCtx, &fogit.Filter{})
 		if err != nil {
 			return fmt.Errorf("failed to search features: %w", err)
 		}
 	}
 
+	// With --current-branch, relationship predicates still see every branch
+	graphFeatures := allFeatures
+	if searchAllBranches {
+		graphFeatures = nil
+	}
+	if err := bindFilterExpression(ctx, cmdCtx, expr, graphFeatures); err != nil {
+		return err
+	}
+
+	index := syncSearchIndex(cmdCtx.Repo, allFeatures)
+	byID := make(map[string]*fogit.Feature, len(allFeatures))
+	for _, f := range allFeatures {
+		byID[f.ID] = f
+	}
+
+	var results []searchResult
+	for _, r := range index.Search(q, search.SearchOptions{Boosts: boosts}) {
+		if f := byID[r.ID]; f != nil && filter.Matches(f) && expr.Matches(f) {
+			results = append(results, searchResult{Result: r, Feature: f})
+		}
+	}
+	if searchLimit > 0 && len(results) > searchLimit {
+		results = results[:searchLimit]
+	}
+
 	// Check if empty
-	if len(featuresList) == 0 {
+	if len(results) == 0 {
 		fmt.Printf("No features found matching '%s'\n", query)
+		suggestSimilarFeatures(query, allFeatures, cmdCtx.Config)
 		return nil
 	}
 
+	featuresList := make([]*fogit.Feature, len(results))
+	for i, r := range results {
+		featuresList[i] = r.Feature
+	}
+
 	// Display results
 	fmt.Printf("Found %d feature(s) matching '%s':\n\n", len(featuresList), query)
 
@@ -126,13 +211,81 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	case "csv":
 		return printer.OutputCSV(os.Stdout, featuresList)
 	default:
-		outputSearchResults(featuresList, query)
+		outputSearchResults(results, q)
 		return nil
 	}
 }
 
-func outputSearchResults(features []*fogit.Feature, query string) {
-	for i, f := range features {
+// searchResult is a ranked search match
+type searchResult struct {
+	search.Result
+	Feature *fogit.Feature
+}
+
+// syncSearchIndex brings the repository's search index up to date with the
+// given features and saves it. Repositories without a persisted index get a
+// throwaway one.
+func syncSearchIndex(repo fogit.Repository, features []*fogit.Feature) *search.Index {
+	provider, ok := repo.(searchIndexProvider)
+	if !ok {
+		index := search.NewIndex()
+		index.Sync(features)
+		return index
+	}
+
+	persisted := provider.SearchIndex()
+	present := make(map[string]bool, len(features))
+	for _, f := range features {
+		present[f.ID] = true
+	}
+	persisted.Sync(features)
+	persisted.Prune(present)
+	if persisted.IsDirty() {
+		_ = persisted.Save() // Best effort
+	}
+	return persisted.Index
+}
+
+// parseSearchBoosts parses field=weight pairs
+func parseSearchBoosts(values []string) (map[string]float64, error) {
+	boosts := make(map[string]float64)
+	for _, value := range values {
+		field, weight := common.SplitKeyValueEquals(value)
+		field = strings.ToLower(field)
+		if !search.IsField(field) {
+			return nil, fmt.Errorf("invalid --boost %q: unknown field %q (valid: %s)", value, field, strings.Join(search.Fields, ", "))
+		}
+		w, err := strconv.ParseFloat(weight, 64)
+		if err != nil || w < 0 {
+			return nil, fmt.Errorf("invalid --boost %q: weight must be a non-negative number", value)
+		}
+		boosts[field] = w
+	}
+	return boosts, nil
+}
+
+// suggestSimilarFeatures prints features with a similar name when a search finds nothing
+func suggestSimilarFeatures(query string, features []*fogit.Feature, cfg *fogit.Config) {
+	if !cfg.FeatureSearch.FuzzyMatch {
+		return
+	}
+	matches := search.FindSimilar(query, features, search.SearchConfig{
+		FuzzyMatch:     cfg.FeatureSearch.FuzzyMatch,
+		MinSimilarity:  cfg.FeatureSearch.MinSimilarity,
+		MaxSuggestions: cfg.FeatureSearch.MaxSuggestions,
+	})
+	if len(matches) == 0 {
+		return
+	}
+	fmt.Println("\nDid you mean:")
+	for _, m := range matches {
+		fmt.Printf("  %s (%.0f%% match)\n", m.Feature.Name, m.Score)
+	}
+}
+
+func outputSearchResults(results []searchResult, q search.Query) {
+	for i, r := range results {
+		f := r.Feature
 		if i > 0 {
 			fmt.Println()
 		}
@@ -151,24 +304,11 @@ func outputSearchResults(features []*fogit.Feature, query string) {
 		if category := f.GetCategory(); category != "" {
 			fmt.Printf("Category: %s\n", category)
 		}
+		fmt.Printf("Score:    %.2f (%s)\n", r.Score, strings.Join(r.Fields, ", "))
 
-		// Show description snippet if it matches
-		if f.Description != "" && common.ContainsIgnoreCase(f.Description, query) {
-			snippet := common.GetSnippet(f.Description, query, 100)
-			fmt.Printf("Description: %s\n", snippet)
-		}
-
-		// Show matching tags
-		if len(f.Tags) > 0 {
-			matchingTags := []string{}
-			for _, tag := range f.Tags {
-				if common.ContainsIgnoreCase(tag, query) {
-					matchingTags = append(matchingTags, tag)
-				}
-			}
-			if len(matchingTags) > 0 {
-				fmt.Printf("Tags:     %s\n", strings.Join(matchingTags, ", "))
-			}
+		// Show where the best non-name match is, with the matching words marked
+		if field, snippet := search.Snippet(f, q, 100, "**", "**"); snippet != "" {
+			fmt.Printf("%s: %s\n", strings.ToUpper(field[:1])+field[1:], snippet)
 		}
 	}
 }
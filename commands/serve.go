@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/server"
+)
+
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the web UI and HTTP JSON API",
+	Long: `Serve the feature repository over an HTTP JSON API, with a web UI at /.
+
+The web UI lists and filters features, shows feature details with their version
+history, and draws the relationship graph colored by relationship category.
+Set metadata.color on a category in .fogit/config.yml to choose its color.
+
+The server reads and writes the features in the current working tree; it never
+creates, switches or commits git branches. Responses use the same JSON as
+'--format json' output. Errors are returned as {"error": "..."}.
+
+Endpoints:
+  GET    /api/features                       List features (filters: state, priority, type,
+                                             category, domain, team, epic, parent, tag,
+                                             contributor, filter=<expression>, sort, order)
+  POST   /api/features                       Create a feature
+  GET    /api/features/{id}                  Get a feature by ID or name
+  PATCH  /api/features/{id}                  Update a feature
+  POST   /api/features/{id}/relationships    Link to another feature
+  DELETE /api/features/{id}/relationships/{relID}
+                                             Remove a relationship
+  GET    /api/features/{id}/impacts          Impact analysis (depth, all_categories,
+                                             include_category, exclude_category)
+  GET    /api/tree                           Hierarchy (root, depth, type)
+  GET    /api/validate                       Validate the repository
+  GET    /api/graph                          Relationship graph (filter=<expression>)
+
+Feature responses carry an ETag. Send it back in If-Match on PATCH, link and
+unlink requests to reject the change (412 Precondition Failed) when the feature
+was modified in the meantime. If-Match uses strong comparison, so weak (W/)
+tags never match.
+
+Requests must use the address the server listens on (localhost or an IP
+address also work), so other web sites can't reach the API through DNS
+rebinding. Writes must send Content-Type: application/json (415 otherwise),
+and writes from another origin are refused (403).
+
+Examples:
+  fogit serve
+  fogit serve --addr :8080
+  curl 'http://127.0.0.1:7420/api/features?filter=priority:high+AND+state:open'`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "127.0.0.1:7420", "Address to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	listener, err := net.Listen("tcp", serveAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", serveAddr, err)
+	}
+
+	srv := &http.Server{
+		Handler:           server.New(cmdCtx.Repo, cmdCtx.Config, cmdCtx.FogitDir, listener.Addr().String()).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	shutdownErr := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownErr <- srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Printf("Serving fogit on http://%s (Ctrl+C to stop)\n", listener.Addr())
+	if err := srv.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("server failed: %w", err)
+	}
+	return <-shutdownErr
+}
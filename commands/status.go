@@ -67,12 +67,12 @@ func runStatus(cmd *cobra.Command, args []string) error {
 
 	// Output based on format
 	textFn := func(w io.Writer) error {
-		return outputStatusText(w, report, featuresList)
+		return outputStatusText(w, report, featuresList, cmdCtx.Config.Workflow.StateMachine())
 	}
 	return printer.OutputFormatted(os.Stdout, statusFormat, report, textFn)
 }
 
-func outputStatusText(w io.Writer, report *features.StatusReport, featuresList []*fogit.Feature) error {
+func outputStatusText(w io.Writer, report *features.StatusReport, featuresList []*fogit.Feature, states *fogit.StateMachine) error {
 	fmt.Fprintf(w, "FoGit Repository Status\n")
 	fmt.Fprintf(w, "========================\n\n")
 
@@ -92,6 +92,11 @@ func outputStatusText(w io.Writer, report *features.StatusReport, featuresList [
 	fmt.Fprintf(w, "  Open:        %d\n", report.FeatureCounts.Open)
 	fmt.Fprintf(w, "  In Progress: %d\n", report.FeatureCounts.InProgress)
 	fmt.Fprintf(w, "  Closed:      %d\n", report.FeatureCounts.Closed)
+	for _, state := range states.States() {
+		if count, ok := report.FeatureCounts.Custom[string(state)]; ok {
+			fmt.Fprintf(w, "  %-12s %d\n", string(state)+":", count)
+		}
+	}
 	fmt.Fprintf(w, "\n")
 
 	// Features on current branch (if any)
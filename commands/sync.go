@@ -0,0 +1,179 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/config"
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/features/validator"
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+var (
+	syncRemote string
+	syncDryRun bool
+	syncFFOnly bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch from remote and update the current branch",
+	Long: `Fetch from a remote and bring the current branch up to date.
+
+This command:
+- Fetches the remote (counterpart of 'fogit push')
+- Fast-forwards the current branch to its remote branch, or rebases local
+  commits on top of it when both sides have new commits
+- Reports the feature changes that came in: new, closed, changed and removed
+  features, and new relationships
+- Validates features after updating (see 'fogit validate')
+
+Use --dry-run to preview incoming feature changes without touching the
+working tree. If a rebase stops on conflicts it is aborted and the branch is
+left as it was.
+
+Examples:
+  fogit sync
+  fogit sync --dry-run
+  fogit sync --remote upstream
+  fogit sync --ff-only`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncRemote, "remote", "origin", "Specify remote name")
+	syncCmd.Flags().BoolVar(&syncDryRun, "dry-run", false, "Show incoming feature changes without updating the branch")
+	syncCmd.Flags().BoolVar(&syncFFOnly, "ff-only", false, "Fail instead of rebasing when the branch has diverged")
+
+	rootCmd.AddCommand(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+	if cmdCtx.Git == nil || cmdCtx.Git.GetGitRepo() == nil {
+		return fmt.Errorf("not in a git repository")
+	}
+	gitRepo := cmdCtx.Git.GetGitRepo()
+
+	remotes, err := gitRepo.GetRemotes()
+	if err != nil {
+		return fmt.Errorf("failed to get remotes: %w", err)
+	}
+	if len(remotes) == 0 {
+		return fmt.Errorf("no remotes configured. Add a remote with 'git remote add origin <url>'")
+	}
+	if !slices.Contains(remotes, syncRemote) {
+		return fmt.Errorf("remote '%s' not found. Available remotes: %v", syncRemote, remotes)
+	}
+
+	fmt.Printf("Fetching from '%s'...\n", syncRemote)
+	result, err := features.Sync(gitRepo, features.SyncOptions{
+		Remote: syncRemote,
+		FFOnly: syncFFOnly,
+		DryRun: syncDryRun,
+	})
+	if result != nil && !result.UpToDate {
+		printIncomingChanges(result, cmdCtx.Config.Workflow.StateMachine())
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, features.ErrBranchDiverged):
+			return fmt.Errorf("'%s' and '%s' have diverged; run without --ff-only to rebase", result.Branch, result.Upstream)
+		case errors.Is(err, git.ErrRebaseConflict):
+			return fmt.Errorf("rebasing onto '%s' stopped on conflicts and was aborted; the branch is unchanged. Rebase or merge manually", result.Upstream)
+		}
+		return err
+	}
+
+	switch {
+	case result.UpToDate:
+		fmt.Printf("✓ '%s' is up to date with '%s'\n", result.Branch, result.Upstream)
+		return nil
+	case !result.Updated:
+		fmt.Println("\nDry run: working tree not updated.")
+		return nil
+	case result.Rebased:
+		fmt.Printf("\n✓ Rebased '%s' onto '%s'\n", result.Branch, result.Upstream)
+	default:
+		fmt.Printf("\n✓ Fast-forwarded '%s' to '%s'\n", result.Branch, result.Upstream)
+	}
+
+	// Validate what came in; the config itself may have changed
+	cfg, err := config.Load(cmdCtx.FogitDir)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	cmdCtx.Config = cfg
+
+	ctx, cancel := WithValidateTimeout(cmd.Context())
+	defer cancel()
+	featuresList, err := listAllFeatures(ctx, cmdCtx)
+	if err != nil {
+		return err
+	}
+	validation, err := validator.New(cmdCtx.Repo, cfg).ValidateFeatures(ctx, featuresList)
+	if err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+	fmt.Println("\nValidating features...")
+	printer.PrintValidationResult(validation)
+
+	return nil
+}
+
+// printIncomingChanges prints the feature-level summary of a sync. Features
+// entering any closed state of the workflow are listed as closed.
+func printIncomingChanges(result *features.SyncResult, states *fogit.StateMachine) {
+	fmt.Printf("↓ %d commit(s) from '%s'\n", result.IncomingCommits, result.Upstream)
+
+	diff := result.Incoming
+	if !diff.HasDifferences() && len(result.NewRelationships) == 0 {
+		fmt.Println("\nNo feature changes.")
+		return
+	}
+
+	fmt.Println("\nIncoming feature changes:")
+	for _, f := range diff.Added {
+		fmt.Printf("  + New:      %s (%s)\n", f.Name, f.State)
+	}
+	for _, c := range diff.Changed {
+		if c.NewState != "" && states.IsClosed(fogit.State(c.NewState)) {
+			fmt.Printf("  ✓ Closed:   %s\n", c.Name)
+			continue
+		}
+		fields := make([]string, 0, len(c.Changes))
+		for _, fc := range c.Changes {
+			if !strings.HasPrefix(fc.Field, "relationship:") {
+				fields = append(fields, fc.Field)
+			}
+		}
+		if len(fields) == 0 && c.OldPath == "" {
+			continue // Only relationships changed; listed below
+		}
+		if c.OldPath != "" {
+			fields = append(fields, "renamed from "+c.OldPath)
+		}
+		fmt.Printf("  ~ Changed:  %s (%s)\n", c.Name, strings.Join(fields, ", "))
+	}
+	for _, f := range diff.Removed {
+		fmt.Printf("  - Removed:  %s\n", f.Name)
+	}
+	for _, r := range result.NewRelationships {
+		target := r.TargetName
+		if target == "" {
+			target = r.TargetID
+		}
+		fmt.Printf("  → Related:  %s %s %s\n", r.SourceName, r.Type, target)
+	}
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncCommand(t *testing.T) {
+	tmpDir := t.TempDir()
+	localDir := filepath.Join(tmpDir, "local")
+	remoteDir := filepath.Join(tmpDir, "remote.git")
+	otherDir := filepath.Join(tmpDir, "other")
+
+	gitRun := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(localDir, "init", "-b", "main")
+	gitRun(localDir, "config", "user.name", "Test")
+	gitRun(localDir, "config", "user.email", "test@example.com")
+	ResetFlags()
+	rootCmd.SetArgs([]string{"-C", localDir, "init"})
+	captureStdout(t, func() {
+		if err := ExecuteRootCmd(); err != nil {
+			t.Fatalf("init failed: %v", err)
+		}
+	})
+	gitRun(localDir, "add", "-A")
+	gitRun(localDir, "commit", "-m", "Initialize fogit")
+	gitRun(localDir, "init", "--bare", remoteDir)
+	gitRun(localDir, "remote", "add", "origin", remoteDir)
+	gitRun(localDir, "push", "-u", "origin", "main")
+
+	// Someone else pushes a new feature
+	gitRun(tmpDir, "clone", "-b", "main", remoteDir, otherDir)
+	featurePath := filepath.Join(otherDir, ".fogit", "features", "billing.yml")
+	if err := os.MkdirAll(filepath.Dir(featurePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(featurePath, []byte("id: billing-456\nname: Billing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(otherDir, "add", "-A")
+	gitRun(otherDir, "-c", "user.name=Other", "-c", "user.email=other@example.com", "commit", "-m", "Add billing")
+	gitRun(otherDir, "push", "origin", "main")
+
+	sync := func(args ...string) string {
+		t.Helper()
+		ResetFlags()
+		rootCmd.SetArgs(append([]string{"-C", localDir, "sync"}, args...))
+		return captureStdout(t, func() {
+			if err := ExecuteRootCmd(); err != nil {
+				t.Fatalf("sync %v failed: %v", args, err)
+			}
+		})
+	}
+
+	out := sync("--dry-run")
+	for _, want := range []string{"+ New:      Billing", "Dry run"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(localDir, ".fogit", "features", "billing.yml")); err == nil {
+		t.Error("dry run must not update the working tree")
+	}
+
+	out = sync()
+	if !strings.Contains(out, "Fast-forwarded 'main' to 'origin/main'") {
+		t.Errorf("expected a fast-forward, got:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(localDir, ".fogit", "features", "billing.yml")); err != nil {
+		t.Errorf("expected the incoming feature in the working tree: %v", err)
+	}
+
+	if out := sync(); !strings.Contains(out, "up to date") {
+		t.Errorf("expected up to date, got:\n%s", out)
+	}
+}
@@ -17,6 +17,7 @@ var tagCmd = &cobra.Command{
 
 Tags are Git annotated tags that mark specific points in your repository history.
 Use tags to mark releases, milestones, or significant feature versions.
+Generate release notes between two tags with 'fogit changelog --from <tag> --to <tag>'.
 
 Subcommands:
   create <name>  - Create a new annotated tag
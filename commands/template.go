@@ -0,0 +1,262 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"templates"},
+	Short:   "Manage feature templates",
+	Long: `Manage feature templates stored in .fogit/templates/.
+
+A template holds defaults for new features: a description skeleton, type,
+priority, tags, metadata values, required metadata keys and relationships
+(for example to a parent epic). Apply one with 'fogit feature "Name" --template <name>'.
+
+A template is named after its file: .fogit/templates/<name>.yml. A 'name:' set
+in the file must match the file name.
+
+Examples:
+  fogit template list
+  fogit template show api-endpoint
+  fogit template create api-endpoint --type api-endpoint --tags api --require owner`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List feature templates",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplateList,
+}
+
+var templateShowCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a feature template",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runTemplateShow,
+}
+
+var templateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a feature template",
+	Long: `Create a feature template in .fogit/templates/<name>.yml.
+
+Metadata given as key= (empty value) is stored as a placeholder key.
+Relationships are given as type:target, where target is a feature ID or name
+resolved when a feature is created from the template.
+
+Examples:
+  # Template for API endpoints that must name an owner
+  fogit template create api-endpoint --type api-endpoint -p high --tags api \
+    --metadata sla=99.9 --require owner --link contained-by:"API Platform"
+
+  # Start from an existing feature's type, priority, tags and metadata
+  fogit template create bugfix --from "Fix login timeout"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTemplateCreate,
+}
+
+var (
+	templateFormat             string
+	templateDescription        string
+	templateType               string
+	templatePriority           string
+	templateFeatureDescription string
+	templateTags               []string
+	templateMetadata           []string
+	templateRequire            []string
+	templateLinks              []string
+	templateFrom               string
+	templateForce              bool
+)
+
+func init() {
+	templateListCmd.Flags().StringVar(&templateFormat, "format", "text", "Output format: text, json, yaml")
+	templateShowCmd.Flags().StringVar(&templateFormat, "format", "text", "Output format: text, json, yaml")
+
+	templateCreateCmd.Flags().StringVarP(&templateDescription, "description", "d", "", "What the template is for")
+	templateCreateCmd.Flags().StringVar(&templateType, "type", "", "Default feature type")
+	templateCreateCmd.Flags().StringVarP(&templatePriority, "priority", "p", "", "Default priority (low, medium, high, critical)")
+	templateCreateCmd.Flags().StringVar(&templateFeatureDescription, "feature-description", "", "Description skeleton for new features")
+	templateCreateCmd.Flags().StringSliceVar(&templateTags, "tags", nil, "Comma-separated default tags")
+	templateCreateCmd.Flags().StringSliceVar(&templateMetadata, "metadata", nil, "Default metadata (key=value, repeatable)")
+	templateCreateCmd.Flags().StringSliceVar(&templateRequire, "require", nil, "Metadata keys that must be set (repeatable)")
+	templateCreateCmd.Flags().StringArrayVar(&templateLinks, "link", nil, "Default relationship as type:target (repeatable)")
+	templateCreateCmd.Flags().StringVar(&templateFrom, "from", "", "Copy defaults from an existing feature (ID or name)")
+	templateCreateCmd.Flags().BoolVar(&templateForce, "force", false, "Overwrite an existing template")
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateShowCmd)
+	templateCmd.AddCommand(templateCreateCmd)
+	rootCmd.AddCommand(templateCmd)
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	templates, err := storage.ListTemplates(fogitDir)
+	if err != nil {
+		return err
+	}
+	if templates == nil {
+		templates = []*fogit.FeatureTemplate{}
+	}
+
+	textFn := func(w io.Writer) error {
+		if len(templates) == 0 {
+			fmt.Fprintln(w, "No templates defined")
+			fmt.Fprintln(w, "Create one with: fogit template create <name>")
+			return nil
+		}
+		for _, tpl := range templates {
+			desc := tpl.Description
+			if desc == "" {
+				desc = "No description"
+			}
+			fmt.Fprintf(w, "%-20s %s\n", tpl.Name, desc)
+		}
+		return nil
+	}
+
+	return printer.OutputFormatted(os.Stdout, templateFormat, templates, textFn)
+}
+
+func runTemplateShow(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	tpl, err := storage.LoadTemplate(fogitDir, args[0])
+	if err != nil {
+		return err
+	}
+
+	textFn := func(w io.Writer) error {
+		return outputTemplateText(w, tpl)
+	}
+	return printer.OutputFormatted(os.Stdout, templateFormat, tpl, textFn)
+}
+
+func outputTemplateText(w io.Writer, tpl *fogit.FeatureTemplate) error {
+	fmt.Fprintf(w, "Template: %s\n", tpl.Name)
+	if tpl.Description != "" {
+		fmt.Fprintf(w, "  Description: %s\n", tpl.Description)
+	}
+	if tpl.Type != "" {
+		fmt.Fprintf(w, "  Type: %s\n", tpl.Type)
+	}
+	if tpl.Priority != "" {
+		fmt.Fprintf(w, "  Priority: %s\n", tpl.Priority)
+	}
+	if len(tpl.Tags) > 0 {
+		fmt.Fprintf(w, "  Tags: %s\n", strings.Join(tpl.Tags, ", "))
+	}
+	if len(tpl.Metadata) > 0 {
+		fmt.Fprintln(w, "  Metadata:")
+		keys := make([]string, 0, len(tpl.Metadata))
+		for k := range tpl.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "    %s: %v\n", k, tpl.Metadata[k])
+		}
+	}
+	if len(tpl.RequiredMetadata) > 0 {
+		fmt.Fprintf(w, "  Required metadata: %s\n", strings.Join(tpl.RequiredMetadata, ", "))
+	}
+	if len(tpl.Relationships) > 0 {
+		fmt.Fprintln(w, "  Relationships:")
+		for _, rel := range tpl.Relationships {
+			fmt.Fprintf(w, "    %s -> %s\n", rel.Type, rel.Target)
+		}
+	}
+	if tpl.FeatureDescription != "" {
+		fmt.Fprintln(w, "  Feature description:")
+		for _, line := range strings.Split(strings.TrimRight(tpl.FeatureDescription, "\n"), "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+	return nil
+}
+
+func runTemplateCreate(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+
+	tpl := &fogit.FeatureTemplate{Name: args[0]}
+
+	if templateFrom != "" {
+		source, err := FindFeatureCrossBranch(cmd.Context(), cmdCtx, templateFrom, "fogit template create <name> --from <id>")
+		if err != nil {
+			return err
+		}
+		tpl.Type = source.GetType()
+		tpl.Priority = string(source.GetPriority())
+		tpl.Tags = source.Tags
+		if len(source.Metadata) > 0 {
+			tpl.Metadata = make(map[string]interface{}, len(source.Metadata))
+			for k, v := range source.Metadata {
+				tpl.Metadata[k] = v
+			}
+		}
+	}
+
+	tpl.Description = templateDescription
+	tpl.Type = common.Coalesce(templateType, tpl.Type)
+	tpl.Priority = common.Coalesce(templatePriority, tpl.Priority)
+	tpl.FeatureDescription = templateFeatureDescription
+	if len(templateTags) > 0 {
+		tpl.Tags = templateTags
+	}
+	for _, pair := range templateMetadata {
+		key, value := common.SplitKeyValueEquals(pair)
+		if key == "" {
+			return fmt.Errorf("invalid metadata %q: expected key=value", pair)
+		}
+		if tpl.Metadata == nil {
+			tpl.Metadata = make(map[string]interface{})
+		}
+		tpl.Metadata[key] = value
+	}
+	tpl.RequiredMetadata = templateRequire
+	for _, link := range templateLinks {
+		relType, target := common.SplitKeyValue(link, ":")
+		if relType == "" || target == "" {
+			return fmt.Errorf("invalid link %q: expected type:target", link)
+		}
+		rel := fogit.Relationship{Type: fogit.RelationshipType(relType), TargetID: target}
+		if err := rel.ValidateWithConfig(cmdCtx.Config); err != nil {
+			return err
+		}
+		tpl.Relationships = append(tpl.Relationships, fogit.TemplateRelationship{Type: string(rel.Type), Target: target})
+	}
+
+	path, err := storage.SaveTemplate(cmdCtx.FogitDir, tpl, templateForce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created template: %s\n", tpl.Name)
+	fmt.Printf("  Saved to: %s\n", path)
+	fmt.Printf("\nUse it with: fogit feature \"<name>\" --template %s\n", tpl.Name)
+	return nil
+}
@@ -27,6 +27,14 @@ const (
 	// DefaultValidateTimeout is the timeout for validation operations.
 	// This validates all features and their relationships.
 	DefaultValidateTimeout = 60 * time.Second
+
+	// DefaultHistoryTimeout is the timeout for history operations.
+	// This walks every commit on every ref, which takes long in big repositories.
+	DefaultHistoryTimeout = 10 * time.Minute
+
+	// DefaultBlameTimeout is the timeout for blame operations.
+	// This walks every commit reachable from the blamed ref.
+	DefaultBlameTimeout = 10 * time.Minute
 )
 
 // WithTimeout creates a child context with the specified timeout.
@@ -68,3 +76,13 @@ func WithImportTimeout(parent context.Context) (context.Context, context.CancelF
 func WithValidateTimeout(parent context.Context) (context.Context, context.CancelFunc) {
 	return WithTimeout(parent, DefaultValidateTimeout)
 }
+
+// WithHistoryTimeout creates a context with the default history operation timeout.
+func WithHistoryTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	return WithTimeout(parent, DefaultHistoryTimeout)
+}
+
+// WithBlameTimeout creates a context with the default blame operation timeout.
+func WithBlameTimeout(parent context.Context) (context.Context, context.CancelFunc) {
+	return WithTimeout(parent, DefaultBlameTimeout)
+}
@@ -17,6 +17,7 @@ var (
 	treeCategory string
 	treeState    string
 	treeFormat   string
+	treeFilter   string
 	treeTypes    []string // Changed from treeType to support multiple types
 )
 
@@ -26,7 +27,10 @@ var treeCmd = &cobra.Command{
 	Long: `Show hierarchical tree view of features based on their relationships.
 
 If a feature is specified, shows that feature as the root.
-Otherwise, shows all top-level features (those without parents).`,
+Otherwise, shows all top-level features (those without parents).
+With --filter, only features matching the filter expression are shown
+(see 'fogit filter --help').
+For DOT, Mermaid or GraphML diagrams, use 'fogit graph'.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runTree,
 }
@@ -35,6 +39,7 @@ func init() {
 	treeCmd.Flags().IntVar(&treeDepth, "depth", -1, "Maximum depth to show (-1 for unlimited)")
 	treeCmd.Flags().StringVar(&treeCategory, "category", "", "Filter by category")
 	treeCmd.Flags().StringVar(&treeState, "state", "", "Filter by state")
+	treeCmd.Flags().StringVar(&treeFilter, "filter", "", "Filter expression (see 'fogit filter --help')")
 	treeCmd.Flags().StringVar(&treeFormat, "format", "tree", "Output format: tree, json")
 	treeCmd.Flags().StringSliceVar(&treeTypes, "type", []string{}, "Relationship types for hierarchy (repeatable, default from config)")
 	rootCmd.AddCommand(treeCmd)
@@ -55,6 +60,11 @@ func runTree(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	expr, err := parseFilterExpression(treeFilter, cfg)
+	if err != nil {
+		return err
+	}
+
 	// Build filter
 	filter := &fogit.Filter{}
 	if treeCategory != "" {
@@ -69,8 +79,16 @@ func runTree(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to list features: %w", err)
 	}
+	allFeatures, err = applyFilterExpression(ctx, cmdCtx, expr, allFeatures, nil)
+	if err != nil {
+		return err
+	}
 
-	if len(allFeatures) == 0 {
+	if treeFormat != "tree" && treeFormat != "json" {
+		return fmt.Errorf("invalid format: must be one of tree, json")
+	}
+
+	if len(allFeatures) == 0 && treeFormat != "json" {
 		fmt.Println("No features found")
 		return nil
 	}
@@ -81,12 +99,23 @@ func runTree(cmd *cobra.Command, args []string) error {
 		if err != nil {
 			return err
 		}
+		if treeFormat == "json" {
+			return printer.OutputAsJSON(os.Stdout, features.BuildTree(feature, allFeatures, hierarchyTypes, treeDepth))
+		}
 		return printer.OutputTree(os.Stdout, feature, allFeatures, hierarchyTypes, treeDepth)
 	}
 
 	// Otherwise, find all root features (no relationships of hierarchy types pointing outward)
 	roots := features.FindRoots(allFeatures, hierarchyTypes)
 
+	if treeFormat == "json" {
+		nodes := make([]*features.TreeNode, 0, len(roots))
+		for _, root := range roots {
+			nodes = append(nodes, features.BuildTree(root, allFeatures, hierarchyTypes, treeDepth))
+		}
+		return printer.OutputAsJSON(os.Stdout, nodes)
+	}
+
 	if len(roots) == 0 {
 		typeList := strings.Join(hierarchyTypes, ", ")
 		fmt.Printf("No root features found (all features have '%s' relationships)\n", typeList)
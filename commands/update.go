@@ -33,6 +33,9 @@ Examples:
   # Update state
   fogit update "User Authentication" --state in-progress
 
+  # Move to a custom workflow state (configured in workflow.states)
+  fogit update "User Authentication" --state review
+
   # Update priority
   fogit update "Login Page" --priority critical
 
@@ -49,7 +52,7 @@ Examples:
 func init() {
 	rootCmd.AddCommand(updateCmd)
 
-	updateCmd.Flags().StringVar(&updateState, "state", "", "Update state (open, in-progress, closed)")
+	updateCmd.Flags().StringVar(&updateState, "state", "", "Update state (open, in-progress, closed, or a configured workflow state)")
 	updateCmd.Flags().StringVar(&updatePriority, "priority", "", "Update priority (low, medium, high, critical)")
 	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Update description")
 	updateCmd.Flags().StringVar(&updateType, "type", "", "Update type")
@@ -83,7 +86,11 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Prepare update options
-	opts := features.UpdateOptions{}
+	opts := features.UpdateOptions{
+		States:   cmdCtx.Config.Workflow.StateMachine(),
+		FogitDir: cmdCtx.FogitDir,
+		Schema:   cmdCtx.Config.MetadataSchema,
+	}
 
 	if cmd.Flags().Changed("name") {
 		if updateName == "" {
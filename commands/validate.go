@@ -23,6 +23,11 @@ Checks performed:
   [E004] Schema violations - invalid relationship structure
   [E005] Cycle violations - cycles in categories where not allowed
   [E006] Version constraint violations - target version doesn't satisfy constraint
+  [E007] Metadata violations - metadata doesn't match metadata_schema in config
+         (missing values with a schema default are fixable)
+
+The reverse-relationship index used to look up incoming relationships is also
+checked against the feature files and rebuilt if it is stale.
 
 Use --fix to attempt automatic repair of fixable issues.`,
 	RunE: runValidate,
@@ -63,20 +68,10 @@ func runValidate(cmd *cobra.Command, args []string) error {
 	// Create validator
 	v := validator.New(repo, cfg)
 
-	// Load features - use cross-branch discovery in branch-per-feature mode
-	// This ensures relationships to features on other branches are not marked as orphaned
-	var featuresList []*fogit.Feature
-	if cfg.Workflow.Mode == "branch-per-feature" && cmdCtx.Git != nil && cmdCtx.Git.GetGitRepo() != nil {
-		featuresList, err = ListFeaturesCrossBranch(ctx, cmdCtx, nil)
-		if err != nil {
-			return fmt.Errorf("failed to list features across branches: %w", err)
-		}
-	} else {
-		// Trunk-based mode or no git - use current branch only
-		featuresList, err = repo.List(ctx, nil)
-		if err != nil {
-			return fmt.Errorf("failed to list features: %w", err)
-		}
+	// Cross-branch discovery keeps relationships to features on other branches from being marked as orphaned
+	featuresList, err := listAllFeatures(ctx, cmdCtx)
+	if err != nil {
+		return err
 	}
 
 	result, err := v.ValidateFeatures(ctx, featuresList)
@@ -96,17 +91,10 @@ func runValidate(cmd *cobra.Command, args []string) error {
 			printer.PrintFixResult(fixResult)
 		}
 
-		// Re-validate after fixes - reload features using the same method
-		if cfg.Workflow.Mode == "branch-per-feature" && cmdCtx.Git != nil && cmdCtx.Git.GetGitRepo() != nil {
-			featuresList, err = ListFeaturesCrossBranch(ctx, cmdCtx, nil)
-			if err != nil {
-				return fmt.Errorf("failed to list features across branches: %w", err)
-			}
-		} else {
-			featuresList, err = repo.List(ctx, nil)
-			if err != nil {
-				return fmt.Errorf("failed to list features: %w", err)
-			}
+		// Re-validate after fixes
+		featuresList, err = listAllFeatures(ctx, cmdCtx)
+		if err != nil {
+			return err
 		}
 		result, err = v.ValidateFeatures(ctx, featuresList)
 		if err != nil {
@@ -114,6 +102,18 @@ func runValidate(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Rebuild the reverse-relationship index if it drifted from the feature files
+	if indexer, ok := repo.(fogit.ReverseIndexer); ok {
+		rebuilt, err := indexer.VerifyReverseIndex(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to verify reverse relationship index: %w", err)
+		}
+		if rebuilt && !validateQuiet {
+			fmt.Println("Reverse relationship index was stale and has been rebuilt")
+			fmt.Println()
+		}
+	}
+
 	// Output results
 	if !validateQuiet {
 		printer.PrintValidationResult(result)
@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+var viewCmd = &cobra.Command{
+	Use:     "view",
+	Aliases: []string{"views"},
+	Short:   "Manage saved views",
+	Long: `Manage saved views stored in .fogit/views/.
+
+A view is a named feature listing: a filter expression (see 'fogit filter --help')
+together with the 'fogit list' flags, sort field and order, and output format.
+Views are committed with the repository, so 'fogit view run <name>' shows the
+same list for everyone who pulls it.
+
+Examples:
+  fogit view save my-sprint --team backend --filter "state IN (open, in-progress)" --sort priority --order desc
+  fogit view run my-sprint
+  fogit view run my-sprint --format json
+  fogit view list
+  fogit view delete my-sprint`,
+}
+
+var viewRunCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "List the features of a saved view",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runViewRun,
+}
+
+var viewSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a view",
+	Long: `Save a view in .fogit/views/<name>.yml.
+
+Takes the same flags as 'fogit list', plus a description.
+
+Examples:
+  # Open high-priority work of the backend team, most important first
+  fogit view save backend-hot --team backend --filter "priority>=high AND state!=closed" \
+    --sort priority --order desc -d "What the backend team should pick up next"
+
+  # Replace an existing view
+  fogit view save backend-hot --team backend --state open --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: runViewSave,
+}
+
+var viewListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved views",
+	Args:  cobra.NoArgs,
+	RunE:  runViewList,
+}
+
+var viewDeleteCmd = &cobra.Command{
+	Use:     "delete <name>",
+	Aliases: []string{"rm"},
+	Short:   "Delete a saved view",
+	Args:    cobra.ExactArgs(1),
+	RunE:    runViewDelete,
+}
+
+var (
+	viewRunFormat  string
+	viewListFormat string
+	viewSaved      fogit.SavedView // Bound to the 'view save' flags
+	viewForce      bool
+)
+
+func init() {
+	viewRunCmd.Flags().StringVar(&viewRunFormat, "format", "", "Output format: table, json, csv, or a Go template (default: the view's format)")
+
+	flags := viewSaveCmd.Flags()
+	flags.StringVarP(&viewSaved.Description, "description", "d", "", "What the view shows")
+	flags.StringVar(&viewSaved.Filter, "filter", "", "Filter expression (see 'fogit filter --help')")
+	flags.StringVar(&viewSaved.State, "state", "", "Filter by state")
+	flags.StringVar(&viewSaved.Priority, "priority", "", "Filter by priority (low, medium, high, critical)")
+	flags.StringVar(&viewSaved.Type, "type", "", "Filter by type")
+	flags.StringVar(&viewSaved.Category, "category", "", "Filter by category")
+	flags.StringVar(&viewSaved.Domain, "domain", "", "Filter by domain")
+	flags.StringVar(&viewSaved.Team, "team", "", "Filter by team")
+	flags.StringVar(&viewSaved.Epic, "epic", "", "Filter by epic")
+	flags.StringVar(&viewSaved.Parent, "parent", "", "Show children of feature")
+	flags.StringSliceVar(&viewSaved.Tags, "tag", nil, "Filter by tag (can be used multiple times, AND logic)")
+	flags.StringVar(&viewSaved.Contributor, "contributor", "", "Filter by contributor email")
+	flags.BoolVarP(&viewSaved.CurrentBranch, "current-branch", "c", false, "List features from current branch only")
+	flags.StringVar(&viewSaved.Sort, "sort", "", "Sort by field: name, priority, created, modified (default: created)")
+	flags.StringVar(&viewSaved.Order, "order", "", "Sort order: asc, desc (default: asc)")
+	flags.StringSliceVar(&viewSaved.Columns, "columns", nil, "Columns to show, e.g. name,state,metadata.team,relationships.count")
+	flags.StringVar(&viewSaved.GroupBy, "group-by", "", "Group features by a field (\"default\": ui.default_group_by)")
+	flags.StringVar(&viewSaved.Format, "format", "", "Output format: table, json, csv, or a Go template (default: table)")
+	flags.BoolVar(&viewForce, "force", false, "Replace an existing view")
+
+	viewListCmd.Flags().StringVar(&viewListFormat, "format", "text", "Output format: text, json, yaml")
+
+	viewCmd.AddCommand(viewRunCmd)
+	viewCmd.AddCommand(viewSaveCmd)
+	viewCmd.AddCommand(viewListCmd)
+	viewCmd.AddCommand(viewDeleteCmd)
+	rootCmd.AddCommand(viewCmd)
+}
+
+func runViewRun(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	view, err := storage.LoadView(fogitDir, args[0])
+	if err != nil {
+		return err
+	}
+	if viewRunFormat != "" {
+		view.Format = viewRunFormat
+	}
+
+	return runListView(cmd, view)
+}
+
+func runViewSave(cmd *cobra.Command, args []string) error {
+	cmdCtx, err := GetCommandContext()
+	if err != nil {
+		return err
+	}
+
+	view := viewSaved
+	view.Name = args[0]
+
+	// Validate against the config now, so a broken view is never shared
+	if _, err := parseListPresentation(&view, cmdCtx.Config); err != nil {
+		return err
+	}
+	if err := view.ListFilter().ValidateWith(cmdCtx.Config.Workflow.StateMachine()); err != nil {
+		return err
+	}
+	if _, err := parseFilterExpression(view.Filter, cmdCtx.Config); err != nil {
+		return err
+	}
+
+	path, err := storage.SaveView(cmdCtx.FogitDir, &view, viewForce)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Saved view: %s\n", view.Name)
+	fmt.Printf("  Saved to: %s\n", path)
+	fmt.Printf("\nRun it with: fogit view run %s\n", view.Name)
+	return nil
+}
+
+func runViewList(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	views, err := storage.ListViews(fogitDir)
+	if err != nil {
+		return err
+	}
+	if views == nil {
+		views = []*fogit.SavedView{}
+	}
+
+	textFn := func(w io.Writer) error {
+		if len(views) == 0 {
+			fmt.Fprintln(w, "No views saved")
+			fmt.Fprintln(w, "Save one with: fogit view save <name> [list flags]")
+			return nil
+		}
+		for _, view := range views {
+			desc := view.Description
+			if desc == "" {
+				desc = "No description"
+			}
+			fmt.Fprintf(w, "%-20s %s\n", view.Name, desc)
+			fmt.Fprintf(w, "%-20s %s\n", "", strings.TrimSpace("fogit list "+strings.Join(view.ListArgs(), " ")))
+		}
+		return nil
+	}
+
+	return printer.OutputFormatted(os.Stdout, viewListFormat, views, textFn)
+}
+
+func runViewDelete(cmd *cobra.Command, args []string) error {
+	fogitDir, err := getFogitDir()
+	if err != nil {
+		return fmt.Errorf("failed to get .fogit directory: %w", err)
+	}
+
+	if err := storage.DeleteView(fogitDir, args[0]); err != nil {
+		return err
+	}
+
+	fmt.Printf("Deleted view: %s\n", args[0])
+	return nil
+}
@@ -30,17 +30,7 @@ func GetSnippet(text, query string, maxLen int) string {
 		return text[:maxLen] + "..."
 	}
 
-	// Calculate window around match
-	start := idx - maxLen/4
-	if start < 0 {
-		start = 0
-	}
-
-	end := start + maxLen
-	if end > len(text) {
-		end = len(text)
-	}
-
+	start, end := SnippetBounds(text, idx, maxLen)
 	snippet := text[start:end]
 
 	if start > 0 {
@@ -53,6 +43,26 @@ func GetSnippet(text, query string, maxLen int) string {
 	return snippet
 }
 
+// SnippetBounds returns the window of at most maxLen bytes of text that
+// GetSnippet shows around a match at byte offset idx
+func SnippetBounds(text string, idx, maxLen int) (start, end int) {
+	if maxLen <= 0 {
+		maxLen = 100
+	}
+
+	// Calculate window around match
+	start = idx - maxLen/4
+	if start < 0 {
+		start = 0
+	}
+
+	end = start + maxLen
+	if end > len(text) {
+		end = len(text)
+	}
+	return start, end
+}
+
 // SplitKeyValue splits a string on the first occurrence of separator
 func SplitKeyValue(s, sep string) (key, value string) {
 	idx := strings.Index(s, sep)
@@ -171,3 +171,29 @@ func TestGetSnippet(t *testing.T) {
 		})
 	}
 }
+
+func TestSnippetBounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		idx       int
+		maxLen    int
+		wantStart int
+		wantEnd   int
+	}{
+		{"match at start", "Hello World", 0, 5, 0, 5},
+		{"window before match", "0123456789abcdefghij", 12, 8, 10, 18},
+		{"window clipped at end", "0123456789", 8, 8, 6, 10},
+		{"default length", "short", 0, 0, 0, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := SnippetBounds(tt.text, tt.idx, tt.maxLen)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("SnippetBounds(%q, %d, %d) = %d, %d; want %d, %d",
+					tt.text, tt.idx, tt.maxLen, start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -99,6 +99,14 @@ func mergeWithDefaults(config *fogit.Config) {
 		config.CommitTemplate = defaults.CommitTemplate
 	}
 
+	// Commit trailer defaults
+	if config.CommitTrailers.FeatureKey == "" {
+		config.CommitTrailers.FeatureKey = defaults.CommitTrailers.FeatureKey
+	}
+	if config.CommitTrailers.VersionKey == "" {
+		config.CommitTrailers.VersionKey = defaults.CommitTrailers.VersionKey
+	}
+
 	// Relationship defaults - types and categories must be merged together
 	// since default types reference default categories.
 	// Use nil check (not len==0) to respect explicit empty configs
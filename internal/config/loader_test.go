@@ -418,6 +418,47 @@ func TestLoad_EmptyConfig(t *testing.T) {
 	}
 }
 
+func TestLoad_CommitTrailersEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want bool
+	}{
+		{"default", "", true},
+		{"disabled", "commit_trailers:\n  enabled: false\n", false},
+		{"enabled", "commit_trailers:\n  enabled: true\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(tempDir, "config.yml"), []byte(tt.yaml), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := Load(tempDir)
+			if err != nil {
+				t.Fatalf("Failed to load config: %v", err)
+			}
+			if got := cfg.CommitTrailers.IsEnabled(); got != tt.want {
+				t.Errorf("CommitTrailers.IsEnabled() = %v, want %v", got, tt.want)
+			}
+
+			// Saving keeps the same key
+			if err := Save(tempDir, cfg); err != nil {
+				t.Fatal(err)
+			}
+			data, err := os.ReadFile(filepath.Join(tempDir, "config.yml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(data), "disabled") {
+				t.Errorf("saved config uses the disabled key:\n%s", data)
+			}
+		})
+	}
+}
+
 func TestSave_CreatesFile(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "fogit-test-config-*")
 	if err != nil {
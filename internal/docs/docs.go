@@ -0,0 +1,406 @@
+// Package docs renders feature documentation as a static Markdown or HTML site
+package docs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// Output formats
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+)
+
+// ManifestFile lists the files a build wrote, relative to the output
+// directory. Clean only removes files listed in it.
+const ManifestFile = ".fogit-docs"
+
+// ungrouped is the group name for features without a value for the group-by field
+const ungrouped = "(none)"
+
+// Options configures a documentation build
+type Options struct {
+	OutDir  string
+	Format  string // markdown or html
+	GroupBy string // Field for index grouping: state, tag, or a metadata key such as category
+}
+
+// Result summarizes a documentation build
+type Result struct {
+	Files        []string // Written files, relative to OutDir
+	FeaturePages int
+	GroupPages   int
+	BrokenLinks  int // Relationships whose target is not in the feature set
+}
+
+// site is the format-independent model of the generated pages
+type site struct {
+	Title    string
+	GroupBy  string
+	Features []*featurePage
+	Groups   []*groupPage
+}
+
+type featurePage struct {
+	ID          string
+	Name        string
+	Slug        string
+	State       string
+	Description string
+	Tags        []string
+	Metadata    []field
+	Versions    []versionRow
+	Files       []string
+	Outgoing    []relLink
+	Incoming    []relLink
+	Groups      []*groupPage
+}
+
+type groupPage struct {
+	Name     string
+	Slug     string
+	Features []*featurePage
+}
+
+type field struct {
+	Key   string
+	Value string
+}
+
+type versionRow struct {
+	Version  string
+	State    string
+	Created  string
+	Modified string
+	Closed   string
+	Branch   string
+	Authors  string
+	Notes    string
+}
+
+type relLink struct {
+	Type        string
+	Name        string
+	Slug        string // Empty when the other feature is not part of the site
+	Description string
+}
+
+// renderer turns the site model into files of one output format.
+// Paths are slash-separated and relative to the output directory.
+type renderer interface {
+	index(s *site) ([]byte, error)
+	feature(s *site, f *featurePage) ([]byte, error)
+	group(s *site, g *groupPage) ([]byte, error)
+	indexPath() string
+	featurePath(f *featurePage) string
+	groupPath(g *groupPage) string
+	assets() map[string][]byte
+}
+
+// Build renders one page per feature plus index and group pages into opts.OutDir.
+// Output only depends on the features, so unchanged input produces identical files.
+func Build(featuresList []*fogit.Feature, opts Options) (*Result, error) {
+	var render renderer
+	switch opts.Format {
+	case FormatMarkdown, "md", "":
+		render = markdownRenderer{}
+	case FormatHTML:
+		render = htmlRenderer{}
+	default:
+		return nil, fmt.Errorf("invalid format: must be one of markdown, html")
+	}
+	if opts.OutDir == "" {
+		return nil, fmt.Errorf("output directory is required")
+	}
+
+	s, broken := buildSite(featuresList, opts.GroupBy)
+
+	files := map[string][]byte{}
+	index, err := render.index(s)
+	if err != nil {
+		return nil, err
+	}
+	files[render.indexPath()] = index
+	for _, f := range s.Features {
+		data, err := render.feature(s, f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", f.Name, err)
+		}
+		files[render.featurePath(f)] = data
+	}
+	for _, g := range s.Groups {
+		data, err := render.group(s, g)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render group %s: %w", g.Name, err)
+		}
+		files[render.groupPath(g)] = data
+	}
+	for path, data := range render.assets() {
+		files[path] = data
+	}
+
+	result := &Result{
+		FeaturePages: len(s.Features),
+		GroupPages:   len(s.Groups),
+		BrokenLinks:  broken,
+	}
+	for path := range files {
+		result.Files = append(result.Files, path)
+	}
+	sort.Strings(result.Files)
+
+	for _, path := range result.Files {
+		full := filepath.Join(opts.OutDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(full, files[path], 0644); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+
+	manifest := strings.Join(result.Files, "\n") + "\n"
+	if err := os.WriteFile(filepath.Join(opts.OutDir, ManifestFile), []byte(manifest), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write %s: %w", ManifestFile, err)
+	}
+	return result, nil
+}
+
+// Clean removes the files of an earlier build from dir, along with the
+// directories they leave empty. Other files are kept. A directory without a
+// build manifest is refused unless it is empty or missing, so a mistyped
+// output directory is never wiped.
+func Clean(dir string) error {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if os.IsNotExist(err) {
+		entries, readErr := os.ReadDir(dir)
+		if os.IsNotExist(readErr) || (readErr == nil && len(entries) == 0) {
+			return nil
+		}
+		return fmt.Errorf("refusing to clean %s: it has no %s from an earlier 'fogit docs build'", dir, ManifestFile)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", ManifestFile, err)
+	}
+
+	dirs := make(map[string]bool)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+		path := filepath.Clean(filepath.FromSlash(line))
+		if !filepath.IsLocal(path) {
+			return fmt.Errorf("refusing to clean %s: %s lists %s outside of it", dir, ManifestFile, line)
+		}
+		if err := os.Remove(filepath.Join(dir, path)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %w", line, err)
+		}
+		for d := filepath.Dir(path); d != "."; d = filepath.Dir(d) {
+			dirs[d] = true
+		}
+	}
+	if err := os.Remove(filepath.Join(dir, ManifestFile)); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", ManifestFile, err)
+	}
+
+	// Remove directories left empty, deepest first; non-empty ones stay
+	sorted := make([]string, 0, len(dirs))
+	for d := range dirs {
+		sorted = append(sorted, d)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+	for _, d := range sorted {
+		_ = os.Remove(filepath.Join(dir, d))
+	}
+	return nil
+}
+
+// buildSite converts features to pages, resolving relationships in both directions.
+// Returns the site and the number of relationships to features outside the set.
+func buildSite(featuresList []*fogit.Feature, groupBy string) (*site, int) {
+	sorted := append([]*fogit.Feature(nil), featuresList...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Name != sorted[j].Name {
+			return sorted[i].Name < sorted[j].Name
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+
+	s := &site{Title: "Features", GroupBy: groupBy}
+	byID := make(map[string]*featurePage, len(sorted))
+	usedSlugs := make(map[string]bool)
+	for _, f := range sorted {
+		page := newFeaturePage(f, uniqueSlug(f.Name, f.ID, usedSlugs))
+		byID[f.ID] = page
+		s.Features = append(s.Features, page)
+	}
+
+	broken := 0
+	for _, f := range sorted {
+		page := byID[f.ID]
+		for _, rel := range f.Relationships {
+			target, ok := byID[rel.TargetID]
+			if !ok {
+				broken++
+				page.Outgoing = append(page.Outgoing, relLink{Type: string(rel.Type), Name: common.Coalesce(rel.TargetName, rel.TargetID), Description: rel.Description})
+				continue
+			}
+			page.Outgoing = append(page.Outgoing, relLink{Type: string(rel.Type), Name: target.Name, Slug: target.Slug, Description: rel.Description})
+			target.Incoming = append(target.Incoming, relLink{Type: string(rel.Type), Name: page.Name, Slug: page.Slug, Description: rel.Description})
+		}
+	}
+
+	if groupBy != "" {
+		groups := make(map[string]*groupPage)
+		groupSlugs := make(map[string]bool)
+		var names []string
+		for i, f := range sorted {
+			for _, name := range groupValues(f, groupBy) {
+				g, ok := groups[name]
+				if !ok {
+					g = &groupPage{Name: name}
+					groups[name] = g
+					names = append(names, name)
+				}
+				g.Features = append(g.Features, s.Features[i])
+			}
+		}
+		sort.Slice(names, func(i, j int) bool {
+			// Ungrouped features go last
+			if (names[i] == ungrouped) != (names[j] == ungrouped) {
+				return names[j] == ungrouped
+			}
+			return names[i] < names[j]
+		})
+		for _, name := range names {
+			g := groups[name]
+			g.Slug = uniqueSlug(name, "", groupSlugs)
+			s.Groups = append(s.Groups, g)
+			for _, f := range g.Features {
+				f.Groups = append(f.Groups, g)
+			}
+		}
+	}
+
+	return s, broken
+}
+
+func newFeaturePage(f *fogit.Feature, slug string) *featurePage {
+	page := &featurePage{
+		ID:          f.ID,
+		Name:        f.Name,
+		Slug:        slug,
+		State:       string(f.DeriveState()),
+		Description: strings.TrimSpace(f.Description),
+		Tags:        f.Tags,
+		Files:       f.Files,
+	}
+
+	keys := make([]string, 0, len(f.Metadata))
+	for k := range f.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		page.Metadata = append(page.Metadata, field{Key: k, Value: formatValue(f.Metadata[k])})
+	}
+
+	versionKeys := f.GetSortedVersionKeys()
+	for i := len(versionKeys) - 1; i >= 0; i-- { // Newest first
+		key := versionKeys[i]
+		v := f.Versions[key]
+		row := versionRow{
+			Version:  key,
+			State:    versionState(v),
+			Created:  formatTime(v.CreatedAt),
+			Modified: formatTime(v.ModifiedAt),
+			Branch:   v.Branch,
+			Authors:  strings.Join(v.Authors, ", "),
+			Notes:    v.Notes,
+		}
+		if v.ClosedAt != nil {
+			row.Closed = formatTime(*v.ClosedAt)
+		}
+		page.Versions = append(page.Versions, row)
+	}
+	return page
+}
+
+// versionState derives the state of a single version the same way Feature.DeriveState does
+func versionState(v *fogit.FeatureVersion) string {
+	single := &fogit.Feature{Versions: map[string]*fogit.FeatureVersion{"1": v}}
+	return string(single.DeriveState())
+}
+
+// groupValues returns the groups a feature belongs to for the group-by field
+func groupValues(f *fogit.Feature, groupBy string) []string {
+	switch groupBy {
+	case "state":
+		return []string{string(f.DeriveState())}
+	case "tag", "tags":
+		if len(f.Tags) == 0 {
+			return []string{ungrouped}
+		}
+		tags := append([]string(nil), f.Tags...)
+		sort.Strings(tags)
+		return tags
+	case "priority":
+		return []string{string(f.GetPriority())}
+	default:
+		value := formatValue(f.Metadata[groupBy])
+		if value == "" {
+			return []string{ungrouped}
+		}
+		return []string{value}
+	}
+}
+
+// uniqueSlug returns a file-name slug for name, disambiguated by id (or a counter) on collision
+func uniqueSlug(name, id string, used map[string]bool) string {
+	slug := storage.Slugify(name, storage.SlugifyOptions{MaxLength: 80, NormalizeUnicode: true, EmptyFallback: "untitled"})
+	if used[slug] {
+		if len(id) >= 8 {
+			slug += "-" + id[:8]
+		}
+		for base, n := slug, 2; used[slug]; n++ {
+			slug = fmt.Sprintf("%s-%d", base, n)
+		}
+	}
+	used[slug] = true
+	return slug
+}
+
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = fmt.Sprint(item)
+		}
+		return strings.Join(parts, ", ")
+	case []string:
+		return strings.Join(v, ", ")
+	case time.Time:
+		return v.UTC().Format("2006-01-02")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04 UTC")
+}
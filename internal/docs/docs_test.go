@@ -0,0 +1,194 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func testFeatures(t *testing.T) []*fogit.Feature {
+	t.Helper()
+	auth := fogit.NewFeature("User Authentication")
+	auth.Description = "Login | logout"
+	auth.Metadata["category"] = "security"
+	auth.Files = []string{"auth/login.go"}
+
+	session := fogit.NewFeature("Session Store")
+	session.Metadata["category"] = "security"
+
+	search := fogit.NewFeature("Search")
+
+	if err := auth.AddRelationship(fogit.NewRelationship("depends-on", session.ID, session.Name)); err != nil {
+		t.Fatalf("AddRelationship() error = %v", err)
+	}
+	if err := auth.AddRelationship(fogit.NewRelationship("related-to", "missing-id", "Gone")); err != nil {
+		t.Fatalf("AddRelationship() error = %v", err)
+	}
+	return []*fogit.Feature{search, session, auth}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	return string(data)
+}
+
+func TestBuild_Markdown(t *testing.T) {
+	out := t.TempDir()
+	result, err := Build(testFeatures(t), Options{OutDir: out, GroupBy: "category"})
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	wantFiles := []string{
+		"features/search.md",
+		"features/session-store.md",
+		"features/user-authentication.md",
+		"groups/none.md",
+		"groups/security.md",
+		"index.md",
+	}
+	if strings.Join(result.Files, ",") != strings.Join(wantFiles, ",") {
+		t.Errorf("Files = %v, want %v", result.Files, wantFiles)
+	}
+	if result.FeaturePages != 3 || result.GroupPages != 2 || result.BrokenLinks != 1 {
+		t.Errorf("result = %+v", result)
+	}
+
+	index := readFile(t, filepath.Join(out, "index.md"))
+	if !strings.Contains(index, "### [security](groups/security.md)") {
+		t.Errorf("index missing security group:\n%s", index)
+	}
+	if strings.Index(index, "security") > strings.Index(index, "(none)") {
+		t.Errorf("ungrouped features should be listed last:\n%s", index)
+	}
+
+	auth := readFile(t, filepath.Join(out, "features", "user-authentication.md"))
+	for _, want := range []string{
+		"| category | security |",
+		"- **depends-on** [Session Store](session-store.md)",
+		"- **related-to** Gone",
+		"`auth/login.go`",
+		"[security](../groups/security.md)",
+	} {
+		if !strings.Contains(auth, want) {
+			t.Errorf("feature page missing %q:\n%s", want, auth)
+		}
+	}
+
+	session := readFile(t, filepath.Join(out, "features", "session-store.md"))
+	if !strings.Contains(session, "## Incoming relationships") || !strings.Contains(session, "[User Authentication](user-authentication.md)") {
+		t.Errorf("session page missing incoming link:\n%s", session)
+	}
+}
+
+func TestBuild_HTML(t *testing.T) {
+	out := t.TempDir()
+	features := testFeatures(t)
+	features[0].Name = "<script>alert(1)</script>"
+	if _, err := Build(features, Options{OutDir: out, Format: FormatHTML, GroupBy: "state"}); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(out, "style.css")); err != nil {
+		t.Errorf("style.css not written: %v", err)
+	}
+	index := readFile(t, filepath.Join(out, "index.html"))
+	if strings.Contains(index, "<script>") {
+		t.Errorf("feature name not escaped:\n%s", index)
+	}
+	if !strings.Contains(index, `href="groups/open.html"`) {
+		t.Errorf("index missing state group link:\n%s", index)
+	}
+	auth := readFile(t, filepath.Join(out, "features", "user-authentication.html"))
+	if !strings.Contains(auth, `<a href="session-store.html">Session Store</a>`) {
+		t.Errorf("feature page missing relationship link:\n%s", auth)
+	}
+}
+
+func TestBuild_Deterministic(t *testing.T) {
+	features := testFeatures(t)
+	for _, format := range []string{FormatMarkdown, FormatHTML} {
+		first, second := t.TempDir(), t.TempDir()
+		result, err := Build(features, Options{OutDir: first, Format: format, GroupBy: "category"})
+		if err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		// Input order must not matter
+		reversed := []*fogit.Feature{features[2], features[1], features[0]}
+		if _, err := Build(reversed, Options{OutDir: second, Format: format, GroupBy: "category"}); err != nil {
+			t.Fatalf("Build() error = %v", err)
+		}
+		for _, path := range result.Files {
+			a := readFile(t, filepath.Join(first, path))
+			b := readFile(t, filepath.Join(second, path))
+			if a != b {
+				t.Errorf("%s: %s differs between builds", format, path)
+			}
+		}
+	}
+}
+
+func TestClean(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Build(testFeatures(t), Options{OutDir: dir, Format: FormatHTML, GroupBy: "category"}); err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	own := filepath.Join(dir, "features", "notes.txt")
+	if err := os.WriteFile(own, []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Clean(dir); err != nil {
+		t.Fatalf("Clean() error = %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the features directory with the foreign file is left
+	if len(entries) != 1 || entries[0].Name() != "features" {
+		t.Errorf("left %v, want only features/", entries)
+	}
+	if _, err := os.Stat(own); err != nil {
+		t.Errorf("Clean() removed a file it didn't write: %v", err)
+	}
+
+	// Without a manifest only missing or empty directories are accepted
+	if err := Clean(dir); err == nil {
+		t.Error("Clean() without a manifest should fail")
+	}
+	if err := Clean(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("Clean() of a missing directory error = %v", err)
+	}
+
+	// Manifests can't reach outside the output directory
+	if err := os.WriteFile(filepath.Join(dir, ManifestFile), []byte("../outside\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Clean(dir); err == nil {
+		t.Error("Clean() should refuse manifest paths outside the directory")
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	used := map[string]bool{}
+	first := uniqueSlug("Login", "abcdef123456", used)
+	second := uniqueSlug("Login", "0123456789ab", used)
+	third := uniqueSlug("", "", used)
+	if first != "login" || second != "login-01234567" || third != "untitled" {
+		t.Errorf("slugs = %q, %q, %q", first, second, third)
+	}
+}
+
+func TestBuild_InvalidFormat(t *testing.T) {
+	if _, err := Build(nil, Options{OutDir: t.TempDir(), Format: "pdf"}); err == nil {
+		t.Error("Build() with invalid format should fail")
+	}
+}
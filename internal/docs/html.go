@@ -0,0 +1,178 @@
+package docs
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+)
+
+//go:embed assets/style.css
+var styleCSS []byte
+
+// htmlRenderer writes standalone HTML pages sharing one stylesheet
+type htmlRenderer struct{}
+
+func (htmlRenderer) indexPath() string                 { return "index.html" }
+func (htmlRenderer) featurePath(f *featurePage) string { return "features/" + f.Slug + ".html" }
+func (htmlRenderer) groupPath(g *groupPage) string     { return "groups/" + g.Slug + ".html" }
+func (htmlRenderer) assets() map[string][]byte         { return map[string][]byte{"style.css": styleCSS} }
+
+// htmlPage is the data passed to the page templates
+type htmlPage struct {
+	Site    *site
+	Root    string // Relative path to the site root, "" or "../"
+	Feature *featurePage
+	Group   *groupPage
+}
+
+func (htmlRenderer) index(s *site) ([]byte, error) {
+	return renderHTML("index", htmlPage{Site: s})
+}
+
+func (htmlRenderer) feature(s *site, f *featurePage) ([]byte, error) {
+	return renderHTML("feature", htmlPage{Site: s, Root: "../", Feature: f})
+}
+
+func (htmlRenderer) group(s *site, g *groupPage) ([]byte, error) {
+	return renderHTML("group", htmlPage{Site: s, Root: "../", Group: g})
+}
+
+func renderHTML(name string, page htmlPage) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := htmlTemplates.ExecuteTemplate(&buf, name, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+var htmlFuncs = template.FuncMap{
+	"pages": func(pages []*featurePage, prefix string) map[string]interface{} {
+		return map[string]interface{}{"Pages": pages, "Prefix": prefix}
+	},
+	"links": func(title string, links []relLink) map[string]interface{} {
+		return map[string]interface{}{"Title": title, "Links": links}
+	},
+}
+
+var htmlTemplates = template.Must(template.New("docs").Funcs(htmlFuncs).Parse(`
+{{- define "head" -}}
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.}}</title>
+{{- end}}
+
+{{- define "features" -}}
+<table>
+<thead><tr><th>Feature</th><th>State</th><th>Tags</th></tr></thead>
+<tbody>
+{{- range .Pages}}
+<tr><td><a href="{{$.Prefix}}{{.Slug}}.html">{{.Name}}</a></td><td><span class="state state-{{.State}}">{{.State}}</span></td><td>{{range $i, $t := .Tags}}{{if $i}}, {{end}}{{$t}}{{end}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- define "relationships" -}}
+{{- if .Links}}
+<h2>{{.Title}}</h2>
+<ul class="relationships">
+{{- range .Links}}
+<li><span class="rel-type">{{.Type}}</span> {{if .Slug}}<a href="{{.Slug}}.html">{{.Name}}</a>{{else}}<span class="missing">{{.Name}}</span>{{end}}{{if .Description}} — {{.Description}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- end}}
+
+{{- define "index" -}}
+{{template "head" .Site.Title}}
+<link rel="stylesheet" href="style.css">
+</head>
+<body>
+<main>
+<h1>{{.Site.Title}}</h1>
+<p>{{len .Site.Features}} features.</p>
+{{- if .Site.Groups}}
+<h2>By {{.Site.GroupBy}}</h2>
+{{- range .Site.Groups}}
+<h3><a href="groups/{{.Slug}}.html">{{.Name}}</a></h3>
+<ul>
+{{- range .Features}}
+<li><a href="features/{{.Slug}}.html">{{.Name}}</a> <span class="state state-{{.State}}">{{.State}}</span></li>
+{{- end}}
+</ul>
+{{- end}}
+{{- else}}
+{{template "features" (pages .Site.Features "features/")}}
+{{- end}}
+</main>
+</body>
+</html>
+{{end}}
+
+{{- define "group" -}}
+{{template "head" (printf "%s: %s" .Site.GroupBy .Group.Name)}}
+<link rel="stylesheet" href="{{.Root}}style.css">
+</head>
+<body>
+<main>
+<nav><a href="{{.Root}}index.html">All features</a></nav>
+<h1>{{.Site.GroupBy}}: {{.Group.Name}}</h1>
+{{template "features" (pages .Group.Features "../features/")}}
+</main>
+</body>
+</html>
+{{end}}
+
+{{- define "feature" -}}
+{{- $root := .Root -}}
+{{- with .Feature -}}
+{{template "head" .Name}}
+<link rel="stylesheet" href="{{$root}}style.css">
+</head>
+<body>
+<main>
+<nav><a href="{{$root}}index.html">All features</a>{{range .Groups}} · <a href="{{$root}}groups/{{.Slug}}.html">{{.Name}}</a>{{end}}</nav>
+<h1>{{.Name}} <span class="state state-{{.State}}">{{.State}}</span></h1>
+<table class="fields">
+<tr><th>ID</th><td><code>{{.ID}}</code></td></tr>
+{{- if .Tags}}
+<tr><th>Tags</th><td>{{range $i, $t := .Tags}}{{if $i}}, {{end}}{{$t}}{{end}}</td></tr>
+{{- end}}
+{{- range .Metadata}}
+<tr><th>{{.Key}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- if .Description}}
+<h2>Description</h2>
+<div class="description">{{.Description}}</div>
+{{- end}}
+{{- if .Versions}}
+<h2>Versions</h2>
+<table>
+<thead><tr><th>Version</th><th>State</th><th>Created</th><th>Closed</th><th>Branch</th><th>Authors</th><th>Notes</th></tr></thead>
+<tbody>
+{{- range .Versions}}
+<tr><td>{{.Version}}</td><td>{{.State}}</td><td>{{.Created}}</td><td>{{.Closed}}</td><td>{{.Branch}}</td><td>{{.Authors}}</td><td>{{.Notes}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+{{- if .Files}}
+<h2>Files</h2>
+<ul>
+{{- range .Files}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+{{template "relationships" (links "Outgoing relationships" .Outgoing)}}
+{{template "relationships" (links "Incoming relationships" .Incoming)}}
+</main>
+</body>
+</html>
+{{end}}
+{{- end}}
+`))
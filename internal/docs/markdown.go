@@ -0,0 +1,125 @@
+package docs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// markdownRenderer writes plain Markdown with relative links between pages
+type markdownRenderer struct{}
+
+func (markdownRenderer) indexPath() string                 { return "index.md" }
+func (markdownRenderer) featurePath(f *featurePage) string { return "features/" + f.Slug + ".md" }
+func (markdownRenderer) groupPath(g *groupPage) string     { return "groups/" + g.Slug + ".md" }
+func (markdownRenderer) assets() map[string][]byte         { return nil }
+
+func (markdownRenderer) index(s *site) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	fmt.Fprintf(&b, "%d features.\n", len(s.Features))
+
+	if len(s.Groups) > 0 {
+		fmt.Fprintf(&b, "\n## By %s\n", s.GroupBy)
+		for _, g := range s.Groups {
+			fmt.Fprintf(&b, "\n### [%s](groups/%s.md)\n\n", mdText(g.Name), g.Slug)
+			for _, f := range g.Features {
+				fmt.Fprintf(&b, "- [%s](features/%s.md) (%s)\n", mdText(f.Name), f.Slug, f.State)
+			}
+		}
+		return []byte(b.String()), nil
+	}
+
+	b.WriteString("\n")
+	writeMarkdownFeatureTable(&b, s.Features, "features/")
+	return []byte(b.String()), nil
+}
+
+func (markdownRenderer) group(s *site, g *groupPage) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s: %s\n\n", mdText(s.GroupBy), mdText(g.Name))
+	fmt.Fprintf(&b, "[All features](../index.md)\n\n")
+	writeMarkdownFeatureTable(&b, g.Features, "../features/")
+	return []byte(b.String()), nil
+}
+
+func writeMarkdownFeatureTable(b *strings.Builder, pages []*featurePage, prefix string) {
+	b.WriteString("| Feature | State | Tags |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, f := range pages {
+		fmt.Fprintf(b, "| [%s](%s%s.md) | %s | %s |\n", mdText(f.Name), prefix, f.Slug, f.State, mdCell(strings.Join(f.Tags, ", ")))
+	}
+}
+
+func (markdownRenderer) feature(s *site, f *featurePage) ([]byte, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", mdText(f.Name))
+	fmt.Fprintf(&b, "[All features](../index.md)")
+	for _, g := range f.Groups {
+		fmt.Fprintf(&b, " · [%s](../groups/%s.md)", mdText(g.Name), g.Slug)
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("| Field | Value |\n|---|---|\n")
+	fmt.Fprintf(&b, "| ID | `%s` |\n", f.ID)
+	fmt.Fprintf(&b, "| State | %s |\n", f.State)
+	if len(f.Tags) > 0 {
+		fmt.Fprintf(&b, "| Tags | %s |\n", mdCell(strings.Join(f.Tags, ", ")))
+	}
+	for _, m := range f.Metadata {
+		fmt.Fprintf(&b, "| %s | %s |\n", mdCell(m.Key), mdCell(m.Value))
+	}
+
+	if f.Description != "" {
+		fmt.Fprintf(&b, "\n## Description\n\n%s\n", f.Description)
+	}
+
+	if len(f.Versions) > 0 {
+		b.WriteString("\n## Versions\n\n")
+		b.WriteString("| Version | State | Created | Closed | Branch | Authors | Notes |\n")
+		b.WriteString("|---|---|---|---|---|---|---|\n")
+		for _, v := range f.Versions {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s | %s |\n",
+				mdCell(v.Version), v.State, v.Created, v.Closed, mdCell(v.Branch), mdCell(v.Authors), mdCell(v.Notes))
+		}
+	}
+
+	if len(f.Files) > 0 {
+		b.WriteString("\n## Files\n\n")
+		for _, file := range f.Files {
+			fmt.Fprintf(&b, "- `%s`\n", file)
+		}
+	}
+
+	writeMarkdownRelationships(&b, "Outgoing relationships", f.Outgoing)
+	writeMarkdownRelationships(&b, "Incoming relationships", f.Incoming)
+
+	return []byte(b.String()), nil
+}
+
+func writeMarkdownRelationships(b *strings.Builder, title string, links []relLink) {
+	if len(links) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n## %s\n\n", title)
+	for _, l := range links {
+		target := mdText(l.Name)
+		if l.Slug != "" {
+			target = fmt.Sprintf("[%s](%s.md)", target, l.Slug)
+		}
+		fmt.Fprintf(b, "- **%s** %s", l.Type, target)
+		if l.Description != "" {
+			fmt.Fprintf(b, " — %s", mdText(l.Description))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// mdText escapes characters that would break link text or emphasis
+func mdText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`, "*", `\*`, "_", `\_`, "\n", " ").Replace(s)
+}
+
+// mdCell escapes text for a table cell
+func mdCell(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>").Replace(s)
+}
@@ -1,10 +1,13 @@
 package exchange
 
 import (
+	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/eg3r/fogit/internal/storage"
 	"github.com/eg3r/fogit/pkg/fogit"
 )
 
@@ -428,3 +431,38 @@ func findSubstring(s, substr string) bool {
 	}
 	return false
 }
+
+func TestImport_MetadataSchema(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewFileRepository(t.TempDir())
+	schema := &fogit.MetadataSchema{
+		Fields: map[string]fogit.MetadataField{
+			"owner": {Type: fogit.MetadataTypeString, Required: true},
+			"tier":  {Type: fogit.MetadataTypeEnum, Values: []string{"1", "2"}, Default: "2"},
+		},
+	}
+
+	data := &ExportData{
+		FogitVersion: "1.0",
+		Features: []*ExportFeature{
+			{ID: "a1b2c3d4-0000-0000-0000-000000000001", Name: "Owned", Metadata: map[string]interface{}{"owner": "alice"}},
+			{ID: "a1b2c3d4-0000-0000-0000-000000000002", Name: "Unowned"},
+		},
+	}
+
+	result, err := Import(ctx, repo, data, ImportOptions{Schema: schema})
+	if err != nil {
+		t.Fatalf("Import() error = %v", err)
+	}
+	if result.Created != 1 || len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "owner is required") {
+		t.Fatalf("Import() created %d, errors %v; want 1 created and owner error", result.Created, result.Errors)
+	}
+
+	imported, err := repo.Get(ctx, data.Features[0].ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if imported.Metadata["tier"] != "2" {
+		t.Errorf("tier = %v, want schema default 2", imported.Metadata["tier"])
+	}
+}
@@ -17,6 +17,8 @@ type ImportOptions struct {
 	Merge     bool // Skip existing features, import only new ones
 	Overwrite bool // Replace existing features with imported data
 	DryRun    bool // Preview changes without applying them
+
+	Schema *fogit.MetadataSchema // Imported metadata must match (nil = no schema)
 }
 
 // ImportResult tracks the results of an import operation
@@ -100,6 +102,12 @@ func Import(ctx context.Context, repo fogit.Repository, data *ExportData, opts I
 			}
 
 			// Overwrite - update existing feature
+			feature := ConvertFromExportFeature(ef)
+			if err := opts.Schema.Enforce(feature); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("feature '%s': %v", ef.Name, err))
+				continue
+			}
+
 			result.Actions = append(result.Actions, ImportAction{
 				Type:        "UPDATE",
 				FeatureName: ef.Name,
@@ -107,7 +115,6 @@ func Import(ctx context.Context, repo fogit.Repository, data *ExportData, opts I
 			})
 
 			if !opts.DryRun {
-				feature := ConvertFromExportFeature(ef)
 				if err := repo.Update(ctx, feature); err != nil {
 					result.Errors = append(result.Errors,
 						fmt.Sprintf("failed to update '%s': %v", ef.Name, err))
@@ -117,6 +124,12 @@ func Import(ctx context.Context, repo fogit.Repository, data *ExportData, opts I
 			result.Updated++
 		} else {
 			// New feature
+			feature := ConvertFromExportFeature(ef)
+			if err := opts.Schema.Enforce(feature); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("feature '%s': %v", ef.Name, err))
+				continue
+			}
+
 			result.Actions = append(result.Actions, ImportAction{
 				Type:        "CREATE",
 				FeatureName: ef.Name,
@@ -124,7 +137,6 @@ func Import(ctx context.Context, repo fogit.Repository, data *ExportData, opts I
 			})
 
 			if !opts.DryRun {
-				feature := ConvertFromExportFeature(ef)
 				if err := repo.Create(ctx, feature); err != nil {
 					result.Errors = append(result.Errors,
 						fmt.Sprintf("failed to create '%s': %v", ef.Name, err))
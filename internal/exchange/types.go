@@ -35,6 +35,7 @@ type ExportVersion struct {
 	CreatedAt  string   `json:"created_at" yaml:"created_at"`
 	ModifiedAt string   `json:"modified_at,omitempty" yaml:"modified_at,omitempty"`
 	ClosedAt   string   `json:"closed_at,omitempty" yaml:"closed_at,omitempty"`
+	State      string   `json:"state,omitempty" yaml:"state,omitempty"`
 	Branch     string   `json:"branch,omitempty" yaml:"branch,omitempty"`
 	Authors    []string `json:"authors,omitempty" yaml:"authors,omitempty"`
 	Notes      string   `json:"notes,omitempty" yaml:"notes,omitempty"`
@@ -78,6 +79,7 @@ func ConvertToExportFeature(f *fogit.Feature, featureIDs map[string]bool) *Expor
 		for key, v := range f.Versions {
 			ev := &ExportVersion{
 				CreatedAt: v.CreatedAt.Format(time.RFC3339),
+				State:     v.State,
 				Branch:    v.Branch,
 				Authors:   v.Authors,
 				Notes:     v.Notes,
@@ -135,6 +137,7 @@ func ConvertFromExportFeature(ef *ExportFeature) *fogit.Feature {
 		f.Versions = make(map[string]*fogit.FeatureVersion)
 		for key, ev := range ef.Versions {
 			fv := &fogit.FeatureVersion{
+				State:   ev.State,
 				Branch:  ev.Branch,
 				Authors: ev.Authors,
 				Notes:   ev.Notes,
@@ -0,0 +1,267 @@
+package features
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// FeatureBlame reports when each field of a feature last changed
+type FeatureBlame struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Ref    string        `json:"ref"`
+	Fields []*FieldBlame `json:"fields"`
+}
+
+// FieldBlame is the commit that last set a field to its current value.
+// Commit is empty when the value has not been committed yet.
+type FieldBlame struct {
+	Field   string         `json:"field"`
+	Value   string         `json:"value"`
+	Commit  string         `json:"commit,omitempty"`
+	Author  string         `json:"author,omitempty"`
+	Email   string         `json:"email,omitempty"`
+	Date    time.Time      `json:"date,omitempty"`
+	Subject string         `json:"subject,omitempty"`
+	History []*FieldChange `json:"history,omitempty"` // Oldest first, with BlameOptions.Timeline
+}
+
+// FieldChange is a single commit that changed a field
+type FieldChange struct {
+	Commit     string    `json:"commit"`
+	Author     string    `json:"author"`
+	Email      string    `json:"email"`
+	Date       time.Time `json:"date"`
+	Subject    string    `json:"subject"`
+	OldValue   string    `json:"old_value,omitempty"`
+	NewValue   string    `json:"new_value,omitempty"`
+	ChangeType string    `json:"change_type"` // "added", "removed", "modified"
+}
+
+// BlameOptions configures blame
+type BlameOptions struct {
+	Ref      string // Revision to walk back from (default: HEAD)
+	Timeline bool   // Include every change per field, not just the last one
+}
+
+// BuildBlame walks the history of a feature's file back from a ref and reports,
+// for every field, metadata key, tag, linked file and relationship of the given
+// feature, the commit that last set its current value.
+//
+// Each commit is compared with its first parent, following renames by feature ID.
+// For merge commits only values that differ from both parents are attributed to
+// the merge; everything else is credited to the commit on the merged branch.
+func BuildBlame(ctx context.Context, gitRepo *git.Repository, feature *fogit.Feature, opts BlameOptions) (*FeatureBlame, error) {
+	if opts.Ref == "" {
+		opts.Ref = "HEAD"
+	}
+
+	// Blobs are immutable, so parsed revisions are cached; nil marks other features
+	revisions := make(map[string]*fogit.Feature)
+	parseBlob := func(blob string) *fogit.Feature {
+		if f, ok := revisions[blob]; ok {
+			return f
+		}
+		var rev *fogit.Feature
+		if data, err := gitRepo.ReadBlob(blob); err == nil {
+			if f, err := storage.UnmarshalFeature(data); err == nil && f.ID == feature.ID {
+				rev = f
+			}
+		}
+		revisions[blob] = rev
+		return rev
+	}
+
+	// Only feature files are compared; the whole directory is followed because
+	// the feature's file may have had another name in earlier commits
+	changes := make(map[string][]*FieldChange) // Newest first
+	err := gitRepo.WalkHistoryFrom(ctx, opts.Ref, []string{featuresTreePath + "/"}, func(c *git.CommitChanges) error {
+		var before, after *fogit.Feature
+		for _, change := range c.Changes {
+			if !IsFeatureFilePath(change.From) && !IsFeatureFilePath(change.To) {
+				continue
+			}
+			if change.To != "" {
+				if f := parseBlob(change.ToBlob); f != nil {
+					after = f
+				}
+			}
+			if change.From != "" {
+				if f := parseBlob(change.FromBlob); f != nil {
+					before = f
+				}
+			}
+		}
+		if after == nil {
+			return nil // Feature untouched or deleted
+		}
+
+		diffs := DiffFeatureFields(before, after)
+		if len(diffs) == 0 {
+			return nil
+		}
+
+		// Values brought in by a merge were set on the merged branch
+		var merged map[string]string
+		if len(c.Parents) > 1 {
+			merged = featureFieldsAt(gitRepo, c.Parents[1], feature.ID)
+		}
+
+		subject := strings.SplitN(c.Message, "\n", 2)[0]
+		for _, d := range diffs {
+			if merged != nil {
+				v, ok := merged[d.Field]
+				if d.ChangeType == "removed" && !ok || d.ChangeType != "removed" && ok && v == d.NewValue {
+					continue
+				}
+			}
+			changes[d.Field] = append(changes[d.Field], &FieldChange{
+				Commit:     c.Hash,
+				Author:     c.Author,
+				Email:      c.Email,
+				Date:       c.Date,
+				Subject:    subject,
+				OldValue:   d.OldValue,
+				NewValue:   d.NewValue,
+				ChangeType: d.ChangeType,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	blame := &FeatureBlame{ID: feature.ID, Name: feature.Name, Ref: opts.Ref}
+	current := FeatureFields(feature)
+	for _, field := range sortedFieldNames(current) {
+		fb := &FieldBlame{Field: field, Value: current[field]}
+		history := changes[field]
+		for _, ch := range history {
+			if ch.ChangeType != "removed" && ch.NewValue == fb.Value {
+				fb.Commit, fb.Author, fb.Email, fb.Date, fb.Subject = ch.Commit, ch.Author, ch.Email, ch.Date, ch.Subject
+				break
+			}
+		}
+		if opts.Timeline {
+			for i := len(history) - 1; i >= 0; i-- {
+				fb.History = append(fb.History, history[i])
+			}
+		}
+		blame.Fields = append(blame.Fields, fb)
+	}
+	return blame, nil
+}
+
+// featureFieldsAt returns the fields of a feature as of a commit, or nil if it doesn't exist there
+func featureFieldsAt(gitRepo *git.Repository, rev, id string) map[string]string {
+	files, err := ListFeatureFilesAtRef(gitRepo, rev)
+	if err != nil {
+		return nil
+	}
+	for _, file := range files {
+		if file.Feature.ID == id {
+			return FeatureFields(file.Feature)
+		}
+	}
+	return nil
+}
+
+// FeatureFields flattens a feature into named scalar fields:
+// name, description, state, version, metadata.<key>, tag:<tag>, file:<path> and
+// relationship:<type>:<target id>. Tags and files have an empty value.
+func FeatureFields(f *fogit.Feature) map[string]string {
+	fields := map[string]string{
+		"name":    f.Name,
+		"state":   string(f.DeriveState()),
+		"version": f.GetCurrentVersionKey(),
+	}
+	if f.Description != "" {
+		fields["description"] = f.Description
+	}
+	for key, value := range f.Metadata {
+		fields["metadata."+key] = formatFieldValue(value)
+	}
+	for _, tag := range f.Tags {
+		fields["tag:"+tag] = ""
+	}
+	for _, file := range f.Files {
+		fields["file:"+file] = ""
+	}
+	for _, rel := range f.Relationships {
+		value := rel.TargetName
+		if rel.Description != "" {
+			value += " (" + rel.Description + ")"
+		}
+		fields["relationship:"+string(rel.Type)+":"+rel.TargetID] = value
+	}
+	return fields
+}
+
+// DiffFeatureFields compares the flattened fields of two feature snapshots, sorted by field.
+// before may be nil, in which case every field is reported as added.
+func DiffFeatureFields(before, after *fogit.Feature) []FieldDiff {
+	var old map[string]string
+	if before != nil {
+		old = FeatureFields(before)
+	}
+	current := FeatureFields(after)
+
+	var diffs []FieldDiff
+	for _, field := range sortedFieldNames(current) {
+		value := current[field]
+		prev, ok := old[field]
+		switch {
+		case !ok:
+			diffs = append(diffs, FieldDiff{Field: field, NewValue: value, ChangeType: "added"})
+		case prev != value:
+			diffs = append(diffs, FieldDiff{Field: field, OldValue: prev, NewValue: value, ChangeType: "modified"})
+		}
+	}
+	for _, field := range sortedFieldNames(old) {
+		if _, ok := current[field]; !ok {
+			diffs = append(diffs, FieldDiff{Field: field, OldValue: old[field], ChangeType: "removed"})
+		}
+	}
+	sort.SliceStable(diffs, func(i, j int) bool {
+		return diffs[i].Field < diffs[j].Field
+	})
+	return diffs
+}
+
+func sortedFieldNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// formatFieldValue renders a metadata value as a single line
+func formatFieldValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []interface{}, map[string]interface{}:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,151 @@
+package features
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestDiffFeatureFields(t *testing.T) {
+	before := fogit.NewFeature("Login")
+	before.SetPriority(fogit.PriorityLow)
+	before.AddTag("auth")
+	before.AddTag("ui")
+
+	after := fogit.NewFeature("Login")
+	after.Versions = before.Versions
+	after.SetPriority(fogit.PriorityHigh)
+	after.AddTag("auth")
+	after.AddTag("security")
+	after.Description = "OAuth login"
+
+	got := DiffFeatureFields(before, after)
+	want := []FieldDiff{
+		{Field: "description", NewValue: "OAuth login", ChangeType: "added"},
+		{Field: "metadata.priority", OldValue: "low", NewValue: "high", ChangeType: "modified"},
+		{Field: "tag:security", ChangeType: "added"},
+		{Field: "tag:ui", ChangeType: "removed"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("DiffFeatureFields() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("diff[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if diffs := DiffFeatureFields(nil, after); len(diffs) != len(FeatureFields(after)) {
+		t.Errorf("expected every field added without a previous revision, got %+v", diffs)
+	}
+}
+
+func TestBuildBlame(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+
+	writeFeature := func(name, yaml string) {
+		t.Helper()
+		path := filepath.Join(repoPath, ".fogit", "features", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commitAs := func(author, message string) {
+		t.Helper()
+		gitInRepo(t, repoPath, "add", "-A")
+		gitInRepo(t, repoPath, "-c", "user.name="+author, "-c", "user.email="+author+"@example.com", "commit", "-m", message)
+	}
+
+	const header = `id: auth-123
+name: Auth
+versions:
+  "1":
+    created_at: 2025-01-01T00:00:00Z
+    modified_at: 2025-01-01T00:00:00Z
+`
+	writeFeature("auth.yml", header+`metadata:
+  priority: low
+`)
+	commitAs("alice", "Add auth")
+
+	// Priority changes on a branch and is merged back
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/priority")
+	writeFeature("auth.yml", header+`metadata:
+  priority: high
+`)
+	commitAs("bob", "Raise priority")
+
+	gitInRepo(t, repoPath, "checkout", trunk)
+	writeFeature("auth.yml", header+`description: Sign in
+metadata:
+  priority: low
+`)
+	commitAs("carol", "Describe auth")
+	gitInRepo(t, repoPath, "-c", "user.name=dave", "-c", "user.email=dave@example.com",
+		"merge", "--no-ff", "-m", "Merge priority", "feature/priority")
+
+	// Rename the file and tag the feature in the same commit
+	gitInRepo(t, repoPath, "mv", ".fogit/features/auth.yml", ".fogit/features/authentication.yml")
+	writeFeature("authentication.yml", header+`description: Sign in
+tags:
+  - security
+metadata:
+  priority: high
+`)
+	commitAs("erin", "Rename and tag")
+
+	files, err := ListFeatureFilesAtRef(gitRepo, "HEAD")
+	if err != nil || len(files) != 1 {
+		t.Fatalf("ListFeatureFilesAtRef() = %v, %v", files, err)
+	}
+	feature := files[0].Feature
+	feature.Name = "Auth v2" // Uncommitted change
+
+	blame, err := BuildBlame(context.Background(), gitRepo, feature, BlameOptions{Timeline: true})
+	if err != nil {
+		t.Fatalf("BuildBlame() error = %v", err)
+	}
+
+	fields := make(map[string]*FieldBlame)
+	for _, f := range blame.Fields {
+		fields[f.Field] = f
+	}
+
+	tests := []struct {
+		field   string
+		author  string
+		history int
+	}{
+		{"metadata.priority", "bob", 2},
+		{"description", "carol", 1},
+		{"tag:security", "erin", 1},
+		{"version", "alice", 1},
+		{"name", "", 1}, // Uncommitted
+	}
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			f, ok := fields[tt.field]
+			if !ok {
+				t.Fatalf("field %s missing", tt.field)
+			}
+			if f.Author != tt.author {
+				t.Errorf("author = %q, want %q", f.Author, tt.author)
+			}
+			if len(f.History) != tt.history {
+				t.Errorf("history = %d changes, want %d", len(f.History), tt.history)
+			}
+		})
+	}
+
+	if h := fields["metadata.priority"].History; len(h) == 2 && (h[0].ChangeType != "added" || h[1].NewValue != "high") {
+		t.Errorf("priority timeline = %+v, %+v", h[0], h[1])
+	}
+}
@@ -103,26 +103,134 @@ func HandleBranchCreation(featureName string, cfg *fogit.Config, sameBranch, iso
 			}
 		}
 
-		// Create the branch
-		if err := gitRepo.CreateBranch(branchName); err != nil {
-			if err == git.ErrBranchExists {
-				return fmt.Errorf("branch %s already exists. Use --same to create feature on current branch", branchName)
-			}
-			if err == git.ErrEmptyRepository {
-				return fmt.Errorf("Git repository has no commits. Make an initial commit first:\n  git commit --allow-empty -m \"Initial commit\"")
+		if err := createAndCheckoutBranch(gitRepo, branchName); err != nil {
+			return err
+		}
+
+		fmt.Printf("✓ Created and checked out branch: %s\n", branchName)
+		return nil
+	}
+
+	return nil
+}
+
+// HandleStackedBranchCreation creates and checks out a Git branch for the feature on top
+// of the branch of dependency, an unmerged feature it depends on, instead of the base branch.
+// The inverse of the feature's depends-on relationship is committed on the parent branch
+// first, so it is merged with the parent. Returns the new branch and the parent branch.
+func HandleStackedBranchCreation(feature, dependency *fogit.Feature, cfg *fogit.Config) (string, string, error) {
+	if cfg.Workflow.Mode != "branch-per-feature" {
+		return "", "", fmt.Errorf("stacked branches only work in branch-per-feature mode")
+	}
+	if dependency.IsClosed() {
+		return "", "", fmt.Errorf("feature '%s' is closed (merged); create the branch from the base branch instead", dependency.Name)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	gitRoot, err := git.FindGitRoot(cwd)
+	if err != nil {
+		return "", "", fmt.Errorf("not in a git repository: %w", err)
+	}
+	gitRepo, err := git.OpenRepository(gitRoot)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	parentBranch := GetFeatureBranch(dependency)
+	if parentBranch == "" {
+		parentBranch = SanitizeBranchName(dependency.Name)
+	}
+	if !gitRepo.BranchExists(parentBranch) {
+		return "", "", fmt.Errorf("branch %s of feature '%s' not found", parentBranch, dependency.Name)
+	}
+
+	if current, _ := gitRepo.GetCurrentBranch(); current != parentBranch {
+		fmt.Printf("Switching to %s to stack the feature branch on it...\n", parentBranch)
+		if err := gitRepo.CheckoutBranch(parentBranch); err != nil {
+			return "", "", fmt.Errorf("failed to switch to %s: %w", parentBranch, err)
+		}
+	}
+
+	addStackInverse(gitRepo, parentBranch, feature, dependency, cfg)
+
+	branchName := SanitizeBranchName(feature.Name)
+	if err := createAndCheckoutBranch(gitRepo, branchName); err != nil {
+		return "", "", err
+	}
+
+	fmt.Printf("✓ Created and checked out branch: %s (stacked on %s)\n", branchName, parentBranch)
+	return branchName, parentBranch, nil
+}
+
+// addStackInverse commits the inverse of the stacked feature's depends-on relationship
+// to the dependency on its branch, which must be checked out. Failures are only logged,
+// as with inverse relationships created by 'fogit link'.
+func addStackInverse(gitRepo *git.Repository, parentBranch string, feature, dependency *fogit.Feature, cfg *fogit.Config) {
+	if !cfg.Relationships.System.AutoCreateInverse {
+		return
+	}
+	typeConfig, ok := cfg.Relationships.Types["depends-on"]
+	if !ok || typeConfig.Inverse == "" || typeConfig.Bidirectional {
+		return
+	}
+
+	files, err := ListFeatureFilesAtRef(gitRepo, parentBranch)
+	if err != nil {
+		logger.Warn("failed to read features on branch", "error", err, "branch", parentBranch)
+		return
+	}
+	for _, file := range files {
+		if file.Feature.ID != dependency.ID {
+			continue
+		}
+
+		target := file.Feature
+		// Being depended on is not work on the dependency, keep its state
+		modifiedAt := target.GetModifiedAt()
+		inverse := fogit.NewRelationship(fogit.RelationshipType(typeConfig.Inverse), feature.ID, feature.Name)
+		if err := target.AddRelationship(inverse); err != nil {
+			if err != fogit.ErrDuplicateRelationship {
+				logger.Warn("failed to create inverse relationship", "error", err, "target", target.Name)
 			}
-			return fmt.Errorf("failed to create branch: %w", err)
+			return
+		}
+		if cv := target.GetCurrentVersion(); cv != nil {
+			cv.ModifiedAt = modifiedAt
 		}
 
-		// Checkout the branch
-		if err := gitRepo.CheckoutBranch(branchName); err != nil {
-			return fmt.Errorf("failed to checkout branch: %w", err)
+		data, err := storage.MarshalFeature(target)
+		if err != nil {
+			logger.Warn("failed to serialize feature", "error", err, "target", target.Name)
+			return
+		}
+		msg := fmt.Sprintf("fogit: add inverse relationship to %s", target.Name)
+		if err := gitRepo.UpdateFileOnBranch(parentBranch, file.Path, data, msg); err != nil {
+			logger.Warn("failed to save inverse relationship on branch", "error", err, "target", target.Name, "branch", parentBranch)
+			return
 		}
+		fmt.Printf("Auto-created inverse relationship: %s -> %s (%s)\n", target.Name, feature.Name, typeConfig.Inverse)
+		return
+	}
+}
 
-		fmt.Printf("✓ Created and checked out branch: %s\n", branchName)
-		return nil
+// createAndCheckoutBranch creates a branch at HEAD and checks it out
+func createAndCheckoutBranch(gitRepo *git.Repository, branchName string) error {
+	if err := gitRepo.CreateBranch(branchName); err != nil {
+		if err == git.ErrBranchExists {
+			return fmt.Errorf("branch %s already exists. Use --same to create feature on current branch", branchName)
+		}
+		if err == git.ErrEmptyRepository {
+			return fmt.Errorf("Git repository has no commits. Make an initial commit first:\n  git commit --allow-empty -m \"Initial commit\"")
+		}
+		return fmt.Errorf("failed to create branch: %w", err)
 	}
 
+	if err := gitRepo.CheckoutBranch(branchName); err != nil {
+		return fmt.Errorf("failed to checkout branch: %w", err)
+	}
 	return nil
 }
 
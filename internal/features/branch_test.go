@@ -255,3 +255,57 @@ func TestHandleBranchCreation_WorkflowModes(t *testing.T) {
 		})
 	}
 }
+
+func TestHandleStackedBranchCreation(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/auth")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/auth.yml", stackedFeatureYAML("auth-1", "Auth", "feature/auth", ""), "Add auth feature")
+	gitInRepo(t, repoPath, "checkout", trunk)
+	t.Chdir(repoPath)
+
+	cfg := fogit.DefaultConfig()
+	dependency := &fogit.Feature{ID: "auth-1", Name: "Auth"}
+	feature := fogit.NewFeature("Login")
+
+	t.Run("trunk-based mode errors", func(t *testing.T) {
+		trunkCfg := fogit.DefaultConfig()
+		trunkCfg.Workflow.Mode = "trunk-based"
+		if _, _, err := HandleStackedBranchCreation(feature, dependency, trunkCfg); err == nil || !strings.Contains(err.Error(), "branch-per-feature") {
+			t.Errorf("expected branch-per-feature error, got %v", err)
+		}
+	})
+
+	t.Run("creates branch on the dependency's branch", func(t *testing.T) {
+		branch, parent, err := HandleStackedBranchCreation(feature, dependency, cfg)
+		if err != nil {
+			t.Fatalf("HandleStackedBranchCreation() error = %v", err)
+		}
+		if branch != "feature/login" || parent != "feature/auth" {
+			t.Errorf("got branch %q on %q, want feature/login on feature/auth", branch, parent)
+		}
+		if current, _ := gitRepo.GetCurrentBranch(); current != "feature/login" {
+			t.Errorf("current branch = %s, want feature/login", current)
+		}
+		if !isAncestor(t, repoPath, "feature/auth", "feature/login") {
+			t.Error("feature/login not created from feature/auth")
+		}
+
+		// The inverse relationship is committed on the parent branch
+		data, err := gitRepo.ReadFileOnBranch("feature/auth", ".fogit/features/auth.yml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(data), "required-by") || !strings.Contains(string(data), feature.ID) {
+			t.Errorf("auth.yml on feature/auth lacks the required-by inverse:\n%s", data)
+		}
+	})
+
+	t.Run("missing dependency branch errors", func(t *testing.T) {
+		missing := &fogit.Feature{ID: "x-1", Name: "Unknown"}
+		if _, _, err := HandleStackedBranchCreation(feature, missing, cfg); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
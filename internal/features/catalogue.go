@@ -0,0 +1,167 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+const featuresTreePath = ".fogit/features"
+
+// branchCatalogueProvider is implemented by repositories that keep a catalogue
+// of the features on other branches (see storage.BranchCatalogue)
+type branchCatalogueProvider interface {
+	BranchCatalogue() *storage.BranchCatalogue
+}
+
+// CatalogueBranchStatus describes how a branch's catalogue entry compares to the branch
+type CatalogueBranchStatus struct {
+	Branch   string `json:"branch" yaml:"branch"`
+	Tip      string `json:"tip" yaml:"tip"` // Catalogued tip commit
+	Features int    `json:"features" yaml:"features"`
+	Fresh    bool   `json:"fresh" yaml:"fresh"` // The branch still points at the catalogued tip
+	Gone     bool   `json:"gone" yaml:"gone"`   // The branch no longer exists
+}
+
+// branchFeatureFiles reads the features on a branch, through the repository's
+// branch catalogue when it has one
+func branchFeatureFiles(repo fogit.Repository, gitRepo *git.Repository, branch string) ([]FeatureFile, error) {
+	if provider, ok := repo.(branchCatalogueProvider); ok {
+		return CatalogueBranch(provider.BranchCatalogue(), gitRepo, branch)
+	}
+	return ListFeatureFilesAtRef(gitRepo, branch)
+}
+
+// saveBranchCatalogue saves the repository's branch catalogue if it changed
+func saveBranchCatalogue(repo fogit.Repository) {
+	if provider, ok := repo.(branchCatalogueProvider); ok {
+		if cat := provider.BranchCatalogue(); cat.IsDirty() {
+			_ = cat.Save() // Best effort
+		}
+	}
+}
+
+// pruneBranchCatalogue drops catalogued branches that are not in present
+func pruneBranchCatalogue(repo fogit.Repository, present map[string]bool) {
+	if provider, ok := repo.(branchCatalogueProvider); ok {
+		provider.BranchCatalogue().Prune(present)
+	}
+}
+
+// CatalogueBranch returns the features on a branch, reading from Git only what
+// changed since the branch was catalogued: nothing if its tip is unchanged, the
+// file list if its features tree changed, and only blobs not seen before.
+func CatalogueBranch(cat *storage.BranchCatalogue, gitRepo *git.Repository, branch string) ([]FeatureFile, error) {
+	tip, tree, err := gitRepo.ResolveTree(branch, featuresTreePath)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, ok := cat.Branch(branch)
+	if !ok || entry.Tip != tip {
+		files, found := cat.FilesForTree(tree)
+		if !found && tree != "" {
+			blobs, err := gitRepo.ListTreeBlobs(tree)
+			if err != nil {
+				return nil, err
+			}
+			for _, blob := range blobs {
+				if isYAMLFile(blob.Path) {
+					files = append(files, storage.CataloguedFile{Path: featuresTreePath + "/" + blob.Path, Blob: blob.Hash})
+				}
+			}
+		}
+		entry = storage.CataloguedBranch{Tip: tip, Tree: tree, Files: files, CataloguedAt: time.Now().UTC()}
+		cat.SetBranch(branch, entry)
+	}
+
+	result := make([]FeatureFile, 0, len(entry.Files))
+	for _, file := range entry.Files {
+		feature, ok := cat.Feature(file.Blob)
+		if !ok {
+			feature = parseFeatureBlob(gitRepo, file.Blob)
+			if err := cat.PutFeature(file.Blob, feature); err != nil {
+				return nil, err
+			}
+		}
+		if feature != nil {
+			result = append(result, FeatureFile{Feature: feature, Path: file.Path})
+		}
+	}
+	return result, nil
+}
+
+// parseFeatureBlob reads and parses a feature blob, returning nil if it isn't a valid feature
+func parseFeatureBlob(gitRepo *git.Repository, blob string) *fogit.Feature {
+	data, err := gitRepo.ReadBlob(blob)
+	if err != nil {
+		return nil
+	}
+	feature, err := storage.UnmarshalFeature(data)
+	if err != nil {
+		return nil
+	}
+	return feature
+}
+
+// RebuildBranchCatalogue discards the catalogue and catalogues every local and
+// remote branch again. Returns the number of branches catalogued.
+func RebuildBranchCatalogue(ctx context.Context, cat *storage.BranchCatalogue, gitRepo *git.Repository) (int, error) {
+	if err := cat.Clear(); err != nil {
+		return 0, err
+	}
+
+	branches, err := allBranches(gitRepo)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, branch := range branches {
+		if err := ctx.Err(); err != nil {
+			return count, err
+		}
+		if _, err := CatalogueBranch(cat, gitRepo, branch); err != nil {
+			continue // e.g. origin/HEAD or a branch without commits
+		}
+		count++
+	}
+
+	if err := cat.Save(); err != nil {
+		return count, err
+	}
+	return count, nil
+}
+
+// BranchCatalogueStatus compares each catalogued branch with where it points now
+func BranchCatalogueStatus(cat *storage.BranchCatalogue, gitRepo *git.Repository) []CatalogueBranchStatus {
+	names := cat.BranchNames()
+	statuses := make([]CatalogueBranchStatus, 0, len(names))
+	for _, name := range names {
+		entry, _ := cat.Branch(name)
+		status := CatalogueBranchStatus{Branch: name, Tip: entry.Tip, Features: len(entry.Files)}
+		if tip, _, err := gitRepo.ResolveCommit(name); err != nil {
+			status.Gone = true
+		} else {
+			status.Fresh = tip == entry.Tip
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// allBranches lists the local and remote-tracking branches
+func allBranches(gitRepo *git.Repository) ([]string, error) {
+	branches, err := gitRepo.ListBranches()
+	if err != nil {
+		return nil, err
+	}
+	remoteBranches, err := gitRepo.ListRemoteBranches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote branches: %w", err)
+	}
+	return append(branches, remoteBranches...), nil
+}
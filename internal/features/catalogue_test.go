@@ -0,0 +1,150 @@
+package features
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+)
+
+func TestCatalogueBranch(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/login")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/login.yml", stackedFeatureYAML("login-1", "Login", "feature/login", ""), "Add login")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/notes.txt", "not a feature", "Add notes")
+
+	cat := storage.NewBranchCatalogue(t.TempDir())
+
+	files, err := CatalogueBranch(cat, gitRepo, "feature/login")
+	if err != nil {
+		t.Fatalf("CatalogueBranch() error = %v", err)
+	}
+	if len(files) != 1 || files[0].Feature.ID != "login-1" || files[0].Path != ".fogit/features/login.yml" {
+		t.Fatalf("CatalogueBranch() = %+v, want login.yml", files)
+	}
+	first, _ := cat.Branch("feature/login")
+
+	t.Run("unchanged tip reuses the entry", func(t *testing.T) {
+		// Swap the catalogued feature to prove it is not re-read from Git
+		cached, _ := cat.Feature(first.Files[0].Blob)
+		cached.Name = "Cached Login"
+		if err := cat.PutFeature(first.Files[0].Blob, cached); err != nil {
+			t.Fatal(err)
+		}
+
+		files, err := CatalogueBranch(cat, gitRepo, "feature/login")
+		if err != nil {
+			t.Fatalf("CatalogueBranch() error = %v", err)
+		}
+		if len(files) != 1 || files[0].Feature.Name != "Cached Login" {
+			t.Errorf("CatalogueBranch() = %+v, want the catalogued feature", files)
+		}
+		if entry, _ := cat.Branch("feature/login"); !entry.CataloguedAt.Equal(first.CataloguedAt) {
+			t.Error("entry was re-catalogued although the tip did not move")
+		}
+	})
+
+	t.Run("moved tip picks up changes", func(t *testing.T) {
+		createTestCommitInRepo(t, repoPath, ".fogit/features/signup.yml", stackedFeatureYAML("signup-1", "Signup", "feature/login", ""), "Add signup")
+
+		files, err := CatalogueBranch(cat, gitRepo, "feature/login")
+		if err != nil {
+			t.Fatalf("CatalogueBranch() error = %v", err)
+		}
+		if len(files) != 2 {
+			t.Fatalf("CatalogueBranch() returned %d features, want 2", len(files))
+		}
+		entry, _ := cat.Branch("feature/login")
+		if entry.Tip != gitInRepo(t, repoPath, "rev-parse", "feature/login") {
+			t.Errorf("catalogued tip = %s, want the new tip", entry.Tip)
+		}
+	})
+
+	t.Run("branch without features", func(t *testing.T) {
+		files, err := CatalogueBranch(cat, gitRepo, trunk)
+		if err != nil {
+			t.Fatalf("CatalogueBranch(%s) error = %v", trunk, err)
+		}
+		if len(files) != 0 {
+			t.Errorf("CatalogueBranch(%s) = %+v, want none", trunk, files)
+		}
+	})
+
+	t.Run("status and rebuild", func(t *testing.T) {
+		gitInRepo(t, repoPath, "branch", "feature/copy", "feature/login")
+		createTestCommitInRepo(t, repoPath, "login.go", "package login\n", "Implement login")
+
+		statuses := BranchCatalogueStatus(cat, gitRepo)
+		fresh := make(map[string]bool)
+		for _, s := range statuses {
+			fresh[s.Branch] = s.Fresh
+		}
+		if len(statuses) != 2 || fresh["feature/login"] || !fresh[trunk] {
+			t.Errorf("BranchCatalogueStatus() = %+v, want login stale and %s fresh", statuses, trunk)
+		}
+
+		count, err := RebuildBranchCatalogue(context.Background(), cat, gitRepo)
+		if err != nil {
+			t.Fatalf("RebuildBranchCatalogue() error = %v", err)
+		}
+		if count != 3 {
+			t.Errorf("RebuildBranchCatalogue() = %d, want 3 branches", count)
+		}
+		// feature/copy has the same features tree as feature/login
+		login, _ := cat.Branch("feature/login")
+		copied, _ := cat.Branch("feature/copy")
+		if login.Tree != copied.Tree || len(copied.Files) != 2 {
+			t.Errorf("feature/copy = %+v, want the features tree of feature/login", copied)
+		}
+		for _, s := range BranchCatalogueStatus(cat, gitRepo) {
+			if !s.Fresh {
+				t.Errorf("%s is stale after rebuild", s.Branch)
+			}
+		}
+	})
+}
+
+func TestListFeaturesAcrossBranches_Catalogue(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/login")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/login.yml", stackedFeatureYAML("login-1", "Login", "feature/login", ""), "Add login")
+	gitInRepo(t, repoPath, "checkout", trunk)
+
+	ctx := context.Background()
+	fogitDir := filepath.Join(repoPath, ".fogit")
+	repo := storage.NewFileRepository(fogitDir)
+
+	found, err := ListFeaturesAcrossBranches(ctx, repo, gitRepo)
+	if err != nil {
+		t.Fatalf("ListFeaturesAcrossBranches() error = %v", err)
+	}
+	if len(found) != 1 || found[0].Feature.ID != "login-1" {
+		t.Fatalf("ListFeaturesAcrossBranches() = %+v, want login-1", found)
+	}
+
+	saved := storage.NewBranchCatalogue(fogitDir)
+	if err := saved.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved.Branch("feature/login"); !ok {
+		t.Fatal("feature/login was not saved to the catalogue")
+	}
+
+	// Deleted branches are pruned
+	gitInRepo(t, repoPath, "branch", "-D", "feature/login")
+	repo = storage.NewFileRepository(fogitDir)
+	if _, err := ListFeaturesAcrossBranches(ctx, repo, gitRepo); err != nil {
+		t.Fatalf("ListFeaturesAcrossBranches() error = %v", err)
+	}
+	if err := saved.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := saved.Branch("feature/login"); ok {
+		t.Error("deleted branch is still catalogued")
+	}
+}
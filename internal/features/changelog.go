@@ -0,0 +1,286 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// otherSection holds changelog entries that match no section or have no group value
+const otherSection = "Other"
+
+// Changelog lists the feature versions closed between two Git refs
+type Changelog struct {
+	From     string              `json:"from,omitempty"`
+	To       string              `json:"to"`
+	ToCommit string              `json:"to_commit"`
+	Date     time.Time           `json:"date"` // Commit time of To
+	Sections []*ChangelogSection `json:"sections"`
+}
+
+// ChangelogSection is a titled group of changelog entries
+type ChangelogSection struct {
+	Title    string            `json:"title"`
+	Template string            `json:"-"` // Entry template override from config
+	Entries  []*ChangelogEntry `json:"entries"`
+}
+
+// ChangelogEntry is one feature version closed within the range
+type ChangelogEntry struct {
+	ID       string            `json:"id"`
+	Name     string            `json:"name"`
+	Version  string            `json:"version"`
+	Type     string            `json:"type,omitempty"`
+	Category string            `json:"category,omitempty"`
+	Priority string            `json:"priority,omitempty"`
+	Branch   string            `json:"branch,omitempty"`
+	ClosedAt time.Time         `json:"closed_at"`
+	Authors  []string          `json:"authors,omitempty"`
+	Notes    string            `json:"notes,omitempty"`
+	Commits  []ChangelogCommit `json:"commits,omitempty"`
+	Feature  *fogit.Feature    `json:"-"`
+}
+
+// ChangelogCommit is a commit in the range that touched the feature's file
+type ChangelogCommit struct {
+	Hash    string    `json:"hash"`
+	Author  string    `json:"author"`
+	Date    time.Time `json:"date"`
+	Subject string    `json:"subject"`
+}
+
+// ChangelogOptions configures changelog generation
+type ChangelogOptions struct {
+	From    string // Start ref (exclusive); empty for all history
+	To      string // End ref (inclusive)
+	GroupBy string // type, category, priority or a metadata key; ignored when Config has sections
+	Config  *fogit.ChangelogConfig
+
+	// RelationType resolves relationship types and aliases in section filters
+	// (usually Config.ResolveRelationshipType of the repository config)
+	RelationType func(name string) (string, bool)
+}
+
+// BuildChangelog finds the feature versions closed between two refs.
+// A version is included when it is closed in the feature file at To but was not
+// closed (or didn't exist) at From. Features are matched by ID, so renamed files
+// and features merged in from other branches are handled.
+func BuildChangelog(gitRepo *git.Repository, opts ChangelogOptions) (*Changelog, error) {
+	toCommit, toDate, err := gitRepo.ResolveCommit(opts.To)
+	if err != nil {
+		return nil, err
+	}
+	after, err := ListFeatureFilesAtRef(gitRepo, toCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read features at %s: %w", opts.To, err)
+	}
+
+	var before []FeatureFile
+	var fromCommit string
+	if opts.From != "" {
+		fromCommit, _, err = gitRepo.ResolveCommit(opts.From)
+		if err != nil {
+			return nil, err
+		}
+		before, err = ListFeatureFilesAtRef(gitRepo, fromCommit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read features at %s: %w", opts.From, err)
+		}
+	}
+
+	entries := ClosedVersionsBetween(before, after)
+
+	// Attach the commits in the range that touched each feature
+	paths := make(map[string]string, len(after))
+	for _, file := range after {
+		paths[file.Feature.ID] = file.Path
+	}
+	commitsByPath := make(map[string][]ChangelogCommit)
+	for _, entry := range entries {
+		path := paths[entry.ID]
+		commits, ok := commitsByPath[path]
+		if !ok {
+			logs, err := gitRepo.GetLogRange(fromCommit, toCommit, path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read history of %s: %w", path, err)
+			}
+			for _, l := range logs {
+				commits = append(commits, ChangelogCommit{
+					Hash:    l.Hash,
+					Author:  l.Author,
+					Date:    l.Date,
+					Subject: strings.SplitN(l.Message, "\n", 2)[0],
+				})
+			}
+			commitsByPath[path] = commits
+		}
+		entry.Commits = commits
+	}
+
+	features := make([]*fogit.Feature, len(after))
+	for i, file := range after {
+		features[i] = file.Feature
+	}
+	sections, err := GroupChangelog(entries, features, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Changelog{
+		From:     opts.From,
+		To:       opts.To,
+		ToCommit: toCommit,
+		Date:     toDate,
+		Sections: sections,
+	}, nil
+}
+
+// ClosedVersionsBetween returns the versions closed in after but not in before,
+// ordered by close time
+func ClosedVersionsBetween(before, after []FeatureFile) []*ChangelogEntry {
+	closedBefore := make(map[string]bool)
+	for _, file := range before {
+		for key, v := range file.Feature.Versions {
+			if v.ClosedAt != nil {
+				closedBefore[file.Feature.ID+"@"+key] = true
+			}
+		}
+	}
+
+	var entries []*ChangelogEntry
+	for _, file := range after {
+		f := file.Feature
+		for key, v := range f.Versions {
+			if v.ClosedAt == nil || closedBefore[f.ID+"@"+key] {
+				continue
+			}
+			entries = append(entries, &ChangelogEntry{
+				ID:       f.ID,
+				Name:     f.Name,
+				Version:  key,
+				Type:     f.GetType(),
+				Category: f.GetCategory(),
+				Priority: string(f.GetPriority()),
+				Branch:   v.Branch,
+				ClosedAt: *v.ClosedAt,
+				Authors:  v.Authors,
+				Notes:    v.Notes,
+				Feature:  f,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].ClosedAt.Equal(entries[j].ClosedAt) {
+			return entries[i].ClosedAt.Before(entries[j].ClosedAt)
+		}
+		if entries[i].Name != entries[j].Name {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Version < entries[j].Version
+	})
+	return entries
+}
+
+// GroupChangelog sorts entries into sections.
+// Configured sections are filled in order, each entry going to the first match;
+// otherwise entries are grouped by opts.GroupBy (default: type).
+// Entries without a section end up in a trailing "Other" section.
+// Relationship predicates in section filters are evaluated against features,
+// the features at the end of the range.
+func GroupChangelog(entries []*ChangelogEntry, features []*fogit.Feature, opts ChangelogOptions) ([]*ChangelogSection, error) {
+	cfg, groupBy := opts.Config, opts.GroupBy
+	var sections []*ChangelogSection
+	var other *ChangelogSection
+
+	if cfg != nil && len(cfg.Sections) > 0 {
+		exprs := make([]fogit.FilterExpr, len(cfg.Sections))
+		for i, sc := range cfg.Sections {
+			sections = append(sections, &ChangelogSection{Title: sc.Title, Template: sc.Template})
+			if sc.Filter != "" {
+				expr, err := fogit.ParseFilterExprWith(sc.Filter, fogit.FilterParseOptions{RelationType: opts.RelationType})
+				if err != nil {
+					return nil, fmt.Errorf("invalid filter for changelog section %q: %w", sc.Title, err)
+				}
+				fogit.BindFilterGraph(expr, features)
+				exprs[i] = expr
+			}
+		}
+		for _, entry := range entries {
+			placed := false
+			for i, expr := range exprs {
+				if expr == nil || expr.Matches(entry.Feature) {
+					sections[i].Entries = append(sections[i].Entries, entry)
+					placed = true
+					break
+				}
+			}
+			if !placed {
+				if other == nil {
+					other = &ChangelogSection{Title: otherSection}
+				}
+				other.Entries = append(other.Entries, entry)
+			}
+		}
+
+		// Drop configured sections that ended up empty
+		nonEmpty := sections[:0]
+		for _, s := range sections {
+			if len(s.Entries) > 0 {
+				nonEmpty = append(nonEmpty, s)
+			}
+		}
+		sections = nonEmpty
+	} else {
+		if groupBy == "" && cfg != nil {
+			groupBy = cfg.GroupBy
+		}
+		if groupBy == "" {
+			groupBy = "type"
+		}
+		byTitle := make(map[string]*ChangelogSection)
+		for _, entry := range entries {
+			title := changelogGroupValue(entry, groupBy)
+			if title == "" {
+				if other == nil {
+					other = &ChangelogSection{Title: otherSection}
+				}
+				other.Entries = append(other.Entries, entry)
+				continue
+			}
+			section, ok := byTitle[title]
+			if !ok {
+				section = &ChangelogSection{Title: title}
+				byTitle[title] = section
+				sections = append(sections, section)
+			}
+			section.Entries = append(section.Entries, entry)
+		}
+		sort.SliceStable(sections, func(i, j int) bool {
+			return sections[i].Title < sections[j].Title
+		})
+	}
+
+	if other != nil {
+		sections = append(sections, other)
+	}
+	return sections, nil
+}
+
+// changelogGroupValue returns the section title for an entry when grouping by a field
+func changelogGroupValue(entry *ChangelogEntry, groupBy string) string {
+	switch groupBy {
+	case "type":
+		return entry.Type
+	case "category":
+		return entry.Category
+	case "priority":
+		return entry.Priority
+	default:
+		return entry.Feature.GetMetadataString(groupBy)
+	}
+}
@@ -0,0 +1,164 @@
+package features
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func closedFeature(name, featureType string, closedAt time.Time) *fogit.Feature {
+	f := fogit.NewFeature(name)
+	f.SetType(featureType)
+	f.Versions["1"].ClosedAt = &closedAt
+	return f
+}
+
+func entryNames(entries []*ChangelogEntry) string {
+	names := make([]string, len(entries))
+	for i, e := range entries {
+		names[i] = e.Name + "@" + e.Version
+	}
+	return strings.Join(names, ",")
+}
+
+func TestClosedVersionsBetween(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	released := closedFeature("Released", "software-feature", base)
+	open := fogit.NewFeature("Open")
+	reopened := closedFeature("Reopened", "software-feature", base)
+
+	before := []FeatureFile{{Feature: released}, {Feature: open}, {Feature: reopened}}
+
+	// At the later ref: Open got closed, Reopened got a second closed version,
+	// and a new feature was created and closed
+	openNow := *open
+	openNow.Versions = map[string]*fogit.FeatureVersion{"1": {CreatedAt: base, ClosedAt: timePtr(base.Add(48 * time.Hour))}}
+	reopenedNow := *reopened
+	reopenedNow.Versions = map[string]*fogit.FeatureVersion{
+		"1": reopened.Versions["1"],
+		"2": {CreatedAt: base.Add(time.Hour), ClosedAt: timePtr(base.Add(72 * time.Hour))},
+	}
+	added := closedFeature("Added", "bugfix", base.Add(24*time.Hour))
+
+	after := []FeatureFile{{Feature: released}, {Feature: &openNow}, {Feature: &reopenedNow}, {Feature: added}}
+
+	got := entryNames(ClosedVersionsBetween(before, after))
+	if want := "Added@1,Open@1,Reopened@2"; got != want {
+		t.Errorf("entries = %s, want %s", got, want)
+	}
+
+	// Without a start ref, every closed version counts
+	got = entryNames(ClosedVersionsBetween(nil, after))
+	if want := "Released@1,Reopened@1,Added@1,Open@1,Reopened@2"; got != want {
+		t.Errorf("entries from start = %s, want %s", got, want)
+	}
+}
+
+func TestGroupChangelog_RelationshipFilters(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	schema := closedFeature("Schema", "", base)
+	migration := fogit.NewFeature("Migration") // still open
+	api := closedFeature("API", "", base.Add(time.Hour))
+	api.Relationships = []fogit.Relationship{fogit.NewRelationship("blocked-by", migration.ID, migration.Name)}
+	ui := closedFeature("UI", "", base.Add(2*time.Hour))
+	ui.Relationships = []fogit.Relationship{fogit.NewRelationship("blocked-by", schema.ID, schema.Name)}
+
+	all := []*fogit.Feature{schema, migration, api, ui}
+	files := make([]FeatureFile, len(all))
+	for i, f := range all {
+		files[i] = FeatureFile{Feature: f}
+	}
+	entries := ClosedVersionsBetween(nil, files)
+
+	cfg := fogit.DefaultConfig()
+	opts := ChangelogOptions{
+		Config: &fogit.ChangelogConfig{Sections: []fogit.ChangelogSection{
+			{Title: "Unblocking", Filter: "incoming(blocked-by) > 0"},
+			{Title: "Shipped blocked", Filter: "blocked-by.state=open"},
+		}},
+		RelationType: cfg.ResolveRelationshipType,
+	}
+
+	sections, err := GroupChangelog(entries, all, opts)
+	if err != nil {
+		t.Fatalf("GroupChangelog() error = %v", err)
+	}
+	parts := make([]string, len(sections))
+	for i, s := range sections {
+		parts[i] = s.Title + "=" + entryNames(s.Entries)
+	}
+	if got, want := strings.Join(parts, ";"), "Unblocking=Schema@1;Shipped blocked=API@1;Other=UI@1"; got != want {
+		t.Errorf("sections = %s, want %s", got, want)
+	}
+
+	// Unknown fields are rejected rather than matching nothing
+	opts.Config = &fogit.ChangelogConfig{Sections: []fogit.ChangelogSection{{Title: "Typo", Filter: "stat:open"}}}
+	if _, err := GroupChangelog(entries, all, opts); err == nil {
+		t.Error("expected error for unknown field in section filter")
+	}
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestGroupChangelog(t *testing.T) {
+	base := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	login := closedFeature("Login", "software-feature", base)
+	crash := closedFeature("Crash", "bugfix", base.Add(time.Hour))
+	crash.AddTag("breaking")
+	chore := closedFeature("Chore", "", base.Add(2*time.Hour))
+	chore.SetCategory("infra")
+
+	entries := ClosedVersionsBetween(nil, []FeatureFile{{Feature: login}, {Feature: crash}, {Feature: chore}})
+
+	tests := []struct {
+		name    string
+		cfg     *fogit.ChangelogConfig
+		groupBy string
+		want    string
+	}{
+		{
+			name: "by type",
+			want: "bugfix=Crash@1;software-feature=Login@1;Other=Chore@1",
+		},
+		{
+			name:    "by category",
+			groupBy: "category",
+			want:    "infra=Chore@1;Other=Login@1,Crash@1",
+		},
+		{
+			name: "configured group-by",
+			cfg:  &fogit.ChangelogConfig{GroupBy: "category"},
+			want: "infra=Chore@1;Other=Login@1,Crash@1",
+		},
+		{
+			name: "sections, first match wins",
+			cfg: &fogit.ChangelogConfig{Sections: []fogit.ChangelogSection{
+				{Title: "Breaking", Filter: "tags:breaking"},
+				{Title: "Fixes", Filter: "type:bugfix"},
+				{Title: "Features", Filter: "type:software-feature"},
+			}},
+			want: "Breaking=Crash@1;Features=Login@1;Other=Chore@1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sections, err := GroupChangelog(entries, nil, ChangelogOptions{Config: tt.cfg, GroupBy: tt.groupBy})
+			if err != nil {
+				t.Fatalf("GroupChangelog() error = %v", err)
+			}
+			parts := make([]string, len(sections))
+			for i, s := range sections {
+				parts[i] = s.Title + "=" + entryNames(s.Entries)
+			}
+			if got := strings.Join(parts, ";"); got != tt.want {
+				t.Errorf("sections = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
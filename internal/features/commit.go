@@ -18,11 +18,13 @@ type CommitOptions struct {
 	Author     string // Override author (optional)
 	AutoLink   bool   // Auto-link changed files to primary feature
 	AllowDirty bool   // Allow commit with uncommitted changes in .fogit/
+	Trailers   fogit.CommitTrailers
 }
 
 // CommitResult contains the result of a Commit operation
 type CommitResult struct {
 	Hash            string
+	Message         string // Commit message including trailers
 	Author          *object.Signature
 	Branch          string
 	Features        []*fogit.Feature
@@ -101,8 +103,15 @@ func Commit(ctx context.Context, repo fogit.Repository, gitRepo *git.Repository,
 		}
 	}
 
+	// Link the commit to every feature it updated
+	message := opts.Message
+	if opts.Trailers.IsEnabled() {
+		message = git.AppendTrailers(message, FeatureTrailers(opts.Trailers, branchFeatures...))
+	}
+	result.Message = message
+
 	// Now commit changes (includes .fogit/ directory)
-	hash, err := gitRepo.Commit(opts.Message, author)
+	hash, err := gitRepo.Commit(message, author)
 	if err != nil {
 		if err == git.ErrNothingToCommit {
 			result.NothingToCommit = true
@@ -115,6 +124,21 @@ func Commit(ctx context.Context, repo fogit.Repository, gitRepo *git.Repository,
 	return result, nil
 }
 
+// FeatureTrailers returns the commit trailers linking a commit to features.
+// Each feature trailer is followed by its version trailer, so they pair up by order.
+func FeatureTrailers(cfg fogit.CommitTrailers, features ...*fogit.Feature) []git.Trailer {
+	var trailers []git.Trailer
+	for _, f := range features {
+		trailers = append(trailers, git.Trailer{Key: cfg.FeatureTrailerKey(), Value: f.ID})
+		if key := cfg.VersionTrailerKey(); key != "" {
+			if version := f.GetCurrentVersionKey(); version != "" {
+				trailers = append(trailers, git.Trailer{Key: key, Value: version})
+			}
+		}
+	}
+	return trailers
+}
+
 // resolveAuthor resolves the commit author from the provided override or git config
 func resolveAuthor(gitRepo *git.Repository, authorOverride string) (*object.Signature, error) {
 	if authorOverride != "" {
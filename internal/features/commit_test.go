@@ -363,3 +363,55 @@ func TestCommit_MultipleFeaturesOnBranch(t *testing.T) {
 		t.Error("Feature 2 ModifiedAt was not updated")
 	}
 }
+
+func TestCommit_AppendsTrailers(t *testing.T) {
+	tempDir, gitRepo, repo, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	testFile := filepath.Join(tempDir, "traced.txt")
+
+	// Trailers turned off
+	untraced := fogit.NewFeature("Untraced Feature")
+	if err := repo.Create(ctx, untraced); err != nil {
+		t.Fatalf("Failed to create feature: %v", err)
+	}
+	if err := os.WriteFile(testFile, []byte("one"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+	if _, err := Commit(ctx, repo, gitRepo, CommitOptions{Message: "Untraced commit", Trailers: fogit.CommitTrailers{Enabled: new(bool)}}); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	feature := fogit.NewFeature("Traced Feature")
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Failed to create feature: %v", err)
+	}
+	if err := os.WriteFile(testFile, []byte("two"), 0644); err != nil {
+		t.Fatalf("Failed to update test file: %v", err)
+	}
+	result, err := Commit(ctx, repo, gitRepo, CommitOptions{Message: "Traced commit\n\nSigned-off-by: Test User <test@example.com>"})
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	want := "Signed-off-by: Test User <test@example.com>\nFogit-Feature: " + feature.ID + "\nFogit-Version: 1"
+	if !strings.HasSuffix(result.Message, want) {
+		t.Errorf("Message = %q, want suffix %q", result.Message, want)
+	}
+
+	logs, err := gitRepo.GetLogByTrailer(fogit.DefaultFeatureTrailer, feature.ID, "", nil, 0)
+	if err != nil {
+		t.Fatalf("GetLogByTrailer failed: %v", err)
+	}
+	if len(logs) != 1 || logs[0].Hash != result.Hash {
+		t.Errorf("Expected only the traced commit, got %+v", logs)
+	}
+
+	logs, err = gitRepo.GetLogByTrailer(fogit.DefaultFeatureTrailer, untraced.ID, "", nil, 0)
+	if err != nil {
+		t.Fatalf("GetLogByTrailer failed: %v", err)
+	}
+	if len(logs) != 0 {
+		t.Errorf("Expected no commits for the untraced feature, got %+v", logs)
+	}
+}
@@ -3,8 +3,10 @@ package features
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/eg3r/fogit/internal/common"
 	"github.com/eg3r/fogit/pkg/fogit"
 )
 
@@ -21,19 +23,46 @@ type CreateOptions struct {
 	Tags        []string
 	Metadata    map[string]interface{}
 	ParentID    string
+	Template    *fogit.FeatureTemplate // Defaults applied before the explicit options
 
 	// Git options
 	SameBranch    bool
 	IsolateBranch bool
 	FromCurrent   bool // Override create_branch_from, create from current branch
+	SkipBranch    bool // Never create or switch branches (e.g. requests served over HTTP)
+
+	// StackOn is an unmerged feature the new one depends on: the feature branch is
+	// created from its branch and a depends-on relationship is added
+	StackOn *fogit.Feature
 }
 
 func Create(ctx context.Context, repo fogit.Repository, opts CreateOptions, cfg *fogit.Config, fogitDir string) (*fogit.Feature, error) {
+	if opts.StackOn != nil && opts.SameBranch {
+		return nil, fmt.Errorf("cannot stack on another feature's branch and stay on the current branch")
+	}
+
+	tpl := opts.Template
+	if tpl != nil {
+		opts.Description = common.Coalesce(opts.Description, tpl.FeatureDescription)
+		opts.Type = common.Coalesce(opts.Type, tpl.Type)
+		opts.Priority = common.Coalesce(opts.Priority, tpl.Priority)
+		opts.Tags = mergeTags(tpl.Tags, opts.Tags)
+	}
+
 	// Create feature object
 	feature := fogit.NewFeature(opts.Name)
 	feature.Description = opts.Description
 	feature.Tags = opts.Tags
 
+	// Template metadata defaults go first so organization flags and --metadata override them
+	if tpl != nil {
+		for k, v := range tpl.Metadata {
+			if v != nil {
+				feature.SetMetadata(k, v)
+			}
+		}
+	}
+
 	// Set organization fields via metadata accessors (per spec 06-data-model.md)
 	if opts.Type != "" {
 		feature.SetType(opts.Type)
@@ -78,14 +107,46 @@ func Create(ctx context.Context, repo fogit.Repository, opts CreateOptions, cfg
 		}
 	}
 
+	if tpl != nil {
+		if missing := tpl.MissingMetadata(feature.Metadata); len(missing) > 0 {
+			return nil, fmt.Errorf("template '%s' requires metadata: %s", tpl.Name, strings.Join(missing, ", "))
+		}
+		if err := addTemplateRelationships(ctx, repo, feature, tpl, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.StackOn != nil {
+		if err := addStackDependency(feature, opts.StackOn, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg != nil {
+		if err := cfg.MetadataSchema.Enforce(feature); err != nil {
+			return nil, err
+		}
+	}
+
 	// Validate feature
 	if err := feature.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid feature: %w", err)
 	}
 
 	// Handle Git branch creation
-	if err := HandleBranchCreation(opts.Name, cfg, opts.SameBranch, opts.IsolateBranch, opts.FromCurrent); err != nil {
-		return nil, err
+	if !opts.SkipBranch && opts.StackOn != nil {
+		branch, parentBranch, err := HandleStackedBranchCreation(feature, opts.StackOn, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if cv := feature.GetCurrentVersion(); cv != nil {
+			cv.Branch = branch
+			cv.ParentBranch = parentBranch
+		}
+	} else if !opts.SkipBranch {
+		if err := HandleBranchCreation(opts.Name, cfg, opts.SameBranch, opts.IsolateBranch, opts.FromCurrent); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create feature in repository
@@ -95,3 +156,90 @@ func Create(ctx context.Context, repo fogit.Repository, opts CreateOptions, cfg
 
 	return feature, nil
 }
+
+// addTemplateRelationships resolves the template's relationship targets by ID or name
+// and adds them to the new feature
+func addTemplateRelationships(ctx context.Context, repo fogit.Repository, feature *fogit.Feature, tpl *fogit.FeatureTemplate, cfg *fogit.Config) error {
+	if len(tpl.Relationships) == 0 {
+		return nil
+	}
+
+	all, err := repo.List(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to resolve template relationships: %w", err)
+	}
+
+	// Scaffolding added at creation does not count as work, keep the feature open
+	if cv := feature.GetCurrentVersion(); cv != nil {
+		modifiedAt := cv.ModifiedAt
+		defer func() { cv.ModifiedAt = modifiedAt }()
+	}
+
+	for _, tr := range tpl.Relationships {
+		var target *fogit.Feature
+		for _, f := range all {
+			if f.ID == tr.Target || strings.EqualFold(f.Name, tr.Target) {
+				target = f
+				break
+			}
+		}
+		if target == nil {
+			return fmt.Errorf("template '%s' links to unknown feature '%s'", tpl.Name, tr.Target)
+		}
+
+		rel := fogit.NewRelationship(fogit.RelationshipType(tr.Type), target.ID, target.Name)
+		rel.Description = tr.Description
+		if cfg != nil {
+			if err := rel.ValidateWithConfig(cfg); err != nil {
+				return fmt.Errorf("template '%s': %w", tpl.Name, err)
+			}
+		}
+		if err := feature.AddRelationship(rel); err != nil {
+			return fmt.Errorf("template '%s': failed to add %s relationship: %w", tpl.Name, tr.Type, err)
+		}
+	}
+
+	return nil
+}
+
+// addStackDependency adds a depends-on relationship to the feature being stacked on,
+// unless the feature already has one (e.g. from its template)
+func addStackDependency(feature, dependency *fogit.Feature, cfg *fogit.Config) error {
+	for _, rel := range feature.Relationships {
+		if rel.Type == "depends-on" && rel.TargetID == dependency.ID {
+			return nil
+		}
+	}
+
+	if cv := feature.GetCurrentVersion(); cv != nil {
+		modifiedAt := cv.ModifiedAt
+		defer func() { cv.ModifiedAt = modifiedAt }()
+	}
+
+	rel := fogit.NewRelationship("depends-on", dependency.ID, dependency.Name)
+	if cfg != nil {
+		if err := rel.ValidateWithConfig(cfg); err != nil {
+			return fmt.Errorf("cannot stack on '%s': %w", dependency.Name, err)
+		}
+	}
+	if err := feature.AddRelationship(rel); err != nil {
+		return fmt.Errorf("failed to add depends-on relationship: %w", err)
+	}
+	return nil
+}
+
+// mergeTags appends extra tags to base, skipping duplicates
+func mergeTags(base, extra []string) []string {
+	if len(base) == 0 {
+		return extra
+	}
+	seen := make(map[string]bool)
+	var merged []string
+	for _, tag := range append(append([]string{}, base...), extra...) {
+		if !seen[tag] {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,121 @@
+package features
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestCreate_WithTemplate(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewFileRepository(t.TempDir())
+	cfg := fogit.DefaultConfig()
+	cfg.Workflow.AllowSharedBranches = true
+
+	epic := fogit.NewFeature("API Platform")
+	if err := repo.Create(ctx, epic); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	tpl := &fogit.FeatureTemplate{
+		Name:               "api-endpoint",
+		Type:               "api-endpoint",
+		Priority:           "high",
+		FeatureDescription: "## Request\n\n## Response\n",
+		Tags:               []string{"api"},
+		Metadata:           map[string]interface{}{"sla": "99.9", "team": "platform"},
+		RequiredMetadata:   []string{"owner"},
+		Relationships:      []fogit.TemplateRelationship{{Type: "contained-by", Target: "api platform"}},
+	}
+
+	// Required metadata must be provided
+	_, err := Create(ctx, repo, CreateOptions{Name: "Get Orders", Template: tpl, SameBranch: true}, cfg, "")
+	if err == nil || !strings.Contains(err.Error(), "requires metadata: owner") {
+		t.Fatalf("Create() without owner error = %v", err)
+	}
+
+	feature, err := Create(ctx, repo, CreateOptions{
+		Name:       "Get Orders",
+		Team:       "orders",
+		Tags:       []string{"orders", "api"},
+		Metadata:   map[string]interface{}{"owner": "alice"},
+		Template:   tpl,
+		SameBranch: true,
+	}, cfg, "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if feature.GetType() != "api-endpoint" || feature.GetPriority() != fogit.PriorityHigh {
+		t.Errorf("type/priority = %s/%s, want template defaults", feature.GetType(), feature.GetPriority())
+	}
+	if feature.Description != tpl.FeatureDescription {
+		t.Errorf("Description = %q, want template skeleton", feature.Description)
+	}
+	if strings.Join(feature.Tags, ",") != "api,orders" {
+		t.Errorf("Tags = %v, want [api orders]", feature.Tags)
+	}
+	if feature.GetTeam() != "orders" || feature.Metadata["sla"] != "99.9" || feature.Metadata["owner"] != "alice" {
+		t.Errorf("Metadata = %v, want explicit team over template and template sla", feature.Metadata)
+	}
+	if len(feature.Relationships) != 1 || feature.Relationships[0].TargetID != epic.ID {
+		t.Errorf("Relationships = %+v, want contained-by %s", feature.Relationships, epic.ID)
+	}
+	if state := feature.DeriveState(); state != fogit.StateOpen {
+		t.Errorf("DeriveState() = %s, want open", state)
+	}
+
+	tpl.Relationships[0].Target = "Missing Epic"
+	tpl.RequiredMetadata = nil
+	if _, err := Create(ctx, repo, CreateOptions{Name: "Other", Template: tpl, SameBranch: true}, cfg, ""); err == nil {
+		t.Error("Create() accepted a template linking to an unknown feature")
+	}
+}
+
+func TestCreateAndUpdate_MetadataSchema(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewFileRepository(t.TempDir())
+	cfg := fogit.DefaultConfig()
+	cfg.MetadataSchema = &fogit.MetadataSchema{
+		Types: map[string]map[string]fogit.MetadataField{
+			"api-endpoint": {
+				"sla":     {Type: fogit.MetadataTypeEnum, Values: []string{"gold", "silver"}, Default: "silver"},
+				"version": {Type: fogit.MetadataTypeInt, Required: true},
+			},
+		},
+	}
+
+	_, err := Create(ctx, repo, CreateOptions{Name: "Orders", Type: "api-endpoint", SameBranch: true}, cfg, "")
+	if err == nil || !strings.Contains(err.Error(), "version is required") {
+		t.Fatalf("Create() error = %v, want missing version", err)
+	}
+
+	// Untyped features are not covered by the api-endpoint fields
+	if _, err := Create(ctx, repo, CreateOptions{Name: "Notes", SameBranch: true}, cfg, ""); err != nil {
+		t.Fatalf("Create() untyped error = %v", err)
+	}
+
+	feature, err := Create(ctx, repo, CreateOptions{
+		Name:       "Orders",
+		Type:       "api-endpoint",
+		Metadata:   map[string]interface{}{"version": "2"},
+		SameBranch: true,
+	}, cfg, "")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if feature.Metadata["version"] != 2 || feature.Metadata["sla"] != "silver" {
+		t.Errorf("Metadata = %v, want version 2 and default sla", feature.Metadata)
+	}
+
+	_, err = Update(ctx, repo, feature, UpdateOptions{
+		Metadata: map[string]interface{}{"sla": "bronze"},
+		Schema:   cfg.MetadataSchema,
+	})
+	if err == nil || !strings.Contains(err.Error(), "must be one of gold, silver") {
+		t.Errorf("Update() error = %v, want enum violation", err)
+	}
+}
@@ -205,11 +205,14 @@ func FindAcrossBranches(ctx context.Context, repo fogit.Repository, gitRepo *git
 		}, nil
 	}
 
+	// Other branches are read through the branch catalogue, if the repository has one
+	defer saveBranchCatalogue(repo)
+
 	// Step 2: Check trunk branch (main/master)
 	trunkBranch, err := gitRepo.GetTrunkBranch()
 	if err == nil {
 		if trunkBranch != currentBranch {
-			feature, branch, err := findFeatureOnBranch(gitRepo, trunkBranch, identifier)
+			feature, branch, err := findFeatureOnBranch(repo, gitRepo, trunkBranch, identifier)
 			if err == nil && feature != nil {
 				return &CrossBranchFindResult{
 					Feature:  feature,
@@ -229,7 +232,7 @@ func FindAcrossBranches(ctx context.Context, repo fogit.Repository, gitRepo *git
 				continue
 			}
 
-			feature, foundBranch, err := findFeatureOnBranch(gitRepo, branch, identifier)
+			feature, foundBranch, err := findFeatureOnBranch(repo, gitRepo, branch, identifier)
 			if err == nil && feature != nil {
 				return &CrossBranchFindResult{
 					Feature:  feature,
@@ -246,7 +249,7 @@ func FindAcrossBranches(ctx context.Context, repo fogit.Repository, gitRepo *git
 	remoteBranches, err := gitRepo.ListRemoteBranches()
 	if err == nil {
 		for _, remoteBranch := range remoteBranches {
-			feature, foundBranch, err := findFeatureOnBranch(gitRepo, remoteBranch, identifier)
+			feature, foundBranch, err := findFeatureOnBranch(repo, gitRepo, remoteBranch, identifier)
 			if err == nil && feature != nil {
 				return &CrossBranchFindResult{
 					Feature:  feature,
@@ -280,37 +283,15 @@ func FindAcrossBranches(ctx context.Context, repo fogit.Repository, gitRepo *git
 
 // findFeatureOnBranch searches for a feature on a specific branch without checkout.
 // Returns the feature, branch name, and any error.
-func findFeatureOnBranch(gitRepo *git.Repository, branch string, identifier string) (*fogit.Feature, string, error) {
-	featuresPath := ".fogit/features"
-
-	// List feature files on the branch
-	files, err := gitRepo.ListFilesOnBranch(branch, featuresPath)
+func findFeatureOnBranch(repo fogit.Repository, gitRepo *git.Repository, branch string, identifier string) (*fogit.Feature, string, error) {
+	files, err := branchFeatureFiles(repo, gitRepo, branch)
 	if err != nil {
 		return nil, "", err
 	}
 
-	// Check each feature file
-	for _, filePath := range files {
-		// Only process YAML files
-		if !isYAMLFile(filePath) {
-			continue
-		}
-
-		// Read the feature file from the branch
-		data, err := gitRepo.ReadFileOnBranch(branch, filePath)
-		if err != nil {
-			continue
-		}
-
-		// Parse the feature
-		feature, err := storage.UnmarshalFeature(data)
-		if err != nil {
-			continue
-		}
-
-		// Check if this is the feature we're looking for
-		if matchesIdentifier(feature, identifier) {
-			return feature, branch, nil
+	for _, file := range files {
+		if matchesIdentifier(file.Feature, identifier) {
+			return file.Feature, branch, nil
 		}
 	}
 
@@ -372,8 +353,13 @@ func ListFeaturesAcrossBranches(ctx context.Context, repo fogit.Repository, gitR
 		}
 	}
 
+	// Branches that still exist; the rest are pruned from the branch catalogue
+	present := make(map[string]bool)
+	listedAll := true
+
 	// Step 1: Get features from current branch
 	currentBranch, _ := gitRepo.GetCurrentBranch()
+	present[currentBranch] = true
 	localFeatures, err := repo.List(ctx, &fogit.Filter{})
 	if err == nil {
 		for _, f := range localFeatures {
@@ -383,8 +369,11 @@ func ListFeaturesAcrossBranches(ctx context.Context, repo fogit.Repository, gitR
 
 	// Step 2: Get features from trunk branch
 	trunkBranch, err := gitRepo.GetTrunkBranch()
+	if err == nil {
+		present[trunkBranch] = true
+	}
 	if err == nil && trunkBranch != currentBranch {
-		trunkFeatures, err := listFeaturesOnBranch(gitRepo, trunkBranch)
+		trunkFeatures, err := listFeaturesOnBranch(repo, gitRepo, trunkBranch)
 		if err == nil {
 			for _, f := range trunkFeatures {
 				addOrUpdateFeature(f, trunkBranch, false)
@@ -396,17 +385,20 @@ func ListFeaturesAcrossBranches(ctx context.Context, repo fogit.Repository, gitR
 	branches, err := gitRepo.ListBranches()
 	if err == nil {
 		for _, branch := range branches {
+			present[branch] = true
 			if branch == currentBranch || branch == trunkBranch {
 				continue
 			}
 
-			branchFeatures, err := listFeaturesOnBranch(gitRepo, branch)
+			branchFeatures, err := listFeaturesOnBranch(repo, gitRepo, branch)
 			if err == nil {
 				for _, f := range branchFeatures {
 					addOrUpdateFeature(f, branch, false)
 				}
 			}
 		}
+	} else {
+		listedAll = false
 	}
 
 	// Step 4: Get features from remote branches
@@ -415,14 +407,22 @@ func ListFeaturesAcrossBranches(ctx context.Context, repo fogit.Repository, gitR
 	remoteBranches, err := gitRepo.ListRemoteBranches()
 	if err == nil {
 		for _, remoteBranch := range remoteBranches {
-			remoteFeatures, err := listFeaturesOnBranch(gitRepo, remoteBranch)
+			present[remoteBranch] = true
+			remoteFeatures, err := listFeaturesOnBranch(repo, gitRepo, remoteBranch)
 			if err == nil {
 				for _, f := range remoteFeatures {
 					addOrUpdateFeature(f, remoteBranch, true)
 				}
 			}
 		}
+	} else {
+		listedAll = false
+	}
+
+	if listedAll {
+		pruneBranchCatalogue(repo, present)
 	}
+	saveBranchCatalogue(repo)
 
 	// Convert map to slice
 	allFeatures := make([]*CrossBranchFeature, 0, len(featureMap))
@@ -441,12 +441,33 @@ type CrossBranchFeature struct {
 }
 
 // listFeaturesOnBranch lists all features on a specific branch
-func listFeaturesOnBranch(gitRepo *git.Repository, branch string) ([]*fogit.Feature, error) {
+func listFeaturesOnBranch(repo fogit.Repository, gitRepo *git.Repository, branch string) ([]*fogit.Feature, error) {
+	files, err := branchFeatureFiles(repo, gitRepo, branch)
+	if err != nil {
+		return nil, err
+	}
+
+	features := make([]*fogit.Feature, 0, len(files))
+	for _, file := range files {
+		features = append(features, file.Feature)
+	}
+	return features, nil
+}
+
+// FeatureFile is a feature read from a Git tree together with its repository-relative path
+type FeatureFile struct {
+	Feature *fogit.Feature
+	Path    string
+}
+
+// ListFeatureFilesAtRef reads all features at a branch, tag or commit without checkout.
+// Files that can't be read or parsed are skipped.
+func ListFeatureFilesAtRef(gitRepo *git.Repository, ref string) ([]FeatureFile, error) {
 	featuresPath := ".fogit/features"
-	var features []*fogit.Feature
+	var result []FeatureFile
 
-	// List feature files on the branch
-	files, err := gitRepo.ListFilesOnBranch(branch, featuresPath)
+	// List feature files at the ref
+	files, err := gitRepo.ListFilesOnBranch(ref, featuresPath)
 	if err != nil {
 		return nil, err
 	}
@@ -457,7 +478,7 @@ func listFeaturesOnBranch(gitRepo *git.Repository, branch string) ([]*fogit.Feat
 			continue
 		}
 
-		data, err := gitRepo.ReadFileOnBranch(branch, filePath)
+		data, err := gitRepo.ReadFileOnBranch(ref, filePath)
 		if err != nil {
 			continue
 		}
@@ -467,8 +488,8 @@ func listFeaturesOnBranch(gitRepo *git.Repository, branch string) ([]*fogit.Feat
 			continue
 		}
 
-		features = append(features, feature)
+		result = append(result, FeatureFile{Feature: feature, Path: filePath})
 	}
 
-	return features, nil
+	return result, nil
 }
@@ -250,3 +250,40 @@ func TestFindForBranch_EqualTimestamps(t *testing.T) {
 		}
 	}
 }
+
+func TestFindAllForBranch_OnlyOpen(t *testing.T) {
+	fogitDir := t.TempDir() + "/.fogit"
+	if err := os.MkdirAll(fogitDir+"/features", 0755); err != nil {
+		t.Fatal(err)
+	}
+	repo := storage.NewFileRepository(fogitDir)
+	ctx := context.Background()
+
+	// An in-progress feature is never picked, not even as the most recent one
+	open := fogit.NewFeature("Open")
+	if v := open.GetCurrentVersion(); v != nil {
+		oldTime := time.Now().UTC().Add(-time.Hour)
+		v.CreatedAt = oldTime
+		v.ModifiedAt = oldTime
+	}
+	inProgress := fogit.NewFeature("In Progress")
+	inProgress.Metadata["branch"] = "feature/wip"
+	if err := inProgress.UpdateState(fogit.StateInProgress); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*fogit.Feature{open, inProgress} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, branch := range []string{"feature/wip", "feature/other"} {
+		found, err := FindAllForBranch(ctx, repo, branch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(found) != 1 || found[0].ID != open.ID {
+			t.Errorf("FindAllForBranch(%s) = %v, want only the open feature", branch, found)
+		}
+	}
+}
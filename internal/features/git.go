@@ -48,6 +48,9 @@ func AutoCommitFeature(feature *fogit.Feature, action string, cfg *fogit.Config)
 
 	// Generate commit message using template
 	commitMsg := generateCommitMessage(cfg.CommitTemplate, feature, action)
+	if cfg.CommitTrailers.IsEnabled() {
+		commitMsg = git.AppendTrailers(commitMsg, FeatureTrailers(cfg.CommitTrailers, feature))
+	}
 
 	// Commit
 	hash, err := gitRepo.Commit(commitMsg, author)
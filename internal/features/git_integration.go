@@ -181,6 +181,9 @@ func (gi *GitIntegration) CommitFeatureChange(feature *fogit.Feature, action str
 
 	// Generate commit message
 	message := gi.formatCommitMessage(feature, action)
+	if gi.config.CommitTrailers.IsEnabled() {
+		message = git.AppendTrailers(message, FeatureTrailers(gi.config.CommitTrailers, feature))
+	}
 
 	// Commit
 	hash, err := gi.gitRepo.Commit(message, author)
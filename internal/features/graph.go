@@ -0,0 +1,185 @@
+package features
+
+import (
+	"sort"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// Graph is the relationship graph between a set of features
+type Graph struct {
+	Nodes      []GraphNode              `json:"nodes"`
+	Edges      []GraphEdge              `json:"edges"`
+	Categories map[string]GraphCategory `json:"categories"`
+}
+
+// GraphNode is a feature in the relationship graph
+type GraphNode struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	State    string `json:"state"`
+	Priority string `json:"priority,omitempty"`
+	Type     string `json:"type,omitempty"`
+}
+
+// GraphEdge is a relationship between two features in the graph
+type GraphEdge struct {
+	ID       string `json:"id"`
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+}
+
+// GraphCategory describes a relationship category used by the graph's edges
+type GraphCategory struct {
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"` // From the category's metadata.color, if set
+}
+
+// GraphOptions restricts which relationships become edges.
+// Empty lists place no restriction.
+type GraphOptions struct {
+	Types      []string // Relationship types to include
+	Categories []string // Relationship categories to include
+}
+
+// includes reports whether a relationship of the given type and category passes the options
+func (opts GraphOptions) includes(relType, category string) bool {
+	if len(opts.Types) > 0 && !containsString(opts.Types, relType) {
+		return false
+	}
+	return len(opts.Categories) == 0 || containsString(opts.Categories, category)
+}
+
+// BuildGraph builds the relationship graph of the given features.
+// Relationships to features outside the set are dropped, and when a relationship
+// and its configured inverse both exist only one edge is kept.
+func BuildGraph(featuresList []*fogit.Feature, cfg *fogit.Config, opts GraphOptions) *Graph {
+	graph := &Graph{
+		Nodes:      make([]GraphNode, 0, len(featuresList)),
+		Edges:      []GraphEdge{},
+		Categories: make(map[string]GraphCategory),
+	}
+
+	for name := range cfg.Relationships.Categories {
+		graph.Categories[name] = graphCategory(cfg, name)
+	}
+
+	// Sort by name so nodes and edges come out in a stable order
+	featuresList = append([]*fogit.Feature(nil), featuresList...)
+	sort.SliceStable(featuresList, func(i, j int) bool {
+		return featuresList[i].Name < featuresList[j].Name
+	})
+
+	present := make(map[string]bool, len(featuresList))
+	for _, f := range featuresList {
+		present[f.ID] = true
+	}
+
+	// Index the included relationships so inverse pairs can be detected
+	type edgeKey struct{ source, target, relType string }
+	existing := make(map[edgeKey]bool)
+	for _, f := range featuresList {
+		for _, rel := range f.Relationships {
+			if opts.includes(string(rel.Type), relationshipCategory(cfg, string(rel.Type))) {
+				existing[edgeKey{f.ID, rel.TargetID, string(rel.Type)}] = true
+			}
+		}
+	}
+
+	for _, f := range featuresList {
+		graph.Nodes = append(graph.Nodes, GraphNode{
+			ID:       f.ID,
+			Name:     f.Name,
+			State:    string(f.DeriveState()),
+			Priority: string(f.GetPriority()),
+			Type:     f.GetType(),
+		})
+
+		for _, rel := range f.Relationships {
+			relType := string(rel.Type)
+			if !present[rel.TargetID] || !existing[edgeKey{f.ID, rel.TargetID, relType}] {
+				continue
+			}
+
+			// Keep the lexically smaller type of an inverse pair
+			if inverse := cfg.Relationships.Types[relType].Inverse; inverse != "" && inverse < relType && existing[edgeKey{rel.TargetID, f.ID, inverse}] {
+				continue
+			}
+
+			category := relationshipCategory(cfg, relType)
+			graph.Edges = append(graph.Edges, GraphEdge{
+				ID:       rel.ID,
+				Source:   f.ID,
+				Target:   rel.TargetID,
+				Type:     relType,
+				Category: category,
+			})
+			if _, ok := graph.Categories[category]; !ok {
+				graph.Categories[category] = graphCategory(cfg, category)
+			}
+		}
+	}
+
+	return graph
+}
+
+// relationshipCategory returns the configured category of a relationship type
+func relationshipCategory(cfg *fogit.Config, relType string) string {
+	if category := cfg.Relationships.Types[relType].Category; category != "" {
+		return category
+	}
+	return cfg.Relationships.Defaults.Category
+}
+
+// Subgraph returns the part of the graph within maxDepth edges of rootID
+// (-1 = unlimited), following edges in either direction
+func (g *Graph) Subgraph(rootID string, maxDepth int) *Graph {
+	neighbors := make(map[string][]string)
+	for _, e := range g.Edges {
+		neighbors[e.Source] = append(neighbors[e.Source], e.Target)
+		neighbors[e.Target] = append(neighbors[e.Target], e.Source)
+	}
+
+	depth := map[string]int{rootID: 0}
+	queue := []string{rootID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if maxDepth >= 0 && depth[id] >= maxDepth {
+			continue
+		}
+		for _, next := range neighbors[id] {
+			if _, seen := depth[next]; !seen {
+				depth[next] = depth[id] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	sub := &Graph{Nodes: []GraphNode{}, Edges: []GraphEdge{}, Categories: g.Categories}
+	for _, n := range g.Nodes {
+		if _, ok := depth[n.ID]; ok {
+			sub.Nodes = append(sub.Nodes, n)
+		}
+	}
+	for _, e := range g.Edges {
+		_, sourceIn := depth[e.Source]
+		_, targetIn := depth[e.Target]
+		if sourceIn && targetIn {
+			sub.Edges = append(sub.Edges, e)
+		}
+	}
+	return sub
+}
+
+// graphCategory describes a relationship category from the config
+func graphCategory(cfg *fogit.Config, name string) GraphCategory {
+	category := cfg.Relationships.Categories[name]
+	result := GraphCategory{Description: category.Description}
+	if color, ok := category.Metadata["color"].(string); ok {
+		result.Color = color
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package features
+
+import (
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestBuildGraph(t *testing.T) {
+	cfg := fogit.DefaultConfig()
+	cfg.Relationships.Categories["structural"] = fogit.RelationshipCategory{
+		Description: "Hierarchy",
+		Metadata:    map[string]interface{}{"color": "#123456"},
+	}
+
+	parent := fogit.NewFeature("Auth")
+	child := fogit.NewFeature("Login")
+	other := fogit.NewFeature("Session")
+
+	mustAdd := func(f *fogit.Feature, relType string, target *fogit.Feature) {
+		t.Helper()
+		if err := f.AddRelationship(fogit.NewRelationship(fogit.RelationshipType(relType), target.ID, target.Name)); err != nil {
+			t.Fatalf("AddRelationship() error = %v", err)
+		}
+	}
+	mustAdd(child, "contained-by", parent)
+	mustAdd(parent, "contains", child) // Inverse of the above, collapsed into one edge
+	mustAdd(child, "references", other)
+	mustAdd(child, "references", fogit.NewFeature("Elsewhere")) // Target outside the graph
+
+	graph := BuildGraph([]*fogit.Feature{parent, child, other}, cfg, GraphOptions{})
+
+	if len(graph.Nodes) != 3 || graph.Nodes[0].Name != "Auth" || graph.Nodes[2].State != "open" {
+		t.Errorf("Nodes = %+v", graph.Nodes)
+	}
+	if len(graph.Edges) != 2 {
+		t.Fatalf("Edges = %+v, want 2 edges", graph.Edges)
+	}
+
+	want := map[string]string{"contained-by": "structural", "references": "informational"}
+	for _, e := range graph.Edges {
+		if e.Source != child.ID {
+			t.Errorf("edge %s source = %s, want %s", e.Type, e.Source, child.ID)
+		}
+		if category, ok := want[e.Type]; !ok || e.Category != category {
+			t.Errorf("edge %s category = %s", e.Type, e.Category)
+		}
+	}
+
+	if got := graph.Categories["structural"].Color; got != "#123456" {
+		t.Errorf("structural color = %q, want #123456", got)
+	}
+}
+
+func TestBuildGraph_OptionsAndSubgraph(t *testing.T) {
+	cfg := fogit.DefaultConfig()
+
+	epic := fogit.NewFeature("Epic")
+	a := fogit.NewFeature("A")
+	b := fogit.NewFeature("B")
+	c := fogit.NewFeature("C")
+	for _, pair := range []struct {
+		from    *fogit.Feature
+		relType string
+		to      *fogit.Feature
+	}{
+		{epic, "contains", a},
+		{a, "contained-by", epic},
+		{a, "depends-on", b},
+		{b, "references", c},
+	} {
+		if err := pair.from.AddRelationship(fogit.NewRelationship(fogit.RelationshipType(pair.relType), pair.to.ID, pair.to.Name)); err != nil {
+			t.Fatalf("AddRelationship() error = %v", err)
+		}
+	}
+	all := []*fogit.Feature{epic, a, b, c}
+
+	// Selecting the type that is normally collapsed still yields its edges
+	graph := BuildGraph(all, cfg, GraphOptions{Types: []string{"contains"}})
+	if len(graph.Edges) != 1 || graph.Edges[0].Type != "contains" || graph.Edges[0].Source != epic.ID {
+		t.Errorf("contains edges = %+v", graph.Edges)
+	}
+
+	graph = BuildGraph(all, cfg, GraphOptions{Categories: []string{"informational"}})
+	if len(graph.Edges) != 1 || graph.Edges[0].Type != "references" {
+		t.Errorf("informational edges = %+v", graph.Edges)
+	}
+
+	full := BuildGraph(all, cfg, GraphOptions{})
+	tests := []struct {
+		depth int
+		want  int
+	}{
+		{0, 1},
+		{1, 3}, // A, Epic and B
+		{2, 4},
+		{-1, 4},
+	}
+	for _, tt := range tests {
+		sub := full.Subgraph(a.ID, tt.depth)
+		if len(sub.Nodes) != tt.want {
+			t.Errorf("Subgraph(depth=%d) has %d nodes, want %d", tt.depth, len(sub.Nodes), tt.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package features
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// FeatureHistory is every commit that touched a feature, newest first
+type FeatureHistory struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Paths   []string        `json:"paths"` // Feature file paths seen in history (renames)
+	Files   []string        `json:"files"` // Linked files that were followed
+	Commits []*HistoryEntry `json:"commits"`
+}
+
+// HistoryEntry is a commit in a feature's history and why it matched
+type HistoryEntry struct {
+	Hash        string    `json:"hash"`
+	Author      string    `json:"author"`
+	Email       string    `json:"email"`
+	Date        time.Time `json:"date"`
+	Subject     string    `json:"subject"`
+	Merge       bool      `json:"merge,omitempty"`
+	FeatureFile string    `json:"feature_file,omitempty"` // Feature file changed by the commit
+	RenamedFrom string    `json:"renamed_from,omitempty"` // Previous feature file path when renamed
+	Deleted     bool      `json:"deleted,omitempty"`      // Feature file removed
+	Files       []string  `json:"files,omitempty"`        // Linked files changed by the commit
+	Trailer     bool      `json:"trailer,omitempty"`      // Commit carries the feature trailer
+}
+
+// HistoryOptions configures history reconstruction
+type HistoryOptions struct {
+	TrailerKey   string // Feature trailer key; empty skips trailer matching
+	IncludeFiles bool   // Follow the feature's linked files
+	Limit        int    // Maximum number of commits (0 = no limit)
+}
+
+// BuildHistory reconstructs a feature's history from every ref in the repository.
+// The feature is followed by its ID, so renamed feature files, feature files on
+// other branches and merges are all found. With IncludeFiles, commits touching
+// any file linked to the feature (now or in an earlier revision) are included.
+//
+// Only feature files and linked files are compared between commits. Linked files
+// of earlier revisions are collected by a first walk over the feature files, so
+// the second walk knows every path to follow.
+func BuildHistory(ctx context.Context, gitRepo *git.Repository, feature *fogit.Feature, opts HistoryOptions) (*FeatureHistory, error) {
+	history := &FeatureHistory{ID: feature.ID, Name: feature.Name}
+
+	linked := make(map[string]bool)
+	for _, f := range feature.Files {
+		linked[f] = true
+	}
+	seenPaths := make(map[string]bool)
+
+	// Feature files are identified by content; blobs are immutable so results are cached
+	idByBlob := make(map[string]string)
+	featureID := func(blob string) string {
+		if id, ok := idByBlob[blob]; ok {
+			return id
+		}
+		id := ""
+		if data, err := gitRepo.ReadBlob(blob); err == nil {
+			if f, err := storage.UnmarshalFeature(data); err == nil {
+				id = f.ID
+				if id == feature.ID {
+					for _, file := range f.Files {
+						linked[file] = true
+					}
+				}
+			}
+		}
+		idByBlob[blob] = id
+		return id
+	}
+
+	paths := []string{featuresTreePath + "/"}
+	if opts.IncludeFiles {
+		err := gitRepo.WalkHistory(ctx, paths, func(c *git.CommitChanges) error {
+			for _, change := range c.Changes {
+				if IsFeatureFilePath(change.To) {
+					featureID(change.ToBlob)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for f := range linked {
+			paths = append(paths, f)
+		}
+		sort.Strings(paths[1:])
+	}
+
+	err := gitRepo.WalkHistory(ctx, paths, func(c *git.CommitChanges) error {
+		entry := &HistoryEntry{
+			Hash:    c.Hash,
+			Author:  c.Author,
+			Email:   c.Email,
+			Date:    c.Date,
+			Subject: strings.SplitN(c.Message, "\n", 2)[0],
+			Merge:   len(c.Parents) > 1,
+		}
+		if opts.TrailerKey != "" {
+			entry.Trailer = git.HasTrailer(c.Message, opts.TrailerKey, feature.ID)
+		}
+
+		removedFrom := ""
+		for _, change := range c.Changes {
+			if !IsFeatureFilePath(change.From) && !IsFeatureFilePath(change.To) {
+				path := change.To
+				if path == "" {
+					path = change.From
+				}
+				if linked[path] {
+					entry.Files = append(entry.Files, path)
+				}
+				continue
+			}
+			if change.To != "" && featureID(change.ToBlob) == feature.ID {
+				entry.FeatureFile = change.To
+			}
+			if change.From != "" && change.To == "" && featureID(change.FromBlob) == feature.ID {
+				removedFrom = change.From
+			}
+		}
+
+		switch {
+		case removedFrom != "" && entry.FeatureFile != "":
+			entry.RenamedFrom = removedFrom
+		case removedFrom != "":
+			entry.FeatureFile = removedFrom
+			entry.Deleted = true
+		}
+		for _, p := range []string{entry.FeatureFile, entry.RenamedFrom} {
+			if p != "" && !seenPaths[p] {
+				seenPaths[p] = true
+				history.Paths = append(history.Paths, p)
+			}
+		}
+
+		if entry.FeatureFile == "" && !entry.Trailer && len(entry.Files) == 0 {
+			return nil
+		}
+		sort.Strings(entry.Files)
+		history.Commits = append(history.Commits, entry)
+		if opts.Limit > 0 && len(history.Commits) >= opts.Limit {
+			return git.ErrStopWalk
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.IncludeFiles {
+		for f := range linked {
+			history.Files = append(history.Files, f)
+		}
+		sort.Strings(history.Files)
+	}
+	return history, nil
+}
+
+// IsFeatureFilePath reports whether a repository path is a feature file
+func IsFeatureFilePath(path string) bool {
+	return strings.HasPrefix(path, ".fogit/features/") && isYAMLFile(path)
+}
@@ -0,0 +1,119 @@
+package features
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// gitInRepo runs a git command in the repository and fails the test on error
+func gitInRepo(t *testing.T, repoPath string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestBuildHistory(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/auth")
+
+	featureYAML := `id: auth-123
+name: Auth
+files:
+  - src/auth.go
+versions:
+  "1":
+    created_at: 2025-01-01T00:00:00Z
+    modified_at: 2025-01-01T00:00:00Z
+`
+	createTestCommitInRepo(t, repoPath, ".fogit/features/auth.yml", featureYAML, "Add auth feature")
+	createTestCommitInRepo(t, repoPath, "src/auth.go", "package auth", "Implement auth")
+	createTestCommitInRepo(t, repoPath, "src/other.go", "package other", "Unrelated change")
+
+	// Rename the feature file; the linked file moves with it
+	gitInRepo(t, repoPath, "mv", ".fogit/features/auth.yml", ".fogit/features/authentication.yml")
+	gitInRepo(t, repoPath, "commit", "-m", "Rename auth feature")
+
+	createTestCommitInRepo(t, repoPath, "docs/auth.md", "# Auth", "Document auth\n\nFogit-Feature: auth-123")
+
+	gitInRepo(t, repoPath, "checkout", trunk)
+	createTestCommitInRepo(t, repoPath, "CHANGES.md", "changes", "Trunk change")
+	gitInRepo(t, repoPath, "merge", "--no-ff", "-m", "Merge feature/auth", "feature/auth")
+
+	feature := &fogit.Feature{ID: "auth-123", Name: "Auth"}
+
+	subjects := func(h *FeatureHistory) string {
+		var s []string
+		for _, c := range h.Commits {
+			s = append(s, c.Subject)
+		}
+		return strings.Join(s, ",")
+	}
+
+	t.Run("follows renames, linked files and trailers", func(t *testing.T) {
+		history, err := BuildHistory(context.Background(), gitRepo, feature, HistoryOptions{
+			TrailerKey:   fogit.DefaultFeatureTrailer,
+			IncludeFiles: true,
+		})
+		if err != nil {
+			t.Fatalf("BuildHistory() error = %v", err)
+		}
+
+		want := "Merge feature/auth,Document auth,Rename auth feature,Implement auth,Add auth feature"
+		if got := subjects(history); got != want {
+			t.Errorf("commits = %s, want %s", got, want)
+		}
+
+		bySubject := make(map[string]*HistoryEntry)
+		for _, c := range history.Commits {
+			bySubject[c.Subject] = c
+		}
+		if c := bySubject["Rename auth feature"]; c.RenamedFrom != ".fogit/features/auth.yml" || c.FeatureFile != ".fogit/features/authentication.yml" {
+			t.Errorf("rename = %s -> %s", c.RenamedFrom, c.FeatureFile)
+		}
+		if c := bySubject["Implement auth"]; len(c.Files) != 1 || c.Files[0] != "src/auth.go" {
+			t.Errorf("linked files = %v, want [src/auth.go]", c.Files)
+		}
+		if c := bySubject["Document auth"]; !c.Trailer {
+			t.Error("expected trailer match")
+		}
+		if c := bySubject["Merge feature/auth"]; !c.Merge {
+			t.Error("expected merge commit")
+		}
+		if len(history.Paths) != 2 {
+			t.Errorf("paths = %v, want both feature file names", history.Paths)
+		}
+	})
+
+	t.Run("without files or trailers", func(t *testing.T) {
+		history, err := BuildHistory(context.Background(), gitRepo, feature, HistoryOptions{})
+		if err != nil {
+			t.Fatalf("BuildHistory() error = %v", err)
+		}
+		want := "Merge feature/auth,Rename auth feature,Add auth feature"
+		if got := subjects(history); got != want {
+			t.Errorf("commits = %s, want %s", got, want)
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		history, err := BuildHistory(context.Background(), gitRepo, feature, HistoryOptions{Limit: 1})
+		if err != nil {
+			t.Fatalf("BuildHistory() error = %v", err)
+		}
+		if got := subjects(history); got != "Merge feature/auth" {
+			t.Errorf("commits = %s, want only the merge", got)
+		}
+	})
+}
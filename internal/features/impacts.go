@@ -84,38 +84,52 @@ func GetIncludedCategories(cfg *fogit.Config, opts ImpactOptions) []string {
 	return filtered
 }
 
+// impactDependent is a feature with a relationship pointing at another feature
+type impactDependent struct {
+	feature           *fogit.Feature
+	relType           string
+	versionConstraint *fogit.VersionConstraint
+}
+
 // AnalyzeImpacts performs a BFS traversal to find all features impacted by changes to the given feature.
 // It follows reverse relationships (features that depend on the target) through the specified categories.
+// Repositories with a reverse-relationship index are queried per visited feature instead of listing everything.
 func AnalyzeImpacts(ctx context.Context, feature *fogit.Feature, repo fogit.Repository, cfg *fogit.Config, categories []string, maxDepth int) (*ImpactResult, error) {
-	// Get all features for lookup
-	allFeatures, err := repo.List(ctx, &fogit.Filter{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list features: %w", err)
+	if _, ok := repo.(fogit.ReverseIndexer); !ok {
+		// Get all features for lookup
+		allFeatures, err := repo.List(ctx, &fogit.Filter{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list features: %w", err)
+		}
+		return AnalyzeImpactsWithFeatures(ctx, feature, allFeatures, cfg, categories, maxDepth)
+	}
+
+	dependentsOf := func(target *fogit.Feature) ([]impactDependent, error) {
+		sources, err := incomingSources(ctx, repo, target.ID)
+		if err != nil {
+			return nil, err
+		}
+		var dependents []impactDependent
+		for _, source := range sources {
+			for _, rel := range source.Relationships {
+				if rel.TargetID != target.ID || !containsString(categories, rel.GetCategory(cfg)) {
+					continue
+				}
+				dependents = append(dependents, impactDependent{source, string(rel.Type), rel.VersionConstraint})
+			}
+		}
+		return dependents, nil
 	}
 
-	return AnalyzeImpactsWithFeatures(ctx, feature, allFeatures, cfg, categories, maxDepth)
+	return analyzeImpacts(feature, categories, maxDepth, dependentsOf)
 }
 
 // AnalyzeImpactsWithFeatures performs impact analysis using a pre-loaded list of features.
 // This is useful for cross-branch analysis where features come from multiple branches.
 func AnalyzeImpactsWithFeatures(ctx context.Context, feature *fogit.Feature, allFeatures []*fogit.Feature, cfg *fogit.Config, categories []string, maxDepth int) (*ImpactResult, error) {
-	result := &ImpactResult{
-		Feature:            feature.Name,
-		CategoriesIncluded: categories,
-	}
-
-	featureMap := make(map[string]*fogit.Feature)
-	for _, f := range allFeatures {
-		featureMap[f.ID] = f
-	}
-
 	// Build reverse relationship map (who depends on what)
 	// Key: target ID, Value: list of features that have relationships TO this target
-	reverseMap := make(map[string][]struct {
-		feature           *fogit.Feature
-		relType           string
-		versionConstraint *fogit.VersionConstraint
-	})
+	reverseMap := make(map[string][]impactDependent)
 
 	for _, f := range allFeatures {
 		for _, rel := range f.Relationships {
@@ -125,14 +139,25 @@ func AnalyzeImpactsWithFeatures(ctx context.Context, feature *fogit.Feature, all
 				continue
 			}
 
-			reverseMap[rel.TargetID] = append(reverseMap[rel.TargetID], struct {
-				feature           *fogit.Feature
-				relType           string
-				versionConstraint *fogit.VersionConstraint
-			}{f, string(rel.Type), rel.VersionConstraint})
+			reverseMap[rel.TargetID] = append(reverseMap[rel.TargetID], impactDependent{f, string(rel.Type), rel.VersionConstraint})
 		}
 	}
 
+	dependentsOf := func(target *fogit.Feature) ([]impactDependent, error) {
+		return reverseMap[target.ID], nil
+	}
+
+	return analyzeImpacts(feature, categories, maxDepth, dependentsOf)
+}
+
+// analyzeImpacts runs the BFS over reverse relationships, asking dependentsOf
+// for the features that depend on each visited feature
+func analyzeImpacts(feature *fogit.Feature, categories []string, maxDepth int, dependentsOf func(*fogit.Feature) ([]impactDependent, error)) (*ImpactResult, error) {
+	result := &ImpactResult{
+		Feature:            feature.Name,
+		CategoriesIncluded: categories,
+	}
+
 	// BFS to find all impacted features
 	visited := make(map[string]bool)
 	visited[feature.ID] = true
@@ -156,7 +181,11 @@ func AnalyzeImpactsWithFeatures(ctx context.Context, feature *fogit.Feature, all
 		}
 
 		// Find features that depend on this one
-		for _, dep := range reverseMap[current.feature.ID] {
+		dependents, err := dependentsOf(current.feature)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find dependents of %s: %w", current.feature.Name, err)
+		}
+		for _, dep := range dependents {
 			if visited[dep.feature.ID] {
 				continue
 			}
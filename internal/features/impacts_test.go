@@ -0,0 +1,60 @@
+package features
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestAnalyzeImpacts_IndexedMatchesFullScan(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewFileRepository(t.TempDir())
+	cfg := fogit.DefaultConfig()
+
+	// api <- service <- ui, plus an unrelated informational link
+	api := fogit.NewFeature("API")
+	service := fogit.NewFeature("Service")
+	service.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", api.ID, api.Name)}
+	ui := fogit.NewFeature("UI")
+	ui.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", service.ID, service.Name)}
+	docs := fogit.NewFeature("Docs")
+	docs.Relationships = []fogit.Relationship{fogit.NewRelationship("related-to", api.ID, api.Name)}
+	for _, f := range []*fogit.Feature{api, service, ui, docs} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	categories := []string{"structural"}
+	indexed, err := AnalyzeImpacts(ctx, api, repo, cfg, categories, 0)
+	if err != nil {
+		t.Fatalf("AnalyzeImpacts() error = %v", err)
+	}
+
+	all, err := repo.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	scanned, err := AnalyzeImpactsWithFeatures(ctx, api, all, cfg, categories, 0)
+	if err != nil {
+		t.Fatalf("AnalyzeImpactsWithFeatures() error = %v", err)
+	}
+
+	if indexed.TotalAffected != 2 {
+		t.Errorf("TotalAffected = %d, want 2", indexed.TotalAffected)
+	}
+	if !reflect.DeepEqual(indexed, scanned) {
+		t.Errorf("indexed result %+v differs from full scan %+v", indexed, scanned)
+	}
+
+	limited, err := AnalyzeImpacts(ctx, api, repo, cfg, categories, 1)
+	if err != nil {
+		t.Fatalf("AnalyzeImpacts() error = %v", err)
+	}
+	if limited.TotalAffected != 1 || limited.ImpactedFeatures[0].ID != service.ID {
+		t.Errorf("depth 1 impacts = %+v, want only %s", limited.ImpactedFeatures, service.Name)
+	}
+}
@@ -29,19 +29,22 @@ type MergeOptions struct {
 	Continue    bool   // Continue after conflict resolution
 	Abort       bool   // Abort the current merge
 	FogitDir    string // Path to .fogit directory (required for state management)
+	NoRestack   bool   // Leave branches stacked on the merged branch as they are
 }
 
 // MergeResult contains the result of a Merge operation
 type MergeResult struct {
 	ClosedFeatures   []*fogit.Feature
-	Branch           string // Original feature branch
-	BaseBranch       string // Target branch merged into
-	IsMainBranch     bool   // Was already on main (trunk-based)
-	NoDelete         bool   // Keep branch flag
-	BranchDeleted    bool   // Whether branch was deleted
-	MergePerformed   bool   // Whether Git merge was performed
-	ConflictDetected bool   // Whether merge had conflicts (needs resolution)
-	Aborted          bool   // Whether merge was aborted
+	Branch           string            // Original feature branch
+	BaseBranch       string            // Target branch merged into
+	IsMainBranch     bool              // Was already on main (trunk-based)
+	NoDelete         bool              // Keep branch flag
+	BranchDeleted    bool              // Whether branch was deleted
+	MergePerformed   bool              // Whether Git merge was performed
+	ConflictDetected bool              // Whether merge had conflicts (needs resolution)
+	Aborted          bool              // Whether merge was aborted
+	Restacked        []RestackedBranch // Branches stacked on the merged branch that were rebased onto the base branch
+	RestackErr       error             // Restacking failed; the merge itself succeeded
 }
 
 // Merge closes features and merges branch (in branch-per-feature mode)
@@ -164,8 +167,12 @@ func Merge(ctx context.Context, repo fogit.Repository, gitRepo *git.Repository,
 				BaseBranch:    opts.BaseBranch,
 				FeatureIDs:    featureIDs,
 				NoDelete:      opts.NoDelete,
+				NoRestack:     opts.NoRestack,
 				Squash:        opts.Squash,
 			}
+			if files, err := gitRepo.ConflictedFiles(); err == nil {
+				state.ConflictFiles = files
+			}
 
 			if err := SaveMergeState(opts.FogitDir, state); err != nil {
 				// Non-fatal, but log it
@@ -185,17 +192,31 @@ func Merge(ctx context.Context, repo fogit.Repository, gitRepo *git.Repository,
 	}
 	result.MergePerformed = true
 
-	// Delete feature branch if requested
-	if !opts.NoDelete {
-		if err := gitRepo.DeleteBranch(featureBranch); err != nil {
-			// Non-fatal: branch deletion failure doesn't fail the merge
-			// Just don't set BranchDeleted
-		} else {
+	finishMerge(ctx, gitRepo, featureBranch, opts.BaseBranch, opts.NoDelete, opts.NoRestack, result)
+
+	return result, nil
+}
+
+// finishMerge deletes the merged feature branch if requested and restacks the
+// branches stacked on it onto the base branch. Failures are non-fatal: the merge is done.
+func finishMerge(ctx context.Context, gitRepo *git.Repository, featureBranch, baseBranch string, noDelete, noRestack bool, result *MergeResult) {
+	// Remember where the branch was; stacked branches are rebased from there
+	tip, _, tipErr := gitRepo.ResolveCommit(featureBranch)
+
+	if !noDelete {
+		if err := gitRepo.DeleteBranch(featureBranch); err == nil {
 			result.BranchDeleted = true
 		}
 	}
 
-	return result, nil
+	if noRestack || tipErr != nil {
+		return
+	}
+	result.Restacked, result.RestackErr = Restack(ctx, gitRepo, RestackOptions{
+		Parent:     featureBranch,
+		BaseBranch: baseBranch,
+		ParentTips: map[string]string{featureBranch: tip},
+	})
 }
 
 // findFeaturesToClose finds features that should be closed
@@ -216,28 +237,18 @@ func findFeaturesToClose(ctx context.Context, repo fogit.Repository, featureName
 		}
 		featuresToClose = append(featuresToClose, result.Feature)
 	} else {
-		// Find all non-closed features (both open and in-progress) on current branch
-		// First try open features
-		openFilter := &fogit.Filter{
-			State: fogit.StateOpen,
-		}
-		openFeatures, err := repo.List(ctx, openFilter)
+		// Find all non-closed features (open, in-progress and custom workflow states) on current branch
+		listed, err := repo.List(ctx, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list features: %w", err)
 		}
-
-		// Then try in-progress features
-		inProgressFilter := &fogit.Filter{
-			State: fogit.StateInProgress,
-		}
-		inProgressFeatures, err := repo.List(ctx, inProgressFilter)
-		if err != nil {
-			return nil, fmt.Errorf("failed to list features: %w", err)
+		var allFeatures []*fogit.Feature
+		for _, feature := range listed {
+			if !feature.IsClosed() {
+				allFeatures = append(allFeatures, feature)
+			}
 		}
 
-		// Combine all non-closed features
-		allFeatures := append(openFeatures, inProgressFeatures...)
-
 		// Filter features on current branch or use most recent
 		for _, feature := range allFeatures {
 			if branchMeta, ok := feature.Metadata["branch"].(string); ok {
@@ -265,6 +276,7 @@ func closeFeature(ctx context.Context, repo fogit.Repository, feature *fogit.Fea
 	if currentVersion := feature.GetCurrentVersion(); currentVersion != nil {
 		currentVersion.ClosedAt = &closeTime
 		currentVersion.ModifiedAt = closeTime
+		currentVersion.State = "" // Merged features are closed, whatever workflow state they were in
 	}
 
 	// Save feature
@@ -396,19 +408,12 @@ func handleMergeContinue(ctx context.Context, repo fogit.Repository, gitRepo *gi
 		}
 	}
 
-	// Delete feature branch if requested
-	if !state.NoDelete {
-		if err := gitRepo.DeleteBranch(state.FeatureBranch); err != nil {
-			// Non-fatal
-		} else {
-			result.BranchDeleted = true
-		}
-	}
-
 	// Clear merge state
 	if err := ClearMergeState(opts.FogitDir); err != nil {
 		return nil, fmt.Errorf("failed to clear merge state: %w", err)
 	}
 
+	finishMerge(ctx, gitRepo, state.FeatureBranch, state.BaseBranch, state.NoDelete, state.NoRestack, result)
+
 	return result, nil
 }
@@ -0,0 +1,363 @@
+package features
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// FieldConflict is a field changed in different ways on both sides of a merge
+type FieldConflict struct {
+	Field  string
+	Base   string
+	Ours   string
+	Theirs string
+}
+
+// MergeFeatureFiles performs a semantic three-way merge of feature YAML files,
+// as a Git merge driver does with base (%O), ours (%A) and theirs (%B).
+// base may be empty when both sides added the file.
+//
+// Tags, files, relationships (by ID), version maps and version authors are merged
+// as sets, timestamps take the latest value, and other fields are merged
+// three-way. Only scalar fields changed differently on both sides conflict;
+// those are written with Git-style conflict markers and returned.
+func MergeFeatureFiles(base, ours, theirs []byte, oursLabel, theirsLabel string) ([]byte, []FieldConflict, error) {
+	baseFeature, oursFeature, theirsFeature, err := parseMergeSides(base, ours, theirs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged, conflicts := MergeFeatures(baseFeature, oursFeature, theirsFeature, false)
+	data, err := storage.MarshalFeature(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(conflicts) == 0 {
+		return data, nil, nil
+	}
+
+	// Render conflicts by diffing the ours- and theirs-resolved documents
+	alternative, _ := MergeFeatures(baseFeature, oursFeature, theirsFeature, true)
+	altData, err := storage.MarshalFeature(alternative)
+	if err != nil {
+		return nil, nil, err
+	}
+	return []byte(withConflictMarkers(string(data), string(altData), oursLabel, theirsLabel)), conflicts, nil
+}
+
+// parseMergeSides parses the three sides of a feature file merge; base is nil when empty
+func parseMergeSides(base, ours, theirs []byte) (*fogit.Feature, *fogit.Feature, *fogit.Feature, error) {
+	var baseFeature *fogit.Feature
+	if len(strings.TrimSpace(string(base))) > 0 {
+		f, err := storage.UnmarshalFeature(base)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("base: %w", err)
+		}
+		baseFeature = f
+	}
+	oursFeature, err := storage.UnmarshalFeature(ours)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("ours: %w", err)
+	}
+	theirsFeature, err := storage.UnmarshalFeature(theirs)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("theirs: %w", err)
+	}
+	if oursFeature.ID != theirsFeature.ID {
+		return nil, nil, nil, fmt.Errorf("feature IDs differ (%s, %s)", oursFeature.ID, theirsFeature.ID)
+	}
+	return baseFeature, oursFeature, theirsFeature, nil
+}
+
+// MergeFeatures merges two descendants of base (nil if the feature is new on both sides).
+// Conflicting fields take ours, or theirs when preferTheirs is set.
+func MergeFeatures(base, ours, theirs *fogit.Feature, preferTheirs bool) (*fogit.Feature, []FieldConflict) {
+	if base == nil {
+		base = &fogit.Feature{}
+	}
+	m := &featureMerger{preferTheirs: preferTheirs}
+
+	result := &fogit.Feature{
+		ID:          ours.ID,
+		Name:        m.scalar("name", base.Name, ours.Name, theirs.Name),
+		Description: m.scalar("description", base.Description, ours.Description, theirs.Description),
+		Tags:        mergeStringSets(base.Tags, ours.Tags, theirs.Tags),
+		Files:       mergeStringSets(base.Files, ours.Files, theirs.Files),
+	}
+	result.Relationships = m.relationships(base.Relationships, ours.Relationships, theirs.Relationships)
+	result.Versions = m.versions(base.Versions, ours.Versions, theirs.Versions)
+	result.Metadata = m.metadata(base.Metadata, ours.Metadata, theirs.Metadata)
+
+	sort.Slice(m.conflicts, func(i, j int) bool { return m.conflicts[i].Field < m.conflicts[j].Field })
+	return result, m.conflicts
+}
+
+type featureMerger struct {
+	preferTheirs bool
+	conflicts    []FieldConflict
+}
+
+// scalar merges a string field three-way, recording a conflict when both sides changed it differently
+func (m *featureMerger) scalar(field, base, ours, theirs string) string {
+	switch {
+	case ours == theirs, theirs == base:
+		return ours
+	case ours == base:
+		return theirs
+	}
+	m.conflicts = append(m.conflicts, FieldConflict{Field: field, Base: base, Ours: ours, Theirs: theirs})
+	if m.preferTheirs {
+		return theirs
+	}
+	return ours
+}
+
+func (m *featureMerger) metadata(base, ours, theirs map[string]interface{}) map[string]interface{} {
+	keys := make(map[string]bool)
+	for _, md := range []map[string]interface{}{base, ours, theirs} {
+		for k := range md {
+			keys[k] = true
+		}
+	}
+
+	result := make(map[string]interface{})
+	for k := range keys {
+		b, inBase := base[k]
+		o, inOurs := ours[k]
+		t, inTheirs := theirs[k]
+		oursSame := inOurs == inBase && reflect.DeepEqual(o, b)
+		theirsSame := inTheirs == inBase && reflect.DeepEqual(t, b)
+
+		var value interface{}
+		var keep bool
+		switch {
+		case inOurs == inTheirs && reflect.DeepEqual(o, t), theirsSame:
+			value, keep = o, inOurs
+		case oursSame:
+			value, keep = t, inTheirs
+		default:
+			m.conflicts = append(m.conflicts, FieldConflict{
+				Field:  "metadata." + k,
+				Base:   formatFieldValue(b),
+				Ours:   formatFieldValue(o),
+				Theirs: formatFieldValue(t),
+			})
+			value, keep = o, inOurs
+			if m.preferTheirs {
+				value, keep = t, inTheirs
+			}
+		}
+		if keep {
+			result[k] = value
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// relationships merges by relationship ID: additions from both sides are kept,
+// removals on either side win, and a relationship edited on both sides takes the preferred side
+func (m *featureMerger) relationships(base, ours, theirs []fogit.Relationship) []fogit.Relationship {
+	index := func(rels []fogit.Relationship) map[string]fogit.Relationship {
+		byID := make(map[string]fogit.Relationship, len(rels))
+		for _, r := range rels {
+			byID[relationshipKey(r)] = r
+		}
+		return byID
+	}
+	baseByID, oursByID, theirsByID := index(base), index(ours), index(theirs)
+
+	var result []fogit.Relationship
+	add := func(r fogit.Relationship) {
+		key := relationshipKey(r)
+		_, inBase := baseByID[key]
+		o, inOurs := oursByID[key]
+		t, inTheirs := theirsByID[key]
+		if inBase && (!inOurs || !inTheirs) {
+			return // Removed on one side
+		}
+		switch {
+		case !inTheirs:
+			result = append(result, o)
+		case !inOurs:
+			result = append(result, t)
+		case reflect.DeepEqual(o, baseByID[key]) || m.preferTheirs && !reflect.DeepEqual(t, baseByID[key]):
+			result = append(result, t)
+		default:
+			result = append(result, o)
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, rels := range [][]fogit.Relationship{ours, theirs} {
+		for _, r := range rels {
+			if key := relationshipKey(r); !seen[key] {
+				seen[key] = true
+				add(r)
+			}
+		}
+	}
+	return result
+}
+
+// relationshipKey identifies a relationship; legacy relationships without an ID use type and target
+func relationshipKey(r fogit.Relationship) string {
+	if r.ID != "" {
+		return r.ID
+	}
+	return string(r.Type) + ":" + r.TargetID
+}
+
+// versions unions the version maps, merging versions present on both sides
+func (m *featureMerger) versions(base, ours, theirs map[string]*fogit.FeatureVersion) map[string]*fogit.FeatureVersion {
+	result := make(map[string]*fogit.FeatureVersion)
+	for key, v := range ours {
+		result[key] = v
+	}
+	for key, t := range theirs {
+		o, ok := result[key]
+		if !ok {
+			result[key] = t
+			continue
+		}
+		b := base[key]
+		if b == nil {
+			b = &fogit.FeatureVersion{}
+		}
+		result[key] = m.version("versions."+key, b, o, t)
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func (m *featureMerger) version(field string, base, ours, theirs *fogit.FeatureVersion) *fogit.FeatureVersion {
+	v := &fogit.FeatureVersion{
+		CreatedAt:    ours.CreatedAt,
+		ModifiedAt:   ours.ModifiedAt,
+		ClosedAt:     ours.ClosedAt,
+		Branch:       m.scalar(field+".branch", base.Branch, ours.Branch, theirs.Branch),
+		ParentBranch: m.scalar(field+".parent_branch", base.ParentBranch, ours.ParentBranch, theirs.ParentBranch),
+		Authors:      mergeStringSets(nil, ours.Authors, theirs.Authors),
+		Notes:        m.scalar(field+".notes", base.Notes, ours.Notes, theirs.Notes),
+		State:        m.scalar(field+".state", base.State, ours.State, theirs.State),
+	}
+	if !theirs.CreatedAt.IsZero() && (v.CreatedAt.IsZero() || theirs.CreatedAt.Before(v.CreatedAt)) {
+		v.CreatedAt = theirs.CreatedAt
+	}
+	if theirs.ModifiedAt.After(v.ModifiedAt) {
+		v.ModifiedAt = theirs.ModifiedAt
+	}
+
+	// Closing wins unless a side reopened a version that was closed at the base
+	switch {
+	case timePtrEqual(ours.ClosedAt, theirs.ClosedAt), timePtrEqual(theirs.ClosedAt, base.ClosedAt):
+	case timePtrEqual(ours.ClosedAt, base.ClosedAt):
+		v.ClosedAt = theirs.ClosedAt
+	case ours.ClosedAt == nil || theirs.ClosedAt != nil && theirs.ClosedAt.After(*ours.ClosedAt):
+		v.ClosedAt = theirs.ClosedAt
+	}
+	return v
+}
+
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// mergeStringSets merges two descendants of a string set: items added on
+// either side are kept, items removed on either side are dropped. Order follows
+// ours, then theirs' additions.
+func mergeStringSets(base, ours, theirs []string) []string {
+	inBase := make(map[string]bool, len(base))
+	for _, s := range base {
+		inBase[s] = true
+	}
+	inOurs := make(map[string]bool, len(ours))
+	for _, s := range ours {
+		inOurs[s] = true
+	}
+	inTheirs := make(map[string]bool, len(theirs))
+	for _, s := range theirs {
+		inTheirs[s] = true
+	}
+
+	var result []string
+	seen := make(map[string]bool)
+	for _, list := range [][]string{ours, theirs} {
+		for _, s := range list {
+			if seen[s] || inBase[s] && !(inOurs[s] && inTheirs[s]) {
+				continue
+			}
+			seen[s] = true
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// withConflictMarkers combines two versions of a document, wrapping the lines
+// that differ in Git-style conflict markers
+func withConflictMarkers(ours, theirs, oursLabel, theirsLabel string) string {
+	a := strings.SplitAfter(ours, "\n")
+	b := strings.SplitAfter(theirs, "\n")
+
+	// Longest common subsequence of lines
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var out strings.Builder
+	var hunkA, hunkB []string
+	flush := func() {
+		if len(hunkA) == 0 && len(hunkB) == 0 {
+			return
+		}
+		out.WriteString("<<<<<<< " + oursLabel + "\n")
+		out.WriteString(strings.Join(hunkA, ""))
+		out.WriteString("=======\n")
+		out.WriteString(strings.Join(hunkB, ""))
+		out.WriteString(">>>>>>> " + theirsLabel + "\n")
+		hunkA, hunkB = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			out.WriteString(a[i])
+			i++
+			j++
+		case j >= len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			hunkA = append(hunkA, a[i])
+			i++
+		default:
+			hunkB = append(hunkB, b[j])
+			j++
+		}
+	}
+	flush()
+	return out.String()
+}
@@ -0,0 +1,138 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+)
+
+const mergeBaseYAML = `id: auth-123
+name: Auth
+description: Login
+tags: [security, legacy]
+relationships:
+  - id: rel-1
+    type: depends-on
+    target_id: db-1
+    target_name: Database
+    created_at: 2025-01-01T00:00:00Z
+versions:
+  "1":
+    created_at: 2025-01-01T00:00:00Z
+    modified_at: 2025-01-02T00:00:00Z
+    authors: [alice]
+metadata:
+  priority: low
+  team: core
+`
+
+func TestMergeFeatureFiles(t *testing.T) {
+	tests := []struct {
+		name          string
+		ours          string
+		theirs        string
+		wantConflicts []string
+		check         func(t *testing.T, merged string)
+	}{
+		{
+			name: "independent changes merge cleanly",
+			ours: strings.NewReplacer(
+				"tags: [security, legacy]", "tags: [security, legacy, ui]",
+				"priority: low", "priority: high",
+				"modified_at: 2025-01-02T00:00:00Z", "modified_at: 2025-01-05T00:00:00Z",
+				"authors: [alice]", "authors: [alice, bob]",
+			).Replace(mergeBaseYAML),
+			theirs: strings.NewReplacer(
+				"tags: [security, legacy]", "tags: [security]",
+				"description: Login", "description: OAuth login",
+				"modified_at: 2025-01-02T00:00:00Z", "modified_at: 2025-01-03T00:00:00Z",
+				"authors: [alice]", "authors: [alice, carol]",
+				"versions:", `  - id: rel-2
+    type: depends-on
+    target_id: cache-1
+    target_name: Cache
+    created_at: 2025-01-02T00:00:00Z
+versions:`,
+				`  "1":`, `  "2":
+    created_at: 2025-01-03T00:00:00Z
+  "1":`,
+			).Replace(mergeBaseYAML),
+			check: func(t *testing.T, merged string) {
+				f, err := storage.UnmarshalFeature([]byte(merged))
+				if err != nil {
+					t.Fatalf("merged file doesn't parse: %v\n%s", err, merged)
+				}
+				if got := strings.Join(f.Tags, ","); got != "security,ui" {
+					t.Errorf("tags = %s, want security,ui", got)
+				}
+				if f.Description != "OAuth login" || f.GetMetadataString("priority") != "high" {
+					t.Errorf("description = %q, priority = %q", f.Description, f.GetMetadataString("priority"))
+				}
+				if len(f.Relationships) != 2 {
+					t.Errorf("relationships = %d, want 2", len(f.Relationships))
+				}
+				if len(f.Versions) != 2 {
+					t.Errorf("versions = %d, want 2", len(f.Versions))
+				}
+				v1 := f.Versions["1"]
+				if got := strings.Join(v1.Authors, ","); got != "alice,bob,carol" {
+					t.Errorf("authors = %s, want alice,bob,carol", got)
+				}
+				if v1.ModifiedAt.Day() != 5 {
+					t.Errorf("modified_at = %s, want the latest", v1.ModifiedAt)
+				}
+			},
+		},
+		{
+			name:          "same field changed differently conflicts",
+			ours:          strings.Replace(mergeBaseYAML, "priority: low", "priority: high", 1),
+			theirs:        strings.Replace(strings.Replace(mergeBaseYAML, "priority: low", "priority: critical", 1), "team: core", "team: web", 1),
+			wantConflicts: []string{"metadata.priority"},
+			check: func(t *testing.T, merged string) {
+				for _, want := range []string{"<<<<<<< ours\n    priority: high\n=======\n    priority: critical\n>>>>>>> theirs\n", "team: web"} {
+					if !strings.Contains(merged, want) {
+						t.Errorf("merged file missing %q:\n%s", want, merged)
+					}
+				}
+			},
+		},
+		{
+			name:   "same change on both sides",
+			ours:   strings.Replace(mergeBaseYAML, "name: Auth", "name: Authentication", 1),
+			theirs: strings.Replace(mergeBaseYAML, "name: Auth", "name: Authentication", 1),
+			check: func(t *testing.T, merged string) {
+				if !strings.Contains(merged, "name: Authentication") {
+					t.Errorf("expected renamed feature:\n%s", merged)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts, err := MergeFeatureFiles([]byte(mergeBaseYAML), []byte(tt.ours), []byte(tt.theirs), "ours", "theirs")
+			if err != nil {
+				t.Fatalf("MergeFeatureFiles() error = %v", err)
+			}
+			var fields []string
+			for _, c := range conflicts {
+				fields = append(fields, c.Field)
+			}
+			if strings.Join(fields, ",") != strings.Join(tt.wantConflicts, ",") {
+				t.Errorf("conflicts = %v, want %v", fields, tt.wantConflicts)
+			}
+			tt.check(t, string(merged))
+		})
+	}
+}
+
+func TestMergeFeatureFiles_Errors(t *testing.T) {
+	other := strings.Replace(mergeBaseYAML, "id: auth-123", "id: other-456", 1)
+	if _, _, err := MergeFeatureFiles(nil, []byte(mergeBaseYAML), []byte(other), "ours", "theirs"); err == nil {
+		t.Error("expected error for different feature IDs")
+	}
+	if _, _, err := MergeFeatureFiles(nil, []byte(mergeBaseYAML), []byte("<<<<<<< broken"), "ours", "theirs"); err == nil {
+		t.Error("expected error for unparseable input")
+	}
+}
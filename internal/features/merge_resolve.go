@@ -0,0 +1,193 @@
+package features
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// FieldResolution is the value chosen for a conflicting field
+type FieldResolution struct {
+	UseTheirs bool   // Take their side instead of ours
+	Edited    bool   // Use Value instead of either side
+	Value     string // Edited value; empty removes a metadata key
+}
+
+// ConflictResolver chooses the value of a conflicting field. rejected is the
+// reason the previous choice for the same conflict was refused, or nil.
+type ConflictResolver func(conflict FieldConflict, rejected error) (FieldResolution, error)
+
+// ResolveFeatureConflict merges the three sides of a conflicted feature file,
+// asking resolve for each field changed differently on both sides, and returns
+// the resolved feature YAML. base may be empty when both sides added the file.
+// Chosen values are checked against the workflow states and metadata schema
+// of cfg, and resolve is asked again for a value that doesn't pass.
+func ResolveFeatureConflict(base, ours, theirs []byte, cfg *fogit.Config, resolve ConflictResolver) ([]byte, error) {
+	baseFeature, oursFeature, theirsFeature, err := parseMergeSides(base, ours, theirs)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, conflicts := MergeFeatures(baseFeature, oursFeature, theirsFeature, false)
+	if len(conflicts) > 0 {
+		alternative, _ := MergeFeatures(baseFeature, oursFeature, theirsFeature, true)
+		for _, c := range conflicts {
+			var rejected error
+			for {
+				res, err := resolve(c, rejected)
+				if err != nil {
+					return nil, err
+				}
+				switch {
+				case res.Edited:
+					err = setMergedField(merged, c.Field, res.Value)
+				case res.UseTheirs:
+					err = copyMergedField(merged, alternative, c.Field)
+				default:
+					err = copyMergedField(merged, oursFeature, c.Field)
+				}
+				if err != nil {
+					return nil, err
+				}
+				if rejected = validateMergedField(merged, c.Field, cfg); rejected == nil {
+					break
+				}
+			}
+		}
+	}
+
+	if err := merged.Validate(); err != nil {
+		return nil, fmt.Errorf("resolved feature is invalid: %w", err)
+	}
+	if cfg != nil {
+		if err := cfg.MetadataSchema.Enforce(merged); err != nil {
+			return nil, fmt.Errorf("resolved feature is invalid: %w", err)
+		}
+	}
+	return storage.MarshalFeature(merged)
+}
+
+// validateMergedField checks the resolved value of a conflict field. Workflow
+// states and the metadata schema are only checked when cfg is set.
+func validateMergedField(f *fogit.Feature, field string, cfg *fogit.Config) error {
+	if field == "name" || field == "metadata.priority" {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	if cfg == nil {
+		return nil
+	}
+
+	if key, ok := strings.CutPrefix(field, "metadata."); ok {
+		for _, v := range cfg.MetadataSchema.Validate(f) {
+			if v.Field == key {
+				return v
+			}
+		}
+		return nil
+	}
+	if version, attr, ok := versionField(field); ok && attr == "state" {
+		if v := f.Versions[version]; v != nil && v.State != "" {
+			return cfg.Workflow.StateMachine().ValidateState(fogit.State(v.State))
+		}
+	}
+	return nil
+}
+
+// copyMergedField copies a conflict field, as named by MergeFeatures, from src to dst
+func copyMergedField(dst, src *fogit.Feature, field string) error {
+	if key, ok := strings.CutPrefix(field, "metadata."); ok {
+		value, exists := src.Metadata[key]
+		if !exists {
+			delete(dst.Metadata, key)
+			return nil
+		}
+		if dst.Metadata == nil {
+			dst.Metadata = make(map[string]interface{})
+		}
+		dst.Metadata[key] = value
+		return nil
+	}
+	if version, attr, ok := versionField(field); ok {
+		from, to := src.Versions[version], dst.Versions[version]
+		if from == nil || to == nil {
+			return fmt.Errorf("version %s not found", version)
+		}
+		switch attr {
+		case "branch":
+			to.Branch = from.Branch
+		case "parent_branch":
+			to.ParentBranch = from.ParentBranch
+		case "notes":
+			to.Notes = from.Notes
+		case "state":
+			to.State = from.State
+		}
+		return nil
+	}
+
+	switch field {
+	case "name":
+		dst.Name = src.Name
+	case "description":
+		dst.Description = src.Description
+	default:
+		return fmt.Errorf("unsupported conflict field: %s", field)
+	}
+	return nil
+}
+
+// setMergedField sets a conflict field, as named by MergeFeatures, to an edited value
+func setMergedField(f *fogit.Feature, field, value string) error {
+	if key, ok := strings.CutPrefix(field, "metadata."); ok {
+		if value == "" {
+			delete(f.Metadata, key)
+			return nil
+		}
+		f.SetMetadata(key, value)
+		return nil
+	}
+	if version, attr, ok := versionField(field); ok {
+		v := f.Versions[version]
+		if v == nil {
+			return fmt.Errorf("version %s not found", version)
+		}
+		switch attr {
+		case "branch":
+			v.Branch = value
+		case "parent_branch":
+			v.ParentBranch = value
+		case "notes":
+			v.Notes = value
+		case "state":
+			v.State = value
+		}
+		return nil
+	}
+
+	switch field {
+	case "name":
+		f.Name = value
+	case "description":
+		f.Description = value
+	default:
+		return fmt.Errorf("unsupported conflict field: %s", field)
+	}
+	return nil
+}
+
+// versionField splits "versions.<key>.<attr>" into key and attr
+func versionField(field string) (string, string, bool) {
+	rest, ok := strings.CutPrefix(field, "versions.")
+	if !ok {
+		return "", "", false
+	}
+	i := strings.LastIndex(rest, ".")
+	if i < 0 {
+		return "", "", false
+	}
+	return rest[:i], rest[i+1:], true
+}
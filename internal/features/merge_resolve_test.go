@@ -0,0 +1,125 @@
+package features
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestResolveFeatureConflict(t *testing.T) {
+	ours := strings.NewReplacer("name: Auth", "name: Sign in", "priority: low", "priority: high", "tags: [security, legacy]", "tags: [security]").Replace(mergeBaseYAML)
+	theirs := strings.NewReplacer("name: Auth", "name: Log on", "priority: low", "priority: critical").Replace(mergeBaseYAML)
+
+	tests := []struct {
+		name         string
+		resolution   map[string]FieldResolution
+		wantName     string
+		wantPriority string
+		wantErr      bool
+	}{
+		{
+			name:         "keep ours",
+			wantName:     "Sign in",
+			wantPriority: "high",
+		},
+		{
+			name: "take theirs",
+			resolution: map[string]FieldResolution{
+				"name":              {UseTheirs: true},
+				"metadata.priority": {UseTheirs: true},
+			},
+			wantName:     "Log on",
+			wantPriority: "critical",
+		},
+		{
+			name: "edit values",
+			resolution: map[string]FieldResolution{
+				"name":              {Edited: true, Value: "Login"},
+				"metadata.priority": {Edited: true},
+			},
+			wantName: "Login",
+		},
+		{
+			name:       "invalid result",
+			resolution: map[string]FieldResolution{"name": {Edited: true}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var asked []string
+			data, err := ResolveFeatureConflict([]byte(mergeBaseYAML), []byte(ours), []byte(theirs), fogit.DefaultConfig(), func(c FieldConflict, rejected error) (FieldResolution, error) {
+				if rejected != nil {
+					return FieldResolution{}, rejected
+				}
+				asked = append(asked, c.Field)
+				return tt.resolution[c.Field], nil
+			})
+			if strings.Join(asked, ",") != "metadata.priority,name" {
+				t.Errorf("asked about %v, want metadata.priority and name", asked)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveFeatureConflict() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			f, err := storage.UnmarshalFeature(data)
+			if err != nil {
+				t.Fatalf("resolved file doesn't parse: %v\n%s", err, data)
+			}
+			if f.Name != tt.wantName || f.GetMetadataString("priority") != tt.wantPriority {
+				t.Errorf("name = %q, priority = %q, want %q, %q", f.Name, f.GetMetadataString("priority"), tt.wantName, tt.wantPriority)
+			}
+			if got := strings.Join(f.Tags, ","); got != "security" {
+				t.Errorf("tags = %s, want the non-conflicting change from ours", got)
+			}
+		})
+	}
+}
+
+func TestResolveFeatureConflict_Revalidates(t *testing.T) {
+	ours := strings.NewReplacer("team: core", "team: backend", "authors: [alice]", "authors: [alice]\n    state: in-progress").Replace(mergeBaseYAML)
+	theirs := strings.NewReplacer("team: core", "team: web", "authors: [alice]", "authors: [alice]\n    state: closed").Replace(mergeBaseYAML)
+
+	cfg := fogit.DefaultConfig()
+	cfg.MetadataSchema = &fogit.MetadataSchema{Fields: map[string]fogit.MetadataField{
+		"team": {Type: fogit.MetadataTypeEnum, Values: []string{"core", "backend", "web"}},
+	}}
+
+	// The first answer for each field is invalid and must be asked for again
+	answers := map[string][]FieldResolution{
+		"metadata.team":    {{Edited: true, Value: "marketing"}, {Edited: true, Value: "web"}},
+		"versions.1.state": {{Edited: true, Value: "shipped"}, {UseTheirs: true}},
+	}
+	var rejections []string
+	data, err := ResolveFeatureConflict([]byte(mergeBaseYAML), []byte(ours), []byte(theirs), cfg, func(c FieldConflict, rejected error) (FieldResolution, error) {
+		if rejected != nil {
+			rejections = append(rejections, c.Field)
+		}
+		next := answers[c.Field]
+		if len(next) == 0 {
+			t.Fatalf("asked too often about %s", c.Field)
+		}
+		answers[c.Field] = next[1:]
+		return next[0], nil
+	})
+	if err != nil {
+		t.Fatalf("ResolveFeatureConflict() error = %v", err)
+	}
+	if got := strings.Join(rejections, ","); got != "metadata.team,versions.1.state" {
+		t.Errorf("rejected %s, want metadata.team and versions.1.state", got)
+	}
+
+	f, err := storage.UnmarshalFeature(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.GetTeam() != "web" || f.Versions["1"].State != "closed" {
+		t.Errorf("team = %q, state = %q, want web and closed", f.GetTeam(), f.Versions["1"].State)
+	}
+}
@@ -14,6 +14,7 @@ type MergeState struct {
 	BaseBranch    string   `yaml:"base_branch"`
 	FeatureIDs    []string `yaml:"feature_ids"`
 	NoDelete      bool     `yaml:"no_delete"`
+	NoRestack     bool     `yaml:"no_restack,omitempty"`
 	Squash        bool     `yaml:"squash"`
 	ConflictFiles []string `yaml:"conflict_files,omitempty"`
 }
@@ -0,0 +1,139 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// PRBody is the data a pull request description template is rendered with
+type PRBody struct {
+	Branch     string       `json:"branch"`
+	BaseBranch string       `json:"base_branch"`
+	Features   []PRFeature  `json:"features"`
+	Validation PRValidation `json:"validation"`
+}
+
+// PRFeature is a feature going into a pull request, flattened for templates
+type PRFeature struct {
+	ID            string            `json:"id"`
+	Name          string            `json:"name"`
+	Description   string            `json:"description,omitempty"`
+	State         string            `json:"state"`
+	Type          string            `json:"type,omitempty"`
+	Priority      string            `json:"priority,omitempty"`
+	Category      string            `json:"category,omitempty"`
+	Tags          []string          `json:"tags,omitempty"`
+	Files         []string          `json:"files,omitempty"`
+	Versions      []PRVersion       `json:"versions,omitempty"`
+	Relationships []PRRelationship  `json:"relationships,omitempty"`
+	Impacts       []ImpactedFeature `json:"impacts"`
+}
+
+// PRVersion is one version of a feature, oldest first
+type PRVersion struct {
+	Version   string     `json:"version"`
+	Branch    string     `json:"branch,omitempty"`
+	Authors   []string   `json:"authors,omitempty"`
+	Notes     string     `json:"notes,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+}
+
+// PRRelationship is an outgoing relationship of a feature
+type PRRelationship struct {
+	Type        string `json:"type"`
+	TargetID    string `json:"target_id"`
+	TargetName  string `json:"target_name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PRValidation is the validation status of the features in a pull request
+type PRValidation struct {
+	Passed   bool      `json:"passed"`
+	Errors   int       `json:"errors"`
+	Warnings int       `json:"warnings"`
+	Issues   []PRIssue `json:"issues,omitempty"`
+}
+
+// PRIssue is a validation issue found on a feature in a pull request
+type PRIssue struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Feature  string `json:"feature"`
+	Message  string `json:"message"`
+}
+
+// NewPRFeature flattens a feature and its impact analysis for a pull request description.
+// impacts may be nil when no analysis was done.
+func NewPRFeature(f *fogit.Feature, impacts *ImpactResult) PRFeature {
+	pf := PRFeature{
+		ID:          f.ID,
+		Name:        f.Name,
+		Description: f.Description,
+		State:       string(f.DeriveState()),
+		Type:        f.GetType(),
+		Priority:    string(f.GetPriority()),
+		Category:    f.GetCategory(),
+		Tags:        f.Tags,
+		Files:       f.Files,
+		Impacts:     []ImpactedFeature{},
+	}
+	for _, key := range f.GetSortedVersionKeys() {
+		v := f.Versions[key]
+		pf.Versions = append(pf.Versions, PRVersion{
+			Version:   key,
+			Branch:    v.Branch,
+			Authors:   v.Authors,
+			Notes:     v.Notes,
+			CreatedAt: v.CreatedAt,
+			ClosedAt:  v.ClosedAt,
+		})
+	}
+	for _, rel := range f.Relationships {
+		pf.Relationships = append(pf.Relationships, PRRelationship{
+			Type:        string(rel.Type),
+			TargetID:    rel.TargetID,
+			TargetName:  rel.TargetName,
+			Description: rel.Description,
+		})
+	}
+	if impacts != nil && impacts.ImpactedFeatures != nil {
+		pf.Impacts = impacts.ImpactedFeatures
+	}
+	return pf
+}
+
+// FindPRFeatures finds the features a pull request from branch delivers: the
+// features that aren't closed (open, in-progress or a custom workflow state)
+// whose branch metadata or current version names the branch. Without any,
+// it falls back to FindAllForBranch.
+func FindPRFeatures(ctx context.Context, repo fogit.Repository, branch string) ([]*fogit.Feature, error) {
+	listed, err := repo.List(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list features: %w", err)
+	}
+
+	var result []*fogit.Feature
+	for _, feature := range listed {
+		if !feature.IsClosed() && onBranch(feature, branch) {
+			result = append(result, feature)
+		}
+	}
+	if len(result) > 0 {
+		return result, nil
+	}
+
+	return FindAllForBranch(ctx, repo, branch)
+}
+
+// onBranch reports whether a feature's branch metadata or current version names branch
+func onBranch(feature *fogit.Feature, branch string) bool {
+	if branchMeta, ok := feature.Metadata["branch"].(string); ok && branchMeta == branch {
+		return true
+	}
+	cv := feature.GetCurrentVersion()
+	return cv != nil && cv.Branch == branch
+}
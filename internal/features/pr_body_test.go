@@ -0,0 +1,59 @@
+package features
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestFindPRFeatures(t *testing.T) {
+	fogitDir := t.TempDir() + "/.fogit"
+	if err := os.MkdirAll(fogitDir+"/features", 0755); err != nil {
+		t.Fatal(err)
+	}
+	repo := storage.NewFileRepository(fogitDir)
+	ctx := context.Background()
+
+	byMetadata := fogit.NewFeature("By Metadata")
+	byMetadata.Metadata["branch"] = "feature/pr"
+	if err := byMetadata.UpdateState(fogit.StateInProgress); err != nil {
+		t.Fatal(err)
+	}
+	byVersion := fogit.NewFeature("By Version")
+	byVersion.GetCurrentVersion().Branch = "feature/pr"
+	closed := fogit.NewFeature("Closed")
+	closed.Metadata["branch"] = "feature/pr"
+	if err := closed.UpdateState(fogit.StateClosed); err != nil {
+		t.Fatal(err)
+	}
+	other := fogit.NewFeature("Other")
+	for _, f := range []*fogit.Feature{byMetadata, byVersion, closed, other} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	found, err := FindPRFeatures(ctx, repo, "feature/pr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, f := range found {
+		names[f.Name] = true
+	}
+	if len(found) != 2 || !names["By Metadata"] || !names["By Version"] {
+		t.Errorf("FindPRFeatures() = %v, want By Metadata and By Version", names)
+	}
+
+	// Without a match it falls back to FindAllForBranch
+	found, err = FindPRFeatures(ctx, repo, "feature/none")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].IsClosed() || found[0].DeriveState() != fogit.StateOpen {
+		t.Errorf("FindPRFeatures() fallback = %v, want one open feature", found)
+	}
+}
@@ -0,0 +1,147 @@
+package features
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/eg3r/fogit/internal/git"
+)
+
+// FeatureSetDiff is the feature-level difference between two commits
+type FeatureSetDiff struct {
+	From       string           `json:"from" yaml:"from"`
+	To         string           `json:"to" yaml:"to"`
+	FromCommit string           `json:"from_commit" yaml:"from_commit"`
+	ToCommit   string           `json:"to_commit" yaml:"to_commit"`
+	Added      []FeatureSummary `json:"added" yaml:"added"`
+	Removed    []FeatureSummary `json:"removed" yaml:"removed"`
+	Changed    []FeatureChange  `json:"changed" yaml:"changed"`
+}
+
+// FeatureSummary identifies a feature added or removed between two commits
+type FeatureSummary struct {
+	ID    string `json:"id" yaml:"id"`
+	Name  string `json:"name" yaml:"name"`
+	State string `json:"state" yaml:"state"`
+	Path  string `json:"path" yaml:"path"`
+}
+
+// FeatureChange is a feature present at both commits whose file changed
+type FeatureChange struct {
+	ID       string      `json:"id" yaml:"id"`
+	Name     string      `json:"name" yaml:"name"`
+	Path     string      `json:"path" yaml:"path"`
+	OldPath  string      `json:"old_path,omitempty" yaml:"old_path,omitempty"` // Set when the file was renamed
+	OldState string      `json:"old_state,omitempty" yaml:"old_state,omitempty"`
+	NewState string      `json:"new_state,omitempty" yaml:"new_state,omitempty"`
+	Changes  []FieldDiff `json:"changes" yaml:"changes"`
+}
+
+// HasDifferences reports whether anything changed
+func (d *FeatureSetDiff) HasDifferences() bool {
+	return len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// ParseRefRange splits a "from..to" or "from...to" range. Either side may be
+// empty and defaults to HEAD. With three dots, the comparison starts from the
+// merge base, showing only what "to" changed since it diverged (like a pull request).
+func ParseRefRange(spec string) (from, to string, mergeBase bool, err error) {
+	sep := ".."
+	if strings.Contains(spec, "...") {
+		sep, mergeBase = "...", true
+	}
+	parts := strings.SplitN(spec, sep, 2)
+	if len(parts) != 2 {
+		return "", "", false, fmt.Errorf("invalid ref range %q: use <from>..<to> or <from>...<to>", spec)
+	}
+	from, to = parts[0], parts[1]
+	if from == "" {
+		from = "HEAD"
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+	return from, to, mergeBase, nil
+}
+
+// DiffRefs compares the features stored at two revisions given as a range (see ParseRefRange)
+func DiffRefs(gitRepo *git.Repository, spec string) (*FeatureSetDiff, error) {
+	from, to, mergeBase, err := ParseRefRange(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	toCommit, _, err := gitRepo.ResolveCommit(to)
+	if err != nil {
+		return nil, err
+	}
+	var fromCommit string
+	if mergeBase {
+		fromCommit, err = gitRepo.MergeBase(from, to)
+	} else {
+		fromCommit, _, err = gitRepo.ResolveCommit(from)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := ListFeatureFilesAtRef(gitRepo, fromCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read features at %s: %w", from, err)
+	}
+	after, err := ListFeatureFilesAtRef(gitRepo, toCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read features at %s: %w", to, err)
+	}
+
+	diff := DiffFeatureSets(before, after)
+	diff.From, diff.To = from, to
+	diff.FromCommit, diff.ToCommit = fromCommit, toCommit
+	return diff, nil
+}
+
+// DiffFeatureSets compares two feature snapshots by ID, so renamed feature files
+// are reported as changes rather than an addition and a removal
+func DiffFeatureSets(before, after []FeatureFile) *FeatureSetDiff {
+	diff := &FeatureSetDiff{Added: []FeatureSummary{}, Removed: []FeatureSummary{}, Changed: []FeatureChange{}}
+
+	old := make(map[string]FeatureFile, len(before))
+	for _, file := range before {
+		old[file.Feature.ID] = file
+	}
+	seen := make(map[string]bool, len(after))
+
+	for _, file := range after {
+		f := file.Feature
+		seen[f.ID] = true
+		prev, ok := old[f.ID]
+		if !ok {
+			diff.Added = append(diff.Added, FeatureSummary{ID: f.ID, Name: f.Name, State: string(f.DeriveState()), Path: file.Path})
+			continue
+		}
+
+		change := FeatureChange{ID: f.ID, Name: f.Name, Path: file.Path, Changes: DiffFeatureFields(prev.Feature, f)}
+		if prev.Path != file.Path {
+			change.OldPath = prev.Path
+		}
+		if oldState, newState := prev.Feature.DeriveState(), f.DeriveState(); oldState != newState {
+			change.OldState, change.NewState = string(oldState), string(newState)
+		}
+		if len(change.Changes) > 0 || change.OldPath != "" {
+			diff.Changed = append(diff.Changed, change)
+		}
+	}
+
+	for _, file := range before {
+		if !seen[file.Feature.ID] {
+			f := file.Feature
+			diff.Removed = append(diff.Removed, FeatureSummary{ID: f.ID, Name: f.Name, State: string(f.DeriveState()), Path: file.Path})
+		}
+	}
+
+	sort.Slice(diff.Added, func(i, j int) bool { return diff.Added[i].Name < diff.Added[j].Name })
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Name < diff.Removed[j].Name })
+	sort.Slice(diff.Changed, func(i, j int) bool { return diff.Changed[i].Name < diff.Changed[j].Name })
+	return diff
+}
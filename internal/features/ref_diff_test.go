@@ -0,0 +1,130 @@
+package features
+
+import (
+	"testing"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestParseRefRange(t *testing.T) {
+	tests := []struct {
+		spec      string
+		from, to  string
+		mergeBase bool
+		wantErr   bool
+	}{
+		{spec: "main..feature/x", from: "main", to: "feature/x"},
+		{spec: "main...feature/x", from: "main", to: "feature/x", mergeBase: true},
+		{spec: "v1.0.0..", from: "v1.0.0", to: "HEAD"},
+		{spec: "...feature/x", from: "HEAD", to: "feature/x", mergeBase: true},
+		{spec: "main", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			from, to, mergeBase, err := ParseRefRange(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRefRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if from != tt.from || to != tt.to || mergeBase != tt.mergeBase {
+				t.Errorf("ParseRefRange() = %q, %q, %v, want %q, %q, %v", from, to, mergeBase, tt.from, tt.to, tt.mergeBase)
+			}
+		})
+	}
+}
+
+func TestDiffFeatureSets(t *testing.T) {
+	kept := fogit.NewFeature("Kept")
+	removed := fogit.NewFeature("Removed")
+	renamed := fogit.NewFeature("Renamed")
+
+	renamedAfter := *renamed
+	renamedAfter.Name = "Renamed Again"
+	keptAfter := *kept
+	keptAfter.Versions = map[string]*fogit.FeatureVersion{"1": {
+		CreatedAt:  kept.Versions["1"].CreatedAt,
+		ModifiedAt: kept.Versions["1"].ModifiedAt,
+		ClosedAt:   timePtr(time.Now()),
+	}}
+	keptAfter.Relationships = []fogit.Relationship{{Type: "depends-on", TargetID: renamed.ID, TargetName: "Renamed Again"}}
+	added := fogit.NewFeature("Added")
+
+	before := []FeatureFile{
+		{Feature: kept, Path: ".fogit/features/kept.yml"},
+		{Feature: removed, Path: ".fogit/features/removed.yml"},
+		{Feature: renamed, Path: ".fogit/features/renamed.yml"},
+	}
+	after := []FeatureFile{
+		{Feature: &keptAfter, Path: ".fogit/features/kept.yml"},
+		{Feature: &renamedAfter, Path: ".fogit/features/renamed-again.yml"},
+		{Feature: added, Path: ".fogit/features/added.yml"},
+	}
+
+	diff := DiffFeatureSets(before, after)
+	if len(diff.Added) != 1 || diff.Added[0].Name != "Added" {
+		t.Errorf("Added = %+v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != "Removed" {
+		t.Errorf("Removed = %+v", diff.Removed)
+	}
+	if len(diff.Changed) != 2 {
+		t.Fatalf("Changed = %+v, want Kept and Renamed Again", diff.Changed)
+	}
+
+	keptChange, renamedChange := diff.Changed[0], diff.Changed[1]
+	if keptChange.OldState != "open" || keptChange.NewState != "closed" {
+		t.Errorf("state change = %s -> %s, want open -> closed", keptChange.OldState, keptChange.NewState)
+	}
+	var relAdded bool
+	for _, c := range keptChange.Changes {
+		if c.Field == "relationship:depends-on:"+renamed.ID && c.ChangeType == "added" {
+			relAdded = true
+		}
+	}
+	if !relAdded {
+		t.Errorf("expected relationship addition, got %+v", keptChange.Changes)
+	}
+	if renamedChange.OldPath != ".fogit/features/renamed.yml" {
+		t.Errorf("OldPath = %q, want the previous file", renamedChange.OldPath)
+	}
+
+	if d := DiffFeatureSets(before, before); d.HasDifferences() {
+		t.Errorf("identical sets should have no differences, got %+v", d)
+	}
+}
+
+func TestDiffRefs(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, ".fogit/features/auth.yml", "id: auth-123\nname: Auth\n", "Add auth")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/billing")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/billing.yml", "id: billing-456\nname: Billing\n", "Add billing")
+
+	// Trunk moves on after the branch point
+	gitInRepo(t, repoPath, "checkout", trunk)
+	createTestCommitInRepo(t, repoPath, ".fogit/features/search.yml", "id: search-789\nname: Search\n", "Add search")
+
+	tests := []struct {
+		spec        string
+		wantAdded   int
+		wantRemoved int
+	}{
+		{trunk + "..feature/billing", 1, 1}, // Billing added, Search "removed"
+		{trunk + "...feature/billing", 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			diff, err := DiffRefs(gitRepo, tt.spec)
+			if err != nil {
+				t.Fatalf("DiffRefs() error = %v", err)
+			}
+			if len(diff.Added) != tt.wantAdded || len(diff.Removed) != tt.wantRemoved {
+				t.Errorf("added %d, removed %d, want %d, %d", len(diff.Added), len(diff.Removed), tt.wantAdded, tt.wantRemoved)
+			}
+			if diff.Added[0].Name != "Billing" {
+				t.Errorf("added %s, want Billing", diff.Added[0].Name)
+			}
+		})
+	}
+}
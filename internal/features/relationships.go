@@ -2,6 +2,7 @@ package features
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -90,13 +91,13 @@ func FindIncomingRelationshipsMultiType(repo fogit.Repository, ctx context.Conte
 // findIncomingRelationshipsFiltered is the core implementation for finding incoming relationships.
 // It consolidates the duplicate logic from FindIncomingRelationships and FindIncomingRelationshipsMultiType.
 func findIncomingRelationshipsFiltered(repo fogit.Repository, ctx context.Context, targetID string, relTypes []string) ([]RelationshipWithSource, error) {
-	allFeatures, err := repo.List(ctx, nil)
+	sources, err := incomingSources(ctx, repo, targetID)
 	if err != nil {
 		return nil, err
 	}
 
 	var incoming []RelationshipWithSource
-	for _, f := range allFeatures {
+	for _, f := range sources {
 		for _, rel := range f.Relationships {
 			if rel.TargetID == targetID {
 				if len(relTypes) == 0 || containsType(relTypes, string(rel.Type)) {
@@ -112,6 +113,40 @@ func findIncomingRelationshipsFiltered(repo fogit.Repository, ctx context.Contex
 	return incoming, nil
 }
 
+// incomingSources returns the features that may have relationships pointing at targetID.
+// Repositories with a reverse-relationship index are asked for the sources directly;
+// otherwise every feature is a candidate. Callers still check each relationship.
+func incomingSources(ctx context.Context, repo fogit.Repository, targetID string) ([]*fogit.Feature, error) {
+	indexer, ok := repo.(fogit.ReverseIndexer)
+	if !ok {
+		return repo.List(ctx, nil)
+	}
+
+	edges, err := indexer.IncomingEdges(ctx, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reverse relationship index: %w", err)
+	}
+
+	var sources []*fogit.Feature
+	seen := make(map[string]bool)
+	for _, edge := range edges {
+		if seen[edge.SourceID] {
+			continue
+		}
+		seen[edge.SourceID] = true
+
+		source, err := repo.Get(ctx, edge.SourceID)
+		if err != nil {
+			if errors.Is(err, fogit.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
+
 // containsType checks if a type is in the list
 func containsType(types []string, t string) bool {
 	for _, typ := range types {
@@ -262,7 +297,7 @@ func Unlink(ctx context.Context, repo fogit.Repository, source *fogit.Feature, r
 	}
 
 	if foundRel == nil {
-		return nil, fmt.Errorf("relationship not found with ID: %s", relID)
+		return nil, fmt.Errorf("%w with ID: %s", fogit.ErrRelationshipNotFound, relID)
 	}
 
 	// Remove by full ID
@@ -339,13 +374,13 @@ func ClearAllRelationships(ctx context.Context, repo fogit.Repository, source *f
 // CleanupIncomingRelationships removes all relationships from other features that point to the deleted feature
 // Returns the number of relationships removed
 func CleanupIncomingRelationships(ctx context.Context, repo fogit.Repository, deletedFeatureID string) (int, error) {
-	allFeatures, err := repo.List(ctx, nil)
+	sources, err := incomingSources(ctx, repo, deletedFeatureID)
 	if err != nil {
 		return 0, err
 	}
 
 	removedCount := 0
-	for _, f := range allFeatures {
+	for _, f := range sources {
 		if f.ID == deletedFeatureID {
 			continue
 		}
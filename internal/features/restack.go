@@ -0,0 +1,248 @@
+package features
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/internal/storage"
+)
+
+// RestackOptions contains options for restacking stacked feature branches
+type RestackOptions struct {
+	Parent     string            // Only restack branches stacked (directly or transitively) on this branch (empty = all)
+	BaseBranch string            // Branch that replaces a merged parent (default: main)
+	ParentTips map[string]string // Commits that merged, deleted parent branches pointed at, used as the rebase upstream
+	DryRun     bool              // Report what would be restacked without rebasing
+}
+
+// RestackedBranch describes a stacked branch that was (or would be) rebased
+type RestackedBranch struct {
+	FeatureID   string
+	FeatureName string
+	Branch      string
+	OldParent   string
+	NewParent   string // The base branch when the old parent was merged
+}
+
+// stackedBranch is a feature branch recorded as stacked on another branch
+type stackedBranch struct {
+	file   FeatureFile
+	branch string
+	parent string
+}
+
+// Restack rebases feature branches created with a stacked parent (FeatureVersion.ParentBranch):
+// a branch whose parent was merged moves onto the base branch and stops being stacked,
+// and a branch whose parent moved is rebased onto the parent's new tip. Parents are
+// restacked before their children. On a conflict the rebase is aborted and the branches
+// restacked so far are returned with the error. The original branch is checked out again.
+func Restack(ctx context.Context, gitRepo *git.Repository, opts RestackOptions) ([]RestackedBranch, error) {
+	if opts.BaseBranch == "" {
+		opts.BaseBranch = "main"
+	}
+
+	stacked, err := findStackedBranches(gitRepo)
+	if err != nil {
+		return nil, err
+	}
+	if len(stacked) == 0 {
+		return nil, nil
+	}
+
+	if !opts.DryRun {
+		changedFiles, err := gitRepo.GetChangedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for changes: %w", err)
+		}
+		if len(changedFiles) > 0 {
+			return nil, fmt.Errorf("you have uncommitted changes. Commit or stash them first:\n  %v", changedFiles)
+		}
+	}
+
+	startBranch, _ := gitRepo.GetCurrentBranch()
+	defer func() {
+		if opts.DryRun || startBranch == "" {
+			return
+		}
+		if current, _ := gitRepo.GetCurrentBranch(); current != startBranch && gitRepo.BranchExists(startBranch) {
+			_ = gitRepo.CheckoutBranch(startBranch)
+		}
+	}()
+
+	// Old tips of moved branches; their children are rebased from there
+	tips := make(map[string]string)
+	for branch, tip := range opts.ParentTips {
+		tips[branch] = tip
+	}
+
+	var restacked []RestackedBranch
+	for _, s := range orderStackedBranches(stacked) {
+		if err := ctx.Err(); err != nil {
+			return restacked, err
+		}
+
+		_, parentMoved := tips[s.parent]
+		if opts.Parent != "" && s.parent != opts.Parent && !parentMoved {
+			continue
+		}
+
+		_, mergedNow := opts.ParentTips[s.parent]
+		merged := mergedNow || isParentMerged(gitRepo, s.parent)
+		newParent := s.parent
+		if merged {
+			newParent = opts.BaseBranch
+		} else if !parentMoved && isStackedOnTip(gitRepo, s.branch, s.parent) {
+			continue // Already on top of its parent
+		}
+
+		// Commits of the branch are those after the parent's old tip
+		upstream := newParent
+		if tip, ok := tips[s.parent]; ok {
+			upstream = tip
+		} else if gitRepo.BranchExists(s.parent) {
+			upstream = s.parent
+		}
+
+		oldTip, _, err := gitRepo.ResolveCommit(s.branch)
+		if err != nil {
+			return restacked, err
+		}
+
+		entry := RestackedBranch{
+			FeatureID:   s.file.Feature.ID,
+			FeatureName: s.file.Feature.Name,
+			Branch:      s.branch,
+			OldParent:   s.parent,
+			NewParent:   newParent,
+		}
+
+		if !opts.DryRun {
+			if err := gitRepo.RebaseOnto(newParent, upstream, s.branch); err != nil {
+				return restacked, fmt.Errorf("failed to restack %s onto %s: %w", s.branch, newParent, err)
+			}
+			if merged {
+				if err := unstackFeature(gitRepo, s, newParent); err != nil {
+					return restacked, err
+				}
+			}
+		}
+
+		tips[s.branch] = oldTip
+		restacked = append(restacked, entry)
+	}
+
+	return restacked, nil
+}
+
+// findStackedBranches reads each local branch for the open features that live on it
+// and record a parent branch
+func findStackedBranches(gitRepo *git.Repository) ([]stackedBranch, error) {
+	branches, err := gitRepo.ListBranches()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list branches: %w", err)
+	}
+
+	var stacked []stackedBranch
+	for _, branch := range branches {
+		files, err := ListFeatureFilesAtRef(gitRepo, branch)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			f := file.Feature
+			cv := f.GetCurrentVersion()
+			if f.IsClosed() || cv == nil || cv.ParentBranch == "" || GetFeatureBranch(f) != branch {
+				continue
+			}
+			stacked = append(stacked, stackedBranch{file: file, branch: branch, parent: cv.ParentBranch})
+			break
+		}
+	}
+	return stacked, nil
+}
+
+// orderStackedBranches orders stacked branches so that parents come before their children
+func orderStackedBranches(stacked []stackedBranch) []stackedBranch {
+	pending := make(map[string]bool, len(stacked))
+	for _, s := range stacked {
+		pending[s.branch] = true
+	}
+
+	ordered := make([]stackedBranch, 0, len(stacked))
+	for len(ordered) < len(stacked) {
+		progressed := false
+		for _, s := range stacked {
+			if pending[s.branch] && !pending[s.parent] {
+				ordered = append(ordered, s)
+				pending[s.branch] = false
+				progressed = true
+			}
+		}
+		if !progressed {
+			// A cycle of parents; keep the remaining branches in listing order
+			for _, s := range stacked {
+				if pending[s.branch] {
+					ordered = append(ordered, s)
+					pending[s.branch] = false
+				}
+			}
+		}
+	}
+	return ordered
+}
+
+// isParentMerged reports whether a parent branch was merged: it is gone or the
+// features on it are closed (merged with --no-delete)
+func isParentMerged(gitRepo *git.Repository, parent string) bool {
+	if !gitRepo.BranchExists(parent) {
+		return true
+	}
+	files, err := ListFeatureFilesAtRef(gitRepo, parent)
+	if err != nil {
+		return false
+	}
+	found := false
+	for _, file := range files {
+		if GetFeatureBranch(file.Feature) != parent {
+			continue
+		}
+		if !file.Feature.IsClosed() {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// isStackedOnTip reports whether branch already contains the tip of parent
+func isStackedOnTip(gitRepo *git.Repository, branch, parent string) bool {
+	parentTip, _, err := gitRepo.ResolveCommit(parent)
+	if err != nil {
+		return false
+	}
+	branchTip, _, err := gitRepo.ResolveCommit(branch)
+	if err != nil {
+		return false
+	}
+	base, err := gitRepo.MergeBase(parentTip, branchTip)
+	return err == nil && base == parentTip
+}
+
+// unstackFeature clears the parent branch of a feature whose parent was merged
+// and commits the change on its branch, which must be checked out
+func unstackFeature(gitRepo *git.Repository, s stackedBranch, baseBranch string) error {
+	f := s.file.Feature
+	if cv := f.GetCurrentVersion(); cv != nil {
+		cv.ParentBranch = ""
+	}
+	data, err := storage.MarshalFeature(f)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature %s: %w", f.Name, err)
+	}
+	msg := fmt.Sprintf("Restack %s onto %s", f.Name, baseBranch)
+	if err := gitRepo.UpdateFileOnBranch(s.branch, s.file.Path, data, msg); err != nil {
+		return fmt.Errorf("failed to update feature %s: %w", f.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/git"
+)
+
+func stackedFeatureYAML(id, name, branch, parent string) string {
+	yaml := fmt.Sprintf(`id: %s
+name: %s
+versions:
+  "1":
+    created_at: 2025-01-01T00:00:00Z
+    modified_at: 2025-01-01T00:00:00Z
+    branch: %s
+`, id, name, branch)
+	if parent != "" {
+		yaml += "    parent_branch: " + parent + "\n"
+	}
+	return yaml
+}
+
+// setupStackedRepo creates a stack of three feature branches:
+// trunk ← feature/auth ← feature/login ← feature/billing
+func setupStackedRepo(t *testing.T) (string, *git.Repository, string) {
+	t.Helper()
+
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	trunk := gitInRepo(t, repoPath, "rev-parse", "--abbrev-ref", "HEAD")
+
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/auth")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/auth.yml", stackedFeatureYAML("auth-1", "Auth", "feature/auth", ""), "Add auth feature")
+	createTestCommitInRepo(t, repoPath, "auth.go", "package auth\n", "Implement auth")
+
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/login")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/login.yml", stackedFeatureYAML("login-1", "Login", "feature/login", "feature/auth"), "Add login feature")
+	createTestCommitInRepo(t, repoPath, "login.go", "package login\n", "Implement login")
+
+	gitInRepo(t, repoPath, "checkout", "-b", "feature/billing")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/billing.yml", stackedFeatureYAML("billing-1", "Billing", "feature/billing", "feature/login"), "Add billing feature")
+
+	gitInRepo(t, repoPath, "checkout", trunk)
+	return repoPath, gitRepo, trunk
+}
+
+func isAncestor(t *testing.T, repoPath, ancestor, branch string) bool {
+	t.Helper()
+	return gitInRepo(t, repoPath, "merge-base", ancestor, branch) == gitInRepo(t, repoPath, "rev-parse", ancestor)
+}
+
+func parentBranchAt(t *testing.T, gitRepo *git.Repository, branch, id string) string {
+	t.Helper()
+	files, err := ListFeatureFilesAtRef(gitRepo, branch)
+	if err != nil {
+		t.Fatalf("ListFeatureFilesAtRef(%s) error = %v", branch, err)
+	}
+	for _, file := range files {
+		if file.Feature.ID == id {
+			return file.Feature.GetCurrentVersion().ParentBranch
+		}
+	}
+	t.Fatalf("feature %s not found on %s", id, branch)
+	return ""
+}
+
+func TestRestack(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("merged parent moves the stack onto trunk", func(t *testing.T) {
+		repoPath, gitRepo, trunk := setupStackedRepo(t)
+
+		// Squash merge, so the auth commits are not in trunk's history
+		gitInRepo(t, repoPath, "merge", "--squash", "feature/auth")
+		gitInRepo(t, repoPath, "commit", "-m", "Merge auth")
+		authTip := gitInRepo(t, repoPath, "rev-parse", "feature/auth")
+		gitInRepo(t, repoPath, "branch", "-D", "feature/auth")
+
+		restacked, err := Restack(ctx, gitRepo, RestackOptions{
+			Parent:     "feature/auth",
+			BaseBranch: trunk,
+			ParentTips: map[string]string{"feature/auth": authTip},
+		})
+		if err != nil {
+			t.Fatalf("Restack() error = %v", err)
+		}
+		if len(restacked) != 2 {
+			t.Fatalf("restacked %d branches, want 2: %+v", len(restacked), restacked)
+		}
+		if restacked[0].Branch != "feature/login" || restacked[0].NewParent != trunk {
+			t.Errorf("restacked[0] = %+v, want feature/login onto %s", restacked[0], trunk)
+		}
+		if restacked[1].Branch != "feature/billing" || restacked[1].NewParent != "feature/login" {
+			t.Errorf("restacked[1] = %+v, want feature/billing onto feature/login", restacked[1])
+		}
+
+		if !isAncestor(t, repoPath, trunk, "feature/login") || !isAncestor(t, repoPath, "feature/login", "feature/billing") {
+			t.Error("stack not rebased onto the new trunk")
+		}
+		// Only login's own commits and the restack commit are left on top of trunk
+		if got := gitInRepo(t, repoPath, "rev-list", "--count", trunk+"..feature/login"); got != "3" {
+			t.Errorf("feature/login has %s commits on top of %s, want 3", got, trunk)
+		}
+		if got := parentBranchAt(t, gitRepo, "feature/login", "login-1"); got != "" {
+			t.Errorf("login parent_branch = %q, want it cleared", got)
+		}
+		if got := parentBranchAt(t, gitRepo, "feature/billing", "billing-1"); got != "feature/login" {
+			t.Errorf("billing parent_branch = %q, want feature/login", got)
+		}
+		if branch, _ := gitRepo.GetCurrentBranch(); branch != trunk {
+			t.Errorf("current branch = %s, want %s", branch, trunk)
+		}
+	})
+
+	t.Run("moved parent", func(t *testing.T) {
+		repoPath, gitRepo, trunk := setupStackedRepo(t)
+		gitInRepo(t, repoPath, "checkout", "feature/auth")
+		createTestCommitInRepo(t, repoPath, "session.go", "package auth\n", "Add sessions")
+		gitInRepo(t, repoPath, "checkout", trunk)
+
+		dryRun, err := Restack(ctx, gitRepo, RestackOptions{BaseBranch: trunk, DryRun: true})
+		if err != nil {
+			t.Fatalf("Restack(dry run) error = %v", err)
+		}
+		if len(dryRun) != 2 || isAncestor(t, repoPath, "feature/auth", "feature/login") {
+			t.Fatalf("dry run = %+v, want 2 branches reported and none moved", dryRun)
+		}
+
+		restacked, err := Restack(ctx, gitRepo, RestackOptions{BaseBranch: trunk})
+		if err != nil {
+			t.Fatalf("Restack() error = %v", err)
+		}
+		if len(restacked) != 2 || restacked[0].NewParent != "feature/auth" {
+			t.Fatalf("restacked = %+v, want login onto feature/auth, then billing", restacked)
+		}
+		if !isAncestor(t, repoPath, "feature/auth", "feature/login") || !isAncestor(t, repoPath, "feature/login", "feature/billing") {
+			t.Error("stack not rebased onto the moved parent")
+		}
+		if got := parentBranchAt(t, gitRepo, "feature/login", "login-1"); got != "feature/auth" {
+			t.Errorf("login parent_branch = %q, want feature/auth", got)
+		}
+
+		again, err := Restack(ctx, gitRepo, RestackOptions{BaseBranch: trunk})
+		if err != nil || len(again) != 0 {
+			t.Errorf("second Restack() = %+v, %v; want nothing to do", again, err)
+		}
+	})
+
+	t.Run("conflict is aborted", func(t *testing.T) {
+		repoPath, gitRepo, trunk := setupStackedRepo(t)
+		gitInRepo(t, repoPath, "checkout", "feature/auth")
+		createTestCommitInRepo(t, repoPath, "login.go", "package auth\n", "Conflicting login")
+		gitInRepo(t, repoPath, "checkout", trunk)
+		loginTip := gitInRepo(t, repoPath, "rev-parse", "feature/login")
+
+		_, err := Restack(ctx, gitRepo, RestackOptions{BaseBranch: trunk})
+		if !errors.Is(err, git.ErrRebaseConflict) {
+			t.Fatalf("Restack() error = %v, want ErrRebaseConflict", err)
+		}
+		if got := gitInRepo(t, repoPath, "rev-parse", "feature/login"); got != loginTip {
+			t.Error("feature/login moved despite the aborted rebase")
+		}
+		if branch, _ := gitRepo.GetCurrentBranch(); branch != trunk {
+			t.Errorf("current branch = %s, want %s", branch, trunk)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// RunStateEntryHook runs the on_enter hook configured for a workflow state.
+// Hooks are scripts in .fogit/hooks/; they run from the repository root with the
+// feature ID, previous state and new state as arguments, and the same values in
+// FOGIT_FEATURE_ID, FOGIT_FEATURE_NAME, FOGIT_FROM_STATE and FOGIT_TO_STATE.
+// States without a hook are a no-op.
+func RunStateEntryHook(ctx context.Context, fogitDir string, states *fogit.StateMachine, feature *fogit.Feature, from, to fogit.State) error {
+	sc, ok := states.Get(to)
+	if !ok || sc.OnEnter == "" {
+		return nil
+	}
+
+	hooksDir := filepath.Join(fogitDir, "hooks")
+	hookPath := filepath.Join(hooksDir, sc.OnEnter)
+	if rel, err := filepath.Rel(hooksDir, hookPath); err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("on_enter hook for state '%s' must be inside .fogit/hooks: %s", to, sc.OnEnter)
+	}
+	if _, err := os.Stat(hookPath); err != nil {
+		return fmt.Errorf("on_enter hook for state '%s' not found: %w", to, err)
+	}
+
+	cmd := exec.CommandContext(ctx, hookPath, feature.ID, string(from), string(to)) //nolint:gosec // hook path is confined to .fogit/hooks
+	cmd.Dir = filepath.Dir(fogitDir)
+	cmd.Env = append(os.Environ(),
+		"FOGIT_FEATURE_ID="+feature.ID,
+		"FOGIT_FEATURE_NAME="+feature.Name,
+		"FOGIT_FROM_STATE="+string(from),
+		"FOGIT_TO_STATE="+string(to),
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("on_enter hook for state '%s' failed: %w", to, err)
+	}
+	return nil
+}
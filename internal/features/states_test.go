@@ -0,0 +1,73 @@
+package features
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestUpdate_CustomStateRunsOnEnterHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hook script uses sh")
+	}
+
+	ctx := context.Background()
+	fogitDir := filepath.Join(t.TempDir(), ".fogit")
+	hooksDir := filepath.Join(fogitDir, "hooks")
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	script := "#!/bin/sh\necho \"$FOGIT_FEATURE_NAME $1 $2 $3\" > entered.txt\n"
+	if err := os.WriteFile(filepath.Join(hooksDir, "notify.sh"), []byte(script), 0755); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	repo := storage.NewFileRepository(fogitDir)
+	feature := fogit.NewFeature("Login")
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	states := fogit.NewStateMachine([]fogit.StateConfig{
+		{Name: "open", Transitions: []string{"review"}},
+		{Name: "review", Transitions: []string{"closed"}, OnEnter: "notify.sh"},
+	})
+	review := "review"
+	changed, err := Update(ctx, repo, feature, UpdateOptions{State: &review, States: states, FogitDir: fogitDir})
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if !changed {
+		t.Fatal("Update() reported no change")
+	}
+
+	saved, err := repo.Get(ctx, feature.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got := saved.DeriveState(); got != "review" {
+		t.Errorf("saved state = %s, want review", got)
+	}
+
+	// The hook runs from the repository root
+	out, err := os.ReadFile(filepath.Join(filepath.Dir(fogitDir), "entered.txt"))
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	want := "Login " + feature.ID + " open review"
+	if got := strings.TrimSpace(string(out)); got != want {
+		t.Errorf("hook output = %q, want %q", got, want)
+	}
+
+	// Transitions not listed in the workflow are rejected
+	open := "open"
+	if _, err := Update(ctx, repo, saved, UpdateOptions{State: &open, States: states}); err == nil {
+		t.Error("Update() allowed review -> open")
+	}
+}
@@ -43,6 +43,13 @@ func CalculateStats(features []*fogit.Feature, cfg *fogit.Config) *RepoStats {
 		ByRelationCat:  make(map[string]int),
 	}
 
+	// Include every configured workflow state, even when no feature is in it
+	if cfg != nil {
+		for _, state := range cfg.Workflow.StateMachine().States() {
+			stats.ByState[state] = 0
+		}
+	}
+
 	stats.TotalFeatures = len(features)
 	now := time.Now()
 	var totalAge time.Duration
@@ -27,9 +27,10 @@ type RepositoryStatus struct {
 
 // FeatureCountsByState contains counts grouped by state
 type FeatureCountsByState struct {
-	Open       int `json:"open"`
-	InProgress int `json:"in_progress"`
-	Closed     int `json:"closed"`
+	Open       int            `json:"open"`
+	InProgress int            `json:"in_progress"`
+	Closed     int            `json:"closed"`
+	Custom     map[string]int `json:"custom,omitempty"` // Configured workflow states beyond the built-ins
 }
 
 // RecentChange represents a recently modified feature
@@ -79,6 +80,16 @@ func BuildStatusReport(featuresList []*fogit.Feature, cfg *fogit.Config, opts St
 		},
 	}
 
+	// Report every configured custom state, even when no feature is in it
+	report.FeatureCounts.Custom = make(map[string]int)
+	for _, state := range cfg.Workflow.StateMachine().States() {
+		switch state {
+		case fogit.StateOpen, fogit.StateInProgress, fogit.StateClosed:
+		default:
+			report.FeatureCounts.Custom[string(state)] = 0
+		}
+	}
+
 	// Count by state
 	for _, f := range featuresList {
 		state := f.DeriveState()
@@ -89,6 +100,8 @@ func BuildStatusReport(featuresList []*fogit.Feature, cfg *fogit.Config, opts St
 			report.FeatureCounts.InProgress++
 		case fogit.StateClosed:
 			report.FeatureCounts.Closed++
+		default:
+			report.FeatureCounts.Custom[string(state)]++
 		}
 
 		// Count files
@@ -65,8 +65,7 @@ func Switch(ctx context.Context, repo fogit.Repository, gitRepo *git.Repository,
 
 	// Check feature state - can only switch to open features
 	// Closed features need to be reopened with 'fogit feature <name> --new-version'
-	state := feature.DeriveState()
-	if state == fogit.StateClosed {
+	if feature.IsClosed() {
 		return nil, fmt.Errorf("cannot switch to closed feature '%s'. Use 'fogit feature %s --new-version' to reopen it", feature.Name, feature.Name)
 	}
 
@@ -0,0 +1,153 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/eg3r/fogit/internal/git"
+)
+
+// ErrBranchDiverged is returned when the local branch has commits the remote doesn't and rebasing is disabled
+var ErrBranchDiverged = errors.New("local and remote branches have diverged")
+
+// SyncOptions contains options for syncing with a remote
+type SyncOptions struct {
+	Remote string // Defaults to origin
+	Branch string // Defaults to the current branch
+	FFOnly bool   // Fail instead of rebasing when the branches have diverged
+	DryRun bool   // Fetch and report incoming changes without updating the branch
+}
+
+// SyncResult describes what a sync brought in
+type SyncResult struct {
+	Remote           string
+	Branch           string
+	Upstream         string // Remote-tracking branch, e.g. origin/main
+	IncomingCommits  int
+	Incoming         *FeatureSetDiff // Feature changes on the remote since the branches diverged
+	NewRelationships []RelationshipSummary
+	UpToDate         bool
+	Updated          bool // The local branch was moved; false for dry runs
+	Rebased          bool // Local commits were replayed onto the remote branch
+}
+
+// RelationshipSummary identifies a relationship between two features
+type RelationshipSummary struct {
+	SourceID   string
+	SourceName string
+	Type       string
+	TargetID   string
+	TargetName string
+}
+
+// Sync fetches the remote and brings the branch up to date with its
+// remote-tracking branch: fast-forwarding when possible, otherwise rebasing
+// local commits on top. The feature changes that came in are reported by
+// comparing the merge base with the remote branch.
+func Sync(gitRepo *git.Repository, opts SyncOptions) (*SyncResult, error) {
+	if opts.Remote == "" {
+		opts.Remote = "origin"
+	}
+	if opts.Branch == "" {
+		branch, err := gitRepo.GetCurrentBranch()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get current branch: %w", err)
+		}
+		opts.Branch = branch
+	}
+
+	if err := gitRepo.Fetch(opts.Remote); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Remote: opts.Remote, Branch: opts.Branch, Upstream: opts.Remote + "/" + opts.Branch}
+	upstreamCommit, _, err := gitRepo.ResolveCommit(result.Upstream)
+	if err != nil {
+		return nil, fmt.Errorf("branch '%s' not found on remote '%s'", opts.Branch, opts.Remote)
+	}
+	headCommit, _, err := gitRepo.ResolveCommit("HEAD")
+	if err != nil {
+		return nil, err
+	}
+	baseCommit, err := gitRepo.MergeBase(headCommit, upstreamCommit)
+	if err != nil {
+		return nil, err
+	}
+	if baseCommit == upstreamCommit {
+		result.UpToDate = true
+		return result, nil
+	}
+
+	commits, err := gitRepo.GetLogRange(baseCommit, upstreamCommit, "")
+	if err != nil {
+		return nil, err
+	}
+	result.IncomingCommits = len(commits)
+
+	before, err := ListFeatureFilesAtRef(gitRepo, baseCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read local features: %w", err)
+	}
+	after, err := ListFeatureFilesAtRef(gitRepo, upstreamCommit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read features on %s: %w", result.Upstream, err)
+	}
+	result.Incoming = DiffFeatureSets(before, after)
+	result.Incoming.From, result.Incoming.To = opts.Branch, result.Upstream
+	result.Incoming.FromCommit, result.Incoming.ToCommit = baseCommit, upstreamCommit
+	result.NewRelationships = newRelationships(before, after)
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	switch {
+	case baseCommit == headCommit:
+		err = gitRepo.FastForward(upstreamCommit)
+	case opts.FFOnly:
+		return result, ErrBranchDiverged
+	default:
+		err = gitRepo.Rebase(upstreamCommit)
+		result.Rebased = true
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Updated = true
+	return result, nil
+}
+
+// newRelationships returns the relationships present in after but not in before
+func newRelationships(before, after []FeatureFile) []RelationshipSummary {
+	existing := make(map[string]bool)
+	for _, file := range before {
+		for _, rel := range file.Feature.Relationships {
+			existing[file.Feature.ID+"\x00"+relationshipKey(rel)] = true
+		}
+	}
+
+	var added []RelationshipSummary
+	for _, file := range after {
+		f := file.Feature
+		for _, rel := range f.Relationships {
+			if existing[f.ID+"\x00"+relationshipKey(rel)] {
+				continue
+			}
+			added = append(added, RelationshipSummary{
+				SourceID:   f.ID,
+				SourceName: f.Name,
+				Type:       string(rel.Type),
+				TargetID:   rel.TargetID,
+				TargetName: rel.TargetName,
+			})
+		}
+	}
+	sort.Slice(added, func(i, j int) bool {
+		if added[i].SourceName != added[j].SourceName {
+			return added[i].SourceName < added[j].SourceName
+		}
+		return added[i].TargetName < added[j].TargetName
+	})
+	return added
+}
@@ -0,0 +1,131 @@
+package features
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/git"
+)
+
+// setupSyncTestRepos creates a bare remote and two clones of it: local, which
+// is synced, and upstream, which stands in for other people pushing
+func setupSyncTestRepos(t *testing.T) (localPath string, local *git.Repository, upstreamPath string) {
+	t.Helper()
+
+	localPath, local = setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, localPath, ".fogit/features/auth.yml", "id: auth-123\nname: Auth\n", "Add auth")
+	branch := gitInRepo(t, localPath, "rev-parse", "--abbrev-ref", "HEAD")
+
+	remotePath := filepath.Join(t.TempDir(), "remote.git")
+	gitInRepo(t, localPath, "init", "--bare", remotePath)
+	gitInRepo(t, localPath, "remote", "add", "origin", remotePath)
+	gitInRepo(t, localPath, "push", "-u", "origin", branch)
+
+	upstreamPath = filepath.Join(t.TempDir(), "upstream")
+	gitInRepo(t, localPath, "clone", remotePath, upstreamPath)
+	gitInRepo(t, upstreamPath, "config", "user.email", "other@example.com")
+	gitInRepo(t, upstreamPath, "config", "user.name", "Other User")
+	createTestCommitInRepo(t, upstreamPath, ".fogit/features/billing.yml", `id: billing-456
+name: Billing
+relationships:
+  - id: rel-1
+    type: depends-on
+    target_id: auth-123
+    target_name: Auth
+    created_at: 2025-01-01T00:00:00Z
+`, "Add billing")
+	createTestCommitInRepo(t, upstreamPath, ".fogit/features/auth.yml", "id: auth-123\nname: Authentication\n", "Rename auth")
+	gitInRepo(t, upstreamPath, "push", "origin", branch)
+
+	return localPath, local, upstreamPath
+}
+
+func TestSync(t *testing.T) {
+	t.Run("fast-forward", func(t *testing.T) {
+		localPath, local, _ := setupSyncTestRepos(t)
+
+		result, err := Sync(local, SyncOptions{})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if !result.Updated || result.Rebased || result.IncomingCommits != 2 {
+			t.Errorf("result = %+v, want a fast-forward of 2 commits", result)
+		}
+		if len(result.Incoming.Added) != 1 || result.Incoming.Added[0].Name != "Billing" {
+			t.Errorf("Added = %+v, want Billing", result.Incoming.Added)
+		}
+		if len(result.Incoming.Changed) != 1 || result.Incoming.Changed[0].Name != "Authentication" {
+			t.Errorf("Changed = %+v, want Authentication", result.Incoming.Changed)
+		}
+		if len(result.NewRelationships) != 1 || result.NewRelationships[0].TargetID != "auth-123" {
+			t.Errorf("NewRelationships = %+v, want Billing depends-on Auth", result.NewRelationships)
+		}
+		if gitInRepo(t, localPath, "rev-parse", "HEAD") != gitInRepo(t, localPath, "rev-parse", "@{upstream}") {
+			t.Error("expected HEAD to match the remote branch")
+		}
+
+		again, err := Sync(local, SyncOptions{})
+		if err != nil || !again.UpToDate {
+			t.Errorf("second Sync() = %+v, %v, want up to date", again, err)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		localPath, local, _ := setupSyncTestRepos(t)
+		head := gitInRepo(t, localPath, "rev-parse", "HEAD")
+
+		result, err := Sync(local, SyncOptions{DryRun: true})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if result.Updated || len(result.Incoming.Added) != 1 {
+			t.Errorf("result = %+v, want incoming changes without an update", result)
+		}
+		if gitInRepo(t, localPath, "rev-parse", "HEAD") != head {
+			t.Error("dry run moved HEAD")
+		}
+	})
+
+	t.Run("diverged", func(t *testing.T) {
+		localPath, local, _ := setupSyncTestRepos(t)
+		createTestCommitInRepo(t, localPath, ".fogit/features/search.yml", "id: search-789\nname: Search\n", "Add search")
+
+		if _, err := Sync(local, SyncOptions{FFOnly: true}); !errors.Is(err, ErrBranchDiverged) {
+			t.Fatalf("Sync(FFOnly) error = %v, want ErrBranchDiverged", err)
+		}
+
+		result, err := Sync(local, SyncOptions{})
+		if err != nil {
+			t.Fatalf("Sync() error = %v", err)
+		}
+		if !result.Rebased {
+			t.Errorf("result = %+v, want a rebase", result)
+		}
+		// The local commit sits on top of the remote branch
+		if gitInRepo(t, localPath, "rev-parse", "HEAD~1") != gitInRepo(t, localPath, "rev-parse", "@{upstream}") {
+			t.Error("expected the local commit to be rebased onto the remote branch")
+		}
+	})
+
+	t.Run("rebase conflict is aborted", func(t *testing.T) {
+		localPath, local, _ := setupSyncTestRepos(t)
+		createTestCommitInRepo(t, localPath, ".fogit/features/auth.yml", "id: auth-123\nname: Login\n", "Rename auth locally")
+		head := gitInRepo(t, localPath, "rev-parse", "HEAD")
+
+		if _, err := Sync(local, SyncOptions{}); !errors.Is(err, git.ErrRebaseConflict) {
+			t.Fatalf("Sync() error = %v, want ErrRebaseConflict", err)
+		}
+		if gitInRepo(t, localPath, "rev-parse", "HEAD") != head {
+			t.Error("expected the branch to be left as it was")
+		}
+	})
+
+	t.Run("unknown remote branch", func(t *testing.T) {
+		localPath, local, _ := setupSyncTestRepos(t)
+		gitInRepo(t, localPath, "checkout", "-b", "feature/unpushed")
+		if _, err := Sync(local, SyncOptions{}); err == nil {
+			t.Error("expected an error for a branch that isn't on the remote")
+		}
+	})
+}
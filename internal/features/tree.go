@@ -89,3 +89,33 @@ func FindChildren(parentID string, allFeatures []*fogit.Feature, hierarchyTypes
 	}
 	return children
 }
+
+// TreeNode is a feature with its children in the hierarchy
+type TreeNode struct {
+	Feature  *fogit.Feature `json:"feature"`
+	Children []*TreeNode    `json:"children,omitempty"`
+}
+
+// BuildTree builds the hierarchy below root, up to maxDepth levels (-1 = unlimited).
+// Features already on the current path are not descended into again.
+func BuildTree(root *fogit.Feature, allFeatures []*fogit.Feature, hierarchyTypes []string, maxDepth int) *TreeNode {
+	return buildTreeNode(root, allFeatures, hierarchyTypes, 0, maxDepth, map[string]bool{})
+}
+
+func buildTreeNode(feature *fogit.Feature, allFeatures []*fogit.Feature, hierarchyTypes []string, depth, maxDepth int, path map[string]bool) *TreeNode {
+	node := &TreeNode{Feature: feature}
+	if maxDepth >= 0 && depth >= maxDepth {
+		return node
+	}
+
+	path[feature.ID] = true
+	defer delete(path, feature.ID)
+
+	for _, child := range FindChildren(feature.ID, allFeatures, hierarchyTypes) {
+		if path[child.ID] {
+			continue
+		}
+		node.Children = append(node.Children, buildTreeNode(child, allFeatures, hierarchyTypes, depth+1, maxDepth, path))
+	}
+	return node
+}
@@ -0,0 +1,129 @@
+package features
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eg3r/fogit/internal/git"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// TreeRepository is a read-only fogit.Repository serving the features stored in
+// a Git commit, used for point-in-time queries. Features are read from the
+// commit's tree on first use; writes fail with fogit.ErrReadOnly.
+type TreeRepository struct {
+	gitRepo  *git.Repository
+	commit   string
+	once     sync.Once
+	features []*fogit.Feature
+	err      error
+}
+
+// NewTreeRepository creates a repository for the features at a commit
+func NewTreeRepository(gitRepo *git.Repository, commit string) *TreeRepository {
+	return &TreeRepository{gitRepo: gitRepo, commit: commit}
+}
+
+// Commit returns the commit features are read from
+func (r *TreeRepository) Commit() string {
+	return r.commit
+}
+
+func (r *TreeRepository) load() ([]*fogit.Feature, error) {
+	r.once.Do(func() {
+		files, err := ListFeatureFilesAtRef(r.gitRepo, r.commit)
+		if err != nil {
+			r.err = fmt.Errorf("failed to read features at %s: %w", r.commit, err)
+			return
+		}
+		for _, file := range files {
+			r.features = append(r.features, file.Feature)
+		}
+	})
+	return r.features, r.err
+}
+
+// Get retrieves a feature by ID
+func (r *TreeRepository) Get(ctx context.Context, id string) (*fogit.Feature, error) {
+	if id == "" {
+		return nil, fmt.Errorf("id cannot be empty")
+	}
+	all, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range all {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return nil, fogit.ErrNotFound
+}
+
+// List retrieves features matching the given filter
+func (r *TreeRepository) List(ctx context.Context, filter *fogit.Filter) ([]*fogit.Feature, error) {
+	all, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	// Check for cancellation (if context provided)
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+	features := []*fogit.Feature{}
+	for _, f := range all {
+		if filter == nil || filter.Matches(f) {
+			features = append(features, f)
+		}
+	}
+	return features, nil
+}
+
+// Create fails: historical features can't be modified
+func (r *TreeRepository) Create(ctx context.Context, feature *fogit.Feature) error {
+	return r.readOnly()
+}
+
+// Update fails: historical features can't be modified
+func (r *TreeRepository) Update(ctx context.Context, feature *fogit.Feature) error {
+	return r.readOnly()
+}
+
+// Delete fails: historical features can't be modified
+func (r *TreeRepository) Delete(ctx context.Context, id string) error {
+	return r.readOnly()
+}
+
+func (r *TreeRepository) readOnly() error {
+	return fmt.Errorf("cannot modify features at commit %.8s: %w", r.commit, fogit.ErrReadOnly)
+}
+
+// pointInTimeLayouts are the date formats accepted by ResolvePointInTime
+var pointInTimeLayouts = []string{time.RFC3339, "2006-01-02T15:04", "2006-01-02 15:04", "2006-01-02"}
+
+// ResolvePointInTime resolves a ref, tag, commit or date to a commit.
+// Refs take precedence; a date selects the last commit on HEAD's first-parent
+// line made at or before it, and a bare date (YYYY-MM-DD) means the end of that day.
+func ResolvePointInTime(gitRepo *git.Repository, spec string) (string, error) {
+	if hash, _, err := gitRepo.ResolveCommit(spec); err == nil {
+		return hash, nil
+	}
+
+	for _, layout := range pointInTimeLayouts {
+		t, err := time.ParseInLocation(layout, spec, time.Local)
+		if err != nil {
+			continue
+		}
+		if layout == "2006-01-02" {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		hash, _, err := gitRepo.ResolveCommitAt("HEAD", t)
+		return hash, err
+	}
+
+	return "", fmt.Errorf("unknown ref or date %q (dates use YYYY-MM-DD or RFC 3339)", spec)
+}
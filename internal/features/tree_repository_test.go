@@ -0,0 +1,80 @@
+package features
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestTreeRepository(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, ".fogit/features/auth.yml", `id: auth-123
+name: Auth
+tags: [security]
+versions:
+  "1":
+    created_at: 2025-01-01T00:00:00Z
+    modified_at: 2025-01-01T00:00:00Z
+`, "Add auth")
+	first := gitInRepo(t, repoPath, "rev-parse", "HEAD")
+	createTestCommitInRepo(t, repoPath, ".fogit/features/billing.yml", `id: billing-456
+name: Billing
+`, "Add billing")
+
+	ctx := context.Background()
+	repo := NewTreeRepository(gitRepo, first)
+
+	all, err := repo.List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(all) != 1 || all[0].ID != "auth-123" {
+		t.Fatalf("List() = %v, want only auth-123", all)
+	}
+
+	//nolint:staticcheck // List accepts a nil context like the other repositories
+	if all, err := repo.List(nil, nil); err != nil || len(all) != 1 {
+		t.Errorf("List(nil ctx) = %v, %v, want auth-123", all, err)
+	}
+
+	tagged, err := repo.List(ctx, &fogit.Filter{Tags: []string{"missing"}})
+	if err != nil || len(tagged) != 0 {
+		t.Errorf("List(filter) = %v, %v, want none", tagged, err)
+	}
+
+	if f, err := repo.Get(ctx, "auth-123"); err != nil || f.Name != "Auth" {
+		t.Errorf("Get() = %v, %v", f, err)
+	}
+	if _, err := repo.Get(ctx, "billing-456"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("Get(later feature) error = %v, want ErrNotFound", err)
+	}
+
+	if err := repo.Update(ctx, all[0]); !errors.Is(err, fogit.ErrReadOnly) {
+		t.Errorf("Update() error = %v, want ErrReadOnly", err)
+	}
+	if err := repo.Delete(ctx, "auth-123"); !errors.Is(err, fogit.ErrReadOnly) {
+		t.Errorf("Delete() error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestResolvePointInTime(t *testing.T) {
+	repoPath, gitRepo := setupCrossBranchTestRepo(t)
+	createTestCommitInRepo(t, repoPath, "README.md", "# Test", "Initial commit")
+	gitInRepo(t, repoPath, "tag", "v1.0.0")
+	head := gitInRepo(t, repoPath, "rev-parse", "HEAD")
+
+	for _, spec := range []string{"HEAD", "v1.0.0", head[:8], "2999-01-01"} {
+		got, err := ResolvePointInTime(gitRepo, spec)
+		if err != nil || got != head {
+			t.Errorf("ResolvePointInTime(%q) = %s, %v, want %s", spec, got, err, head)
+		}
+	}
+
+	for _, spec := range []string{"2000-01-01", "no-such-ref"} {
+		if _, err := ResolvePointInTime(gitRepo, spec); err == nil {
+			t.Errorf("ResolvePointInTime(%q) expected error", spec)
+		}
+	}
+}
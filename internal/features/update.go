@@ -19,6 +19,16 @@ type UpdateOptions struct {
 	Epic        *string
 	Module      *string
 	Metadata    map[string]interface{}
+
+	States   *fogit.StateMachine   // Workflow states for --state (nil = built-in states)
+	FogitDir string                // Used to run on_enter hooks (empty = hooks are skipped)
+	Schema   *fogit.MetadataSchema // Checked when metadata changes (nil = no schema)
+}
+
+// touchesMetadata reports whether the options change any metadata-backed field
+func (opts UpdateOptions) touchesMetadata() bool {
+	return opts.Priority != nil || opts.Type != nil || opts.Category != nil || opts.Domain != nil ||
+		opts.Team != nil || opts.Epic != nil || opts.Module != nil || len(opts.Metadata) > 0
 }
 
 func Update(ctx context.Context, repo fogit.Repository, feature *fogit.Feature, opts UpdateOptions) (bool, error) {
@@ -34,14 +44,21 @@ func Update(ctx context.Context, repo fogit.Repository, feature *fogit.Feature,
 		changed = true
 	}
 
+	states := opts.States
+	if states == nil {
+		states = fogit.DefaultStateMachine()
+	}
+
+	var enteredState, leftState fogit.State
 	if opts.State != nil && *opts.State != "" {
 		newState := fogit.State(*opts.State)
 		oldState := feature.DeriveState()
 		if newState != oldState {
-			if err := feature.UpdateState(newState); err != nil {
+			if err := feature.TransitionState(states, newState); err != nil {
 				return false, fmt.Errorf("failed to update state: %w", err)
 			}
-			// UpdateState already handles per-version timestamps
+			// TransitionState already handles per-version timestamps
+			enteredState, leftState = newState, oldState
 			changed = true
 		}
 	}
@@ -96,6 +113,13 @@ func Update(ctx context.Context, repo fogit.Repository, feature *fogit.Feature,
 	}
 
 	if changed {
+		// Features that predate the schema can still be edited; only metadata changes are checked
+		if opts.touchesMetadata() {
+			if err := opts.Schema.Enforce(feature); err != nil {
+				return false, err
+			}
+		}
+
 		feature.UpdateModifiedAt()
 
 		// Validate updated feature
@@ -108,5 +132,11 @@ func Update(ctx context.Context, repo fogit.Repository, feature *fogit.Feature,
 		}
 	}
 
+	if enteredState != "" && opts.FogitDir != "" {
+		if err := RunStateEntryHook(ctx, opts.FogitDir, states, feature, leftState, enteredState); err != nil {
+			return changed, fmt.Errorf("state changed to %s but %w", enteredState, err)
+		}
+	}
+
 	return changed, nil
 }
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"reflect"
 
 	"github.com/eg3r/fogit/pkg/fogit"
 )
@@ -58,6 +59,8 @@ func (af *AutoFixer) AttemptFixes(ctx context.Context, issues []ValidationIssue)
 			fixed, fixErr = af.fixMissingInverse(ctx, issue)
 		case CodeDanglingInverse:
 			fixed, fixErr = af.fixDanglingInverse(ctx, issue)
+		case CodeMetadataViolation:
+			fixed, fixErr = af.fixMetadataViolation(ctx, issue)
 		default:
 			result.Skipped = append(result.Skipped,
 				fmt.Sprintf("[%s] %s: no fix handler", issue.Code, issue.FileName))
@@ -235,6 +238,43 @@ func (af *AutoFixer) fixDanglingInverse(ctx context.Context, issue ValidationIss
 	return true, nil
 }
 
+// fixMetadataViolation applies metadata_schema defaults and type conversions
+func (af *AutoFixer) fixMetadataViolation(ctx context.Context, issue ValidationIssue) (bool, error) {
+	feature := af.featureMap[issue.FeatureID]
+	if feature == nil {
+		return false, fmt.Errorf("feature not found: %s", issue.FeatureID)
+	}
+
+	field := issue.Context["field"]
+	if field == "" {
+		return false, fmt.Errorf("missing field in issue context")
+	}
+
+	schema := af.config.MetadataSchema
+	fixedFeature := withSchemaDefaults(schema, feature)
+	for _, violation := range schema.Validate(fixedFeature) {
+		if violation.Field == field {
+			return false, fmt.Errorf("no schema default resolves field %s", field)
+		}
+	}
+
+	// Several issues on one feature share this fix; only write once
+	if reflect.DeepEqual(fixedFeature.Metadata, feature.Metadata) {
+		return true, nil
+	}
+
+	if af.dryRun {
+		return true, nil
+	}
+
+	feature.Metadata = fixedFeature.Metadata
+	if err := af.repo.Update(ctx, feature); err != nil {
+		return false, fmt.Errorf("failed to update feature: %w", err)
+	}
+
+	return true, nil
+}
+
 // IsDryRun returns true if the fixer is in dry-run mode
 func (af *AutoFixer) IsDryRun() bool {
 	return af.dryRun
@@ -2,6 +2,8 @@ package validator
 
 import (
 	"fmt"
+
+	"github.com/eg3r/fogit/pkg/fogit"
 )
 
 // checkOrphanedRelationships finds relationships pointing to non-existent features (E001)
@@ -215,3 +217,52 @@ func (v *Validator) checkVersionConstraints(result *ValidationResult) {
 		}
 	}
 }
+
+// checkMetadataSchema validates feature metadata against metadata_schema (E007)
+// Violations that applying schema defaults (or converting a string value to the
+// declared type) would resolve are fixable.
+func (v *Validator) checkMetadataSchema(result *ValidationResult) {
+	schema := v.config.MetadataSchema
+	if schema.IsEmpty() {
+		return
+	}
+
+	for _, feature := range v.features {
+		violations := schema.Validate(feature)
+		if len(violations) == 0 {
+			continue
+		}
+
+		remaining := make(map[string]bool)
+		for _, violation := range schema.Validate(withSchemaDefaults(schema, feature)) {
+			remaining[violation.Field] = true
+		}
+
+		fileName := GetFeatureFileName(feature.Name)
+		for _, violation := range violations {
+			result.Issues = append(result.Issues, ValidationIssue{
+				Code:        CodeMetadataViolation,
+				Severity:    SeverityError,
+				FeatureID:   feature.ID,
+				FeatureName: feature.Name,
+				FileName:    fileName,
+				Message:     fmt.Sprintf("Metadata field %s", violation.Error()),
+				Fixable:     !remaining[violation.Field],
+				Context: map[string]string{
+					"field": violation.Field,
+				},
+			})
+		}
+	}
+}
+
+// withSchemaDefaults returns a copy of the feature with schema defaults applied
+func withSchemaDefaults(schema *fogit.MetadataSchema, feature *fogit.Feature) *fogit.Feature {
+	fixed := *feature
+	fixed.Metadata = make(map[string]interface{}, len(feature.Metadata))
+	for k, val := range feature.Metadata {
+		fixed.Metadata[k] = val
+	}
+	schema.ApplyDefaults(&fixed)
+	return &fixed
+}
@@ -10,6 +10,7 @@ const (
 	CodeSchemaViolation            IssueCode = "E004"
 	CodeCycleViolation             IssueCode = "E005"
 	CodeVersionConstraintViolation IssueCode = "E006"
+	CodeMetadataViolation          IssueCode = "E007"
 )
 
 // IssueCodeDescriptions provides human-readable descriptions for each code
@@ -20,6 +21,7 @@ var IssueCodeDescriptions = map[IssueCode]string{
 	CodeSchemaViolation:            "Schema violation - invalid relationship structure",
 	CodeCycleViolation:             "Cycle violation - cycles in categories where not allowed",
 	CodeVersionConstraintViolation: "Version constraint violation - target version doesn't satisfy constraint",
+	CodeMetadataViolation:          "Metadata violation - feature metadata doesn't match metadata_schema",
 }
 
 // Severity represents issue severity
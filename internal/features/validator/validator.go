@@ -48,6 +48,7 @@ func (v *Validator) ValidateFeatures(ctx context.Context, features []*fogit.Feat
 	v.checkSchemaViolations(result)      // E004
 	v.checkCycles(result)                // E005
 	v.checkVersionConstraints(result)    // E006
+	v.checkMetadataSchema(result)        // E007
 
 	// Count by severity
 	for _, issue := range result.Issues {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/eg3r/fogit/internal/storage"
 	"github.com/eg3r/fogit/pkg/fogit"
 )
 
@@ -156,3 +157,51 @@ func TestValidationResult_HasFixableIssues(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_MetadataSchema(t *testing.T) {
+	ctx := context.Background()
+	repo := storage.NewFileRepository(t.TempDir())
+	cfg := fogit.DefaultConfig()
+	cfg.MetadataSchema = &fogit.MetadataSchema{
+		Fields: map[string]fogit.MetadataField{
+			"sla":   {Type: fogit.MetadataTypeEnum, Values: []string{"gold", "silver"}, Required: true, Default: "silver"},
+			"owner": {Type: fogit.MetadataTypeString, Required: true},
+		},
+	}
+
+	feature := fogit.NewFeature("Legacy")
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	result, err := New(repo, cfg).ValidateFeatures(ctx, []*fogit.Feature{feature})
+	if err != nil {
+		t.Fatalf("ValidateFeatures() error = %v", err)
+	}
+
+	fixable := make(map[string]bool)
+	for _, issue := range result.Issues {
+		if issue.Code == CodeMetadataViolation {
+			fixable[issue.Context["field"]] = issue.Fixable
+		}
+	}
+	if len(fixable) != 2 || !fixable["sla"] || fixable["owner"] {
+		t.Fatalf("E007 issues fixable = %v, want sla fixable and owner not", fixable)
+	}
+
+	fixResult, err := NewAutoFixer(repo, cfg, false).AttemptFixes(ctx, result.Issues)
+	if err != nil {
+		t.Fatalf("AttemptFixes() error = %v", err)
+	}
+	if fixResult.TotalFixed() != 1 || len(fixResult.Skipped) != 1 {
+		t.Errorf("fixed %v, skipped %v; want sla fixed and owner skipped", fixResult.Fixed, fixResult.Skipped)
+	}
+
+	saved, err := repo.Get(ctx, feature.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if saved.Metadata["sla"] != "silver" {
+		t.Errorf("sla after fix = %v, want silver", saved.Metadata["sla"])
+	}
+}
@@ -0,0 +1,270 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ErrStopWalk can be returned from a WalkHistory callback to stop the walk early
+var ErrStopWalk = errors.New("stop walk")
+
+// FileChange is a path changed by a commit relative to its first parent
+type FileChange struct {
+	From     string // Path before the change, empty if added
+	To       string // Path after the change, empty if deleted
+	FromBlob string // Blob hash before the change
+	ToBlob   string // Blob hash after the change
+}
+
+// CommitChanges is a commit together with the files it changed
+type CommitChanges struct {
+	Hash    string
+	Author  string
+	Email   string
+	Date    time.Time
+	Message string
+	Parents []string // Parent commit hashes, first parent first
+	Changes []FileChange
+}
+
+// WalkHistory calls fn for every commit reachable from any ref (branches, remote
+// branches and tags), newest first. Each commit is visited once; merge commits
+// are compared with their first parent.
+//
+// Only changes under paths are reported: a path ending in "/" covers a
+// directory, any other path a single file. Comparing only those paths keeps the
+// walk cheap in large repositories. No paths reports every change.
+func (r *Repository) WalkHistory(ctx context.Context, paths []string, fn func(*CommitChanges) error) error {
+	return r.walkLog(ctx, &git.LogOptions{All: true, Order: git.LogOrderCommitterTime}, paths, fn)
+}
+
+// WalkHistoryFrom is like WalkHistory but only visits commits reachable from rev
+func (r *Repository) WalkHistoryFrom(ctx context.Context, rev string, paths []string, fn func(*CommitChanges) error) error {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	return r.walkLog(ctx, &git.LogOptions{From: *hash, Order: git.LogOrderCommitterTime}, paths, fn)
+}
+
+func (r *Repository) walkLog(ctx context.Context, opts *git.LogOptions, paths []string, fn func(*CommitChanges) error) error {
+	commits, err := r.repo.Log(opts)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil // No commits yet
+		}
+		return fmt.Errorf("failed to get log: %w", err)
+	}
+
+	err = commits.ForEach(func(c *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		tree, err := c.Tree()
+		if err != nil {
+			return fmt.Errorf("failed to read tree of %s: %w", c.Hash, err)
+		}
+		var parentTree *object.Tree
+		if c.NumParents() > 0 {
+			parent, err := c.Parent(0)
+			if err != nil {
+				return fmt.Errorf("failed to read parent of %s: %w", c.Hash, err)
+			}
+			if parentTree, err = parent.Tree(); err != nil {
+				return fmt.Errorf("failed to read tree of %s: %w", parent.Hash, err)
+			}
+		}
+
+		changes, err := diffPaths(ctx, parentTree, tree, paths)
+		if err != nil {
+			return fmt.Errorf("failed to diff %s: %w", c.Hash, err)
+		}
+
+		entry := &CommitChanges{
+			Hash:    c.Hash.String(),
+			Author:  c.Author.Name,
+			Email:   c.Author.Email,
+			Date:    c.Author.When,
+			Message: strings.TrimSpace(c.Message),
+		}
+		for _, p := range c.ParentHashes {
+			entry.Parents = append(entry.Parents, p.String())
+		}
+		entry.Changes = changes
+		return fn(entry)
+	})
+	if err != nil && !errors.Is(err, ErrStopWalk) {
+		return err
+	}
+	return nil
+}
+
+// diffPaths compares two trees at the given paths (see WalkHistory); a nil
+// tree is empty. Directories whose tree hashes match are skipped unread.
+func diffPaths(ctx context.Context, from, to *object.Tree, paths []string) ([]FileChange, error) {
+	if len(paths) == 0 {
+		return diffTrees(ctx, from, to, "")
+	}
+
+	var changes []FileChange
+	for _, p := range paths {
+		if dir, ok := strings.CutSuffix(p, "/"); ok {
+			fromDir, err := subtree(from, dir)
+			if err != nil {
+				return nil, err
+			}
+			toDir, err := subtree(to, dir)
+			if err != nil {
+				return nil, err
+			}
+			if fromDir == nil && toDir == nil || fromDir != nil && toDir != nil && fromDir.Hash == toDir.Hash {
+				continue
+			}
+			dirChanges, err := diffTrees(ctx, fromDir, toDir, p)
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, dirChanges...)
+			continue
+		}
+
+		fromBlob, toBlob := fileBlob(from, p), fileBlob(to, p)
+		if fromBlob == toBlob {
+			continue
+		}
+		fc := FileChange{FromBlob: fromBlob, ToBlob: toBlob}
+		if fromBlob != "" {
+			fc.From = p
+		}
+		if toBlob != "" {
+			fc.To = p
+		}
+		changes = append(changes, fc)
+	}
+	return changes, nil
+}
+
+// diffTrees lists the files changed between two trees, with paths prefixed by prefix
+func diffTrees(ctx context.Context, from, to *object.Tree, prefix string) ([]FileChange, error) {
+	diff, err := object.DiffTreeWithOptions(ctx, from, to, nil)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]FileChange, 0, len(diff))
+	for _, change := range diff {
+		var fc FileChange
+		if change.From.Name != "" {
+			fc.From = prefix + change.From.Name
+			fc.FromBlob = change.From.TreeEntry.Hash.String()
+		}
+		if change.To.Name != "" {
+			fc.To = prefix + change.To.Name
+			fc.ToBlob = change.To.TreeEntry.Hash.String()
+		}
+		changes = append(changes, fc)
+	}
+	return changes, nil
+}
+
+// subtree returns the tree at dir, or nil when tree is nil or has no such directory
+func subtree(tree *object.Tree, dir string) (*object.Tree, error) {
+	if tree == nil {
+		return nil, nil
+	}
+	sub, err := tree.Tree(dir)
+	if errors.Is(err, object.ErrDirectoryNotFound) {
+		return nil, nil
+	}
+	return sub, err
+}
+
+// fileBlob returns the blob hash of the file at path, or "" when there is none
+func fileBlob(tree *object.Tree, path string) string {
+	if tree == nil {
+		return ""
+	}
+	entry, err := tree.FindEntry(path)
+	if err != nil || !entry.Mode.IsFile() {
+		return ""
+	}
+	return entry.Hash.String()
+}
+
+// ReadBlob returns the content of a blob by hash
+func (r *Repository) ReadBlob(hash string) ([]byte, error) {
+	blob, err := r.repo.BlobObject(plumbing.NewHash(hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob %s: %w", hash, err)
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// TreeBlob is a file in a Git tree, identified by its blob hash
+type TreeBlob struct {
+	Path string // Relative to the tree that was listed
+	Hash string
+}
+
+// ResolveTree returns the commit a revision points at and the hash of the tree at
+// path in that commit. The tree hash is empty when the path doesn't exist there.
+func (r *Repository) ResolveTree(rev, path string) (string, string, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", "", fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	commit, err := r.repo.CommitObject(*hash)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read tree of %s: %w", rev, err)
+	}
+	subtree, err := tree.Tree(path)
+	if err != nil {
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return commit.Hash.String(), "", nil
+		}
+		return "", "", fmt.Errorf("failed to read %s on %s: %w", path, rev, err)
+	}
+	return commit.Hash.String(), subtree.Hash.String(), nil
+}
+
+// ListTreeBlobs lists the files under a tree, recursively
+func (r *Repository) ListTreeBlobs(treeHash string) ([]TreeBlob, error) {
+	tree, err := r.repo.TreeObject(plumbing.NewHash(treeHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree %s: %w", treeHash, err)
+	}
+
+	var blobs []TreeBlob
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk tree %s: %w", treeHash, err)
+		}
+		if entry.Mode.IsFile() {
+			blobs = append(blobs, TreeBlob{Path: name, Hash: entry.Hash.String()})
+		}
+	}
+	return blobs, nil
+}
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestWalkHistory_Paths(t *testing.T) {
+	tmpDir := t.TempDir()
+	gitRepo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := gitRepo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	when := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(path, content, message string) {
+		t.Helper()
+		full := filepath.Join(tmpDir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Add(path); err != nil {
+			t.Fatal(err)
+		}
+		when = when.Add(time.Minute)
+		sig := &object.Signature{Name: "Alice", Email: "alice@example.com", When: when}
+		if _, err := w.Commit(message, &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	commit(".fogit/features/auth.yml", "id: a", "Add feature")
+	commit("src/auth.go", "package auth", "Implement auth")
+	commit("src/other.go", "package other", "Unrelated change")
+	commit(".fogit/features/auth.yml", "id: a\nname: Auth", "Update feature")
+
+	repo, err := OpenRepository(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	walk := func(paths []string) string {
+		t.Helper()
+		var lines []string
+		err := repo.WalkHistory(context.Background(), paths, func(c *CommitChanges) error {
+			var changed []string
+			for _, change := range c.Changes {
+				changed = append(changed, change.From+">"+change.To)
+			}
+			lines = append(lines, c.Message+": "+strings.Join(changed, " "))
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkHistory() error = %v", err)
+		}
+		return strings.Join(lines, "; ")
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  string
+	}{
+		{
+			name: "every change",
+			want: "Update feature: .fogit/features/auth.yml>.fogit/features/auth.yml; " +
+				"Unrelated change: >src/other.go; Implement auth: >src/auth.go; Add feature: >.fogit/features/auth.yml",
+		},
+		{
+			name:  "directory and file",
+			paths: []string{".fogit/features/", "src/auth.go"},
+			want: "Update feature: .fogit/features/auth.yml>.fogit/features/auth.yml; " +
+				"Unrelated change: ; Implement auth: >src/auth.go; Add feature: >.fogit/features/auth.yml",
+		},
+		{
+			name:  "missing paths",
+			paths: []string{"docs/", "README.md"},
+			want:  "Update feature: ; Unrelated change: ; Implement auth: ; Add feature: ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := walk(tt.paths); got != tt.want {
+				t.Errorf("walk = %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
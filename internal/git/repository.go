@@ -335,6 +335,58 @@ func (r *Repository) HasConflicts() (bool, error) {
 	return false, nil
 }
 
+// ConflictedFiles returns the paths with unresolved merge conflicts
+func (r *Repository) ConflictedFiles() ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", "--diff-filter=U")
+	cmd.Dir = r.path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list conflicted files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+// ReadConflictStage reads one side of a conflicted file from the index:
+// stage 1 is the common ancestor, 2 is ours and 3 is theirs.
+// Returns ErrFileNotFound if the side doesn't have the file (e.g. added or deleted).
+func (r *Repository) ReadConflictStage(path string, stage int) ([]byte, error) {
+	if stage < 1 || stage > 3 {
+		return nil, fmt.Errorf("invalid conflict stage: %d", stage)
+	}
+	normalizedPath := strings.ReplaceAll(path, "\\", "/")
+	if strings.Contains(normalizedPath, "..") {
+		return nil, fmt.Errorf("invalid path: contains parent directory reference")
+	}
+
+	cmd := exec.Command("git", "show", fmt.Sprintf(":%d:%s", stage, normalizedPath)) // #nosec G204 - path validated above
+	cmd.Dir = r.path
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+	return output, nil
+}
+
+// StageFile adds a file to the index, marking a conflict as resolved
+func (r *Repository) StageFile(path string) error {
+	cmd := exec.Command("git", "add", "--", path) // #nosec G204 - path passed as a single argument after --
+	cmd.Dir = r.path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stage %s: %s", path, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
 // AbortMerge aborts the current merge operation
 func (r *Repository) AbortMerge() error {
 	cmd := exec.Command("git", "merge", "--abort")
@@ -374,6 +426,89 @@ func (r *Repository) Push(remoteName string) error {
 	return nil
 }
 
+// Fetch downloads objects and refs from the named remote, updating its remote-tracking branches
+func (r *Repository) Fetch(remoteName string) error {
+	err := r.repo.Fetch(&git.FetchOptions{
+		RemoteName: remoteName,
+	})
+	if err != nil {
+		if errors.Is(err, git.NoErrAlreadyUpToDate) {
+			return nil // Not an error, just up to date
+		}
+		return fmt.Errorf("failed to fetch: %w", err)
+	}
+
+	return nil
+}
+
+// ErrRebaseConflict is returned when a rebase stopped on conflicts and was aborted
+var ErrRebaseConflict = errors.New("rebase conflict")
+
+// FastForward advances the current branch to rev, failing if it isn't a descendant of HEAD
+func (r *Repository) FastForward(rev string) error {
+	if !isValidGitRef(rev) {
+		return fmt.Errorf("invalid revision: %s", rev)
+	}
+
+	cmd := exec.Command("git", "merge", "--ff-only", rev) // #nosec G204 - rev validated above
+	cmd.Dir = r.path
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("fast-forward failed: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Rebase replays the current branch's commits onto rev. If the rebase stops on
+// conflicts it is aborted, leaving the branch as it was, and ErrRebaseConflict is returned.
+func (r *Repository) Rebase(rev string) error {
+	if !isValidGitRef(rev) {
+		return fmt.Errorf("invalid revision: %s", rev)
+	}
+	return r.runRebase(rev)
+}
+
+// RebaseOnto replays the commits of branch that are not in upstream onto newBase
+// (git rebase --onto), leaving branch checked out. Conflicts are handled as in Rebase.
+func (r *Repository) RebaseOnto(newBase, upstream, branch string) error {
+	for _, rev := range []string{newBase, upstream, branch} {
+		if !isValidGitRef(rev) {
+			return fmt.Errorf("invalid revision: %s", rev)
+		}
+	}
+	return r.runRebase("--onto", newBase, upstream, branch)
+}
+
+// runRebase runs git rebase, aborting it if it stops on conflicts
+func (r *Repository) runRebase(args ...string) error {
+	cmd := exec.Command("git", append([]string{"rebase"}, args...)...) // #nosec G204 - revisions validated by callers
+	cmd.Dir = r.path
+
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+	if r.isRebasing() {
+		abort := exec.Command("git", "rebase", "--abort")
+		abort.Dir = r.path
+		if abortOutput, abortErr := abort.CombinedOutput(); abortErr != nil {
+			return fmt.Errorf("rebase stopped on conflicts and could not be aborted: %s", strings.TrimSpace(string(abortOutput)))
+		}
+		return ErrRebaseConflict
+	}
+	return fmt.Errorf("rebase failed: %s", strings.TrimSpace(string(output)))
+}
+
+// isRebasing checks for the state directories git keeps during a rebase
+func (r *Repository) isRebasing() bool {
+	for _, dir := range []string{"rebase-merge", "rebase-apply"} {
+		if _, err := os.Stat(filepath.Join(r.path, ".git", dir)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
 // GetRemotes returns the list of configured remotes
 func (r *Repository) GetRemotes() ([]string, error) {
 	remotes, err := r.repo.Remotes()
@@ -558,6 +693,20 @@ func (r *Repository) GetLog(path string, author string, since *time.Time, limit
 		logOpts.FileName = &path
 	}
 
+	return r.filteredLog(logOpts, author, since, nil, limit)
+}
+
+// GetLogByTrailer returns the commits whose message has the trailer key: value,
+// with the same author, since and limit filters as GetLog
+func (r *Repository) GetLogByTrailer(key, value string, author string, since *time.Time, limit int) ([]CommitLog, error) {
+	match := func(c *object.Commit) bool {
+		return HasTrailer(c.Message, key, value)
+	}
+	return r.filteredLog(&git.LogOptions{}, author, since, match, limit)
+}
+
+// filteredLog walks the log and returns the commits passing the author, since and match filters
+func (r *Repository) filteredLog(logOpts *git.LogOptions, author string, since *time.Time, match func(*object.Commit) bool, limit int) ([]CommitLog, error) {
 	commits, err := r.repo.Log(logOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get log: %w", err)
@@ -584,35 +733,11 @@ func (r *Repository) GetLog(path string, author string, since *time.Time, limit
 			return nil // Skip this commit
 		}
 
-		// Count files changed
-		fileCount := 0
-		if c.NumParents() > 0 {
-			parent, err := c.Parent(0)
-			if err == nil {
-				patch, err := parent.Patch(c)
-				if err == nil {
-					fileCount = len(patch.FilePatches())
-				}
-			}
-		} else {
-			// First commit - count all files
-			tree, err := c.Tree()
-			if err == nil {
-				_ = tree.Files().ForEach(func(_ *object.File) error {
-					fileCount++
-					return nil
-				})
-			}
+		if match != nil && !match(c) {
+			return nil
 		}
 
-		logs = append(logs, CommitLog{
-			Hash:    c.Hash.String(),
-			Author:  fmt.Sprintf("%s <%s>", c.Author.Name, c.Author.Email),
-			Email:   c.Author.Email,
-			Date:    c.Author.When,
-			Message: strings.TrimSpace(c.Message),
-			Files:   fileCount,
-		})
+		logs = append(logs, newCommitLog(c))
 
 		count++
 		return nil
@@ -625,6 +750,147 @@ func (r *Repository) GetLog(path string, author string, since *time.Time, limit
 	return logs, nil
 }
 
+// newCommitLog converts a commit to a log entry, counting the files it changed
+func newCommitLog(c *object.Commit) CommitLog {
+	fileCount := 0
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err == nil {
+			patch, err := parent.Patch(c)
+			if err == nil {
+				fileCount = len(patch.FilePatches())
+			}
+		}
+	} else {
+		// First commit - count all files
+		tree, err := c.Tree()
+		if err == nil {
+			_ = tree.Files().ForEach(func(_ *object.File) error {
+				fileCount++
+				return nil
+			})
+		}
+	}
+
+	return CommitLog{
+		Hash:    c.Hash.String(),
+		Author:  fmt.Sprintf("%s <%s>", c.Author.Name, c.Author.Email),
+		Email:   c.Author.Email,
+		Date:    c.Author.When,
+		Message: strings.TrimSpace(c.Message),
+		Files:   fileCount,
+	}
+}
+
+// ResolveCommit resolves a branch, tag or revision expression (e.g. HEAD~2) to a commit.
+// Returns the full commit hash and the commit time.
+func (r *Repository) ResolveCommit(ref string) (string, time.Time, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("unknown revision %s: %w", ref, err)
+	}
+	commit, err := r.repo.CommitObject(*hash)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read commit %s: %w", ref, err)
+	}
+	return commit.Hash.String(), commit.Committer.When, nil
+}
+
+// MergeBase returns the best common ancestor of two revisions, like git merge-base
+func (r *Repository) MergeBase(a, b string) (string, error) {
+	commits := make([]*object.Commit, 2)
+	for i, rev := range []string{a, b} {
+		hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+		if err != nil {
+			return "", fmt.Errorf("unknown revision %s: %w", rev, err)
+		}
+		if commits[i], err = r.repo.CommitObject(*hash); err != nil {
+			return "", fmt.Errorf("failed to read commit %s: %w", rev, err)
+		}
+	}
+	bases, err := commits[0].MergeBase(commits[1])
+	if err != nil {
+		return "", fmt.Errorf("failed to find merge base of %s and %s: %w", a, b, err)
+	}
+	if len(bases) == 0 {
+		return "", fmt.Errorf("%s and %s have no common ancestor", a, b)
+	}
+	return bases[0].Hash.String(), nil
+}
+
+// ResolveCommitAt returns the last commit on rev's first-parent line that was
+// committed at or before t, i.e. the state of that line of history at time t
+func (r *Repository) ResolveCommitAt(rev string, t time.Time) (string, time.Time, error) {
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("unknown revision %s: %w", rev, err)
+	}
+	commit, err := r.repo.CommitObject(*hash)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to read commit %s: %w", rev, err)
+	}
+	for commit.Committer.When.After(t) {
+		if commit.NumParents() == 0 {
+			return "", time.Time{}, fmt.Errorf("no commits on %s before %s", rev, t.Format(time.RFC3339))
+		}
+		if commit, err = commit.Parent(0); err != nil {
+			return "", time.Time{}, fmt.Errorf("failed to read parent commit: %w", err)
+		}
+	}
+	return commit.Hash.String(), commit.Committer.When, nil
+}
+
+// GetLogRange returns the commits reachable from to but not from from, like git log from..to.
+// from may be empty to include all history. path optionally limits the log to commits touching it.
+func (r *Repository) GetLogRange(from, to, path string) ([]CommitLog, error) {
+	toHash, err := r.repo.ResolveRevision(plumbing.Revision(to))
+	if err != nil {
+		return nil, fmt.Errorf("unknown revision %s: %w", to, err)
+	}
+
+	// Collect everything reachable from the start of the range so it can be excluded
+	excluded := make(map[plumbing.Hash]bool)
+	if from != "" {
+		fromHash, err := r.repo.ResolveRevision(plumbing.Revision(from))
+		if err != nil {
+			return nil, fmt.Errorf("unknown revision %s: %w", from, err)
+		}
+		fromCommit, err := r.repo.CommitObject(*fromHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", from, err)
+		}
+		err = object.NewCommitPreorderIter(fromCommit, nil, nil).ForEach(func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate commits: %w", err)
+		}
+	}
+
+	logOpts := &git.LogOptions{From: *toHash}
+	if path != "" {
+		logOpts.FileName = &path
+	}
+	commits, err := r.repo.Log(logOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+
+	var logs []CommitLog
+	err = commits.ForEach(func(c *object.Commit) error {
+		if !excluded[c.Hash] {
+			logs = append(logs, newCommitLog(c))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate commits: %w", err)
+	}
+
+	return logs, nil
+}
+
 // TagInfo represents a Git tag
 type TagInfo struct {
 	Name    string
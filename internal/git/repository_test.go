@@ -359,6 +359,36 @@ func TestGetLog(t *testing.T) {
 		}
 	})
 
+	t.Run("range excludes commits reachable from start", func(t *testing.T) {
+		logs, err := repo.GetLogRange(commit1.String(), "HEAD", "test.txt")
+		if err != nil {
+			t.Fatalf("GetLogRange failed: %v", err)
+		}
+		if len(logs) != 2 || logs[1].Hash != commit2.String() {
+			t.Errorf("Expected third and second commit, got %+v", logs)
+		}
+
+		logs, err = repo.GetLogRange("", commit2.String(), "")
+		if err != nil {
+			t.Fatalf("GetLogRange failed: %v", err)
+		}
+		if len(logs) != 2 || logs[0].Hash != commit2.String() {
+			t.Errorf("Expected second and first commit, got %+v", logs)
+		}
+
+		hash, _, err := repo.ResolveCommit("HEAD~2")
+		if err != nil {
+			t.Fatalf("ResolveCommit failed: %v", err)
+		}
+		if hash != commit1.String() {
+			t.Errorf("Expected HEAD~2 to resolve to %s, got %s", commit1.String(), hash)
+		}
+
+		if _, err := repo.GetLogRange("no-such-ref", "HEAD", ""); err == nil {
+			t.Error("Expected error for unknown revision")
+		}
+	})
+
 	t.Run("empty results for non-existent file", func(t *testing.T) {
 		logs, err := repo.GetLog("nonexistent.txt", "", nil, 0)
 		if err != nil {
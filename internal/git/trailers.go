@@ -0,0 +1,75 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Trailer is a "Key: value" line in the last paragraph of a commit message
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+var trailerLinePattern = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*):\s*(.*)$`)
+
+// ParseTrailers returns the trailers of a commit message in order.
+// Only the last paragraph counts, and only if every line in it is a trailer.
+func ParseTrailers(message string) []Trailer {
+	message = strings.TrimRight(message, "\n\r\t ")
+	idx := strings.LastIndex(message, "\n\n")
+	if idx < 0 {
+		return nil // Subject line only
+	}
+
+	var trailers []Trailer
+	for _, line := range strings.Split(message[idx+2:], "\n") {
+		m := trailerLinePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			return nil
+		}
+		trailers = append(trailers, Trailer{Key: m[1], Value: strings.TrimSpace(m[2])})
+	}
+	return trailers
+}
+
+// AppendTrailers adds trailers to a commit message, extending an existing trailer
+// block if there is one. Trailers already present are not repeated.
+func AppendTrailers(message string, trailers []Trailer) string {
+	message = strings.TrimRight(message, "\n\r\t ")
+	existing := ParseTrailers(message)
+
+	var lines []string
+	for _, t := range trailers {
+		dup := false
+		for _, e := range existing {
+			if strings.EqualFold(e.Key, t.Key) && e.Value == t.Value {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			lines = append(lines, t.Key+": "+t.Value)
+		}
+	}
+	if len(lines) == 0 {
+		return message
+	}
+
+	separator := "\n\n"
+	if len(existing) > 0 {
+		separator = "\n"
+	}
+	return message + separator + strings.Join(lines, "\n")
+}
+
+// HasTrailer reports whether a commit message has a trailer with the given key and value.
+// Keys are compared case-insensitively, as git does.
+func HasTrailer(message, key, value string) bool {
+	for _, t := range ParseTrailers(message) {
+		if strings.EqualFold(t.Key, key) && t.Value == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package git
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Trailer
+	}{
+		{"subject only", "Fix: login timeout", nil},
+		{"body without trailers", "Subject\n\nSome explanation.", nil},
+		{
+			name:    "trailer block",
+			message: "Subject\n\nBody text.\n\nFogit-Feature: abc\nfogit-version:  2\n",
+			want:    []Trailer{{Key: "Fogit-Feature", Value: "abc"}, {Key: "fogit-version", Value: "2"}},
+		},
+		{"mixed last paragraph", "Subject\n\nFogit-Feature: abc\nnot a trailer", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseTrailers(tt.message); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseTrailers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendTrailers(t *testing.T) {
+	trailers := []Trailer{{Key: "Fogit-Feature", Value: "abc"}, {Key: "Fogit-Version", Value: "2"}}
+
+	tests := []struct {
+		name    string
+		message string
+		want    string
+	}{
+		{"subject only", "Add login\n", "Add login\n\nFogit-Feature: abc\nFogit-Version: 2"},
+		{"with body", "Add login\n\nDetails.", "Add login\n\nDetails.\n\nFogit-Feature: abc\nFogit-Version: 2"},
+		{
+			name:    "extends existing trailers",
+			message: "Add login\n\nSigned-off-by: A <a@b>",
+			want:    "Add login\n\nSigned-off-by: A <a@b>\nFogit-Feature: abc\nFogit-Version: 2",
+		},
+		{
+			name:    "skips duplicates",
+			message: "Add login\n\nFogit-Feature: abc",
+			want:    "Add login\n\nFogit-Feature: abc\nFogit-Version: 2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := AppendTrailers(tt.message, trailers)
+			if got != tt.want {
+				t.Errorf("AppendTrailers() = %q, want %q", got, tt.want)
+			}
+			if !HasTrailer(got, "fogit-feature", "abc") {
+				t.Error("HasTrailer() = false, want true")
+			}
+		})
+	}
+}
@@ -188,8 +188,10 @@ func formatStateLabel(state fogit.State) string {
 		return "(closed)"
 	case fogit.StateInProgress:
 		return "(in progress)"
-	default:
+	case fogit.StateOpen, "":
 		return "(open)"
+	default:
+		return "(" + string(state) + ")"
 	}
 }
 
@@ -0,0 +1,52 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/eg3r/fogit/internal/features"
+)
+
+// OutputBlameText writes a blame report as a table, one row per field.
+// With a timeline, every change is listed under its field.
+func OutputBlameText(w io.Writer, blame *features.FeatureBlame) error {
+	fmt.Fprintf(w, "Blame for %s (%s) at %s\n\n", blame.Name, blame.ID, blame.Ref)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE\tCOMMIT\tAUTHOR\tDATE\tSUBJECT")
+	for _, f := range blame.Fields {
+		commit, date := "uncommitted", ""
+		if f.Commit != "" {
+			commit = shortHash(f.Commit)
+			date = f.Date.Format("2006-01-02")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			f.Field, truncate(f.Value, 40), commit, f.Author, date, truncate(f.Subject, 50))
+
+		for _, ch := range f.History {
+			fmt.Fprintf(tw, "\t  %s %s\t%s\t%s\t%s\t%s\n",
+				features.GetChangeSymbol(ch.ChangeType), describeChange(ch),
+				shortHash(ch.Commit), ch.Author, ch.Date.Format("2006-01-02"), truncate(ch.Subject, 50))
+		}
+	}
+	return tw.Flush()
+}
+
+func describeChange(ch *features.FieldChange) string {
+	switch ch.ChangeType {
+	case "added":
+		return truncate(ch.NewValue, 36)
+	case "removed":
+		return truncate(ch.OldValue, 36)
+	default:
+		return truncate(ch.OldValue, 17) + " -> " + truncate(ch.NewValue, 17)
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
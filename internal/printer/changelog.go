@@ -0,0 +1,64 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// Changelog output formats
+const (
+	ChangelogFormatMarkdown = "markdown"
+	ChangelogFormatJSON     = "json"
+)
+
+// OutputChangelogMarkdown writes release notes as Markdown.
+// entryTemplate renders each entry unless its section has its own template;
+// empty uses fogit.DefaultChangelogEntryTemplate.
+func OutputChangelogMarkdown(w io.Writer, changelog *features.Changelog, entryTemplate string) error {
+	if entryTemplate == "" {
+		entryTemplate = fogit.DefaultChangelogEntryTemplate
+	}
+	defaultTmpl, err := template.New("entry").Parse(entryTemplate)
+	if err != nil {
+		return fmt.Errorf("invalid entry template: %w", err)
+	}
+
+	title := changelog.To
+	if title == "HEAD" {
+		title = "Unreleased"
+	}
+	fmt.Fprintf(w, "## %s (%s)\n", title, changelog.Date.Format("2006-01-02"))
+	if changelog.From != "" {
+		fmt.Fprintf(w, "\nChanges since %s.\n", changelog.From)
+	}
+
+	if len(changelog.Sections) == 0 {
+		fmt.Fprintln(w, "\nNo feature versions were closed in this range.")
+		return nil
+	}
+
+	for _, section := range changelog.Sections {
+		tmpl := defaultTmpl
+		if section.Template != "" {
+			tmpl, err = template.New("entry").Parse(section.Template)
+			if err != nil {
+				return fmt.Errorf("invalid template for section %q: %w", section.Title, err)
+			}
+		}
+
+		fmt.Fprintf(w, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			var line strings.Builder
+			if err := tmpl.Execute(&line, entry); err != nil {
+				return fmt.Errorf("failed to render %s: %w", entry.Name, err)
+			}
+			fmt.Fprintln(w, strings.TrimRight(line.String(), "\n"))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eg3r/fogit/internal/features"
+)
+
+func TestOutputChangelogMarkdown(t *testing.T) {
+	changelog := &features.Changelog{
+		From: "v1.0.0",
+		To:   "v1.1.0",
+		Date: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Sections: []*features.ChangelogSection{
+			{Title: "Features", Entries: []*features.ChangelogEntry{{Name: "Login", Version: "2", Notes: "OAuth support"}}},
+			{Title: "Fixes", Template: "- Fixed {{.Name}}", Entries: []*features.ChangelogEntry{{Name: "Crash", Version: "1"}}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := OutputChangelogMarkdown(&buf, changelog, ""); err != nil {
+		t.Fatalf("OutputChangelogMarkdown() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## v1.1.0 (2025-03-01)",
+		"Changes since v1.0.0.",
+		"### Features\n\n- **Login** (v2): OAuth support\n",
+		"### Fixes\n\n- Fixed Crash\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := OutputChangelogMarkdown(&buf, &features.Changelog{To: "HEAD"}, ""); err != nil {
+		t.Fatalf("OutputChangelogMarkdown() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "## Unreleased") || !strings.Contains(buf.String(), "No feature versions were closed") {
+		t.Errorf("empty changelog output:\n%s", buf.String())
+	}
+}
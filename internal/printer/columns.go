@@ -0,0 +1,201 @@
+package printer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// DefaultColumns are the columns of the table output
+var DefaultColumns = []string{"name", "state", "priority", "type", "category", "team"}
+
+// DefaultCSVColumns are the columns of the CSV output
+var DefaultCSVColumns = []string{"id", "name", "type", "state", "priority", "category", "domain", "team", "epic", "created", "modified"}
+
+// ParseColumns parses a comma-separated list of columns. A column is a field
+// as used in filter expressions (name, state, priority, metadata.jira, ...),
+// relationships.count, or relationships.<type> for the targets of a type.
+func ParseColumns(spec string) ([]string, error) {
+	var columns []string
+	for _, column := range strings.Split(spec, ",") {
+		column = strings.TrimSpace(column)
+		if column == "" {
+			continue
+		}
+		if !IsColumn(column) {
+			return nil, fmt.Errorf("unknown column %q (use a field such as name, state, priority or metadata.<key>, or relationships.count)", column)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns given")
+	}
+	return columns, nil
+}
+
+// IsColumn reports whether a column or group-by field is known
+func IsColumn(column string) bool {
+	if rest, ok := strings.CutPrefix(strings.ToLower(column), "relationships."); ok {
+		return rest != ""
+	}
+	return fogit.IsFilterField(column)
+}
+
+// ColumnValue returns the value of a column for a feature
+func ColumnValue(f *fogit.Feature, column string) string {
+	rest, ok := strings.CutPrefix(strings.ToLower(column), "relationships.")
+	if !ok {
+		if strings.EqualFold(column, "tags") {
+			return strings.Join(f.Tags, ", ")
+		}
+		return fogit.FieldValue(f, column)
+	}
+
+	if rest == "count" {
+		return strconv.Itoa(len(f.Relationships))
+	}
+	var targets []string
+	for _, rel := range f.Relationships {
+		if strings.EqualFold(string(rel.Type), rest) {
+			targets = append(targets, rel.TargetName)
+		}
+	}
+	return strings.Join(targets, ", ")
+}
+
+// columnHeader returns the table header of a column; metadata columns are
+// shown by their key
+func columnHeader(column string) string {
+	if key, ok := strings.CutPrefix(column, "metadata."); ok {
+		column = key
+	}
+	return strings.ToUpper(column)
+}
+
+// OutputColumnsTable writes features as a table of the given columns
+func OutputColumnsTable(w io.Writer, features []*fogit.Feature, columns []string) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	defer tw.Flush()
+
+	// The rule is split into cells so the header stays aligned with the rows
+	headers := make([]string, len(columns))
+	rules := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = columnHeader(column)
+		rules[i] = strings.Repeat("-", len(headers[i]))
+	}
+	fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	fmt.Fprintln(tw, strings.Join(rules, "\t"))
+
+	for _, f := range features {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			max := 40
+			if strings.EqualFold(column, "name") {
+				max = 30
+			}
+			values[i] = truncate(ColumnValue(f, column), max)
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+
+	return nil
+}
+
+// OutputColumnsCSV writes features as CSV with the given columns
+func OutputColumnsCSV(w io.Writer, features []*fogit.Feature, columns []string) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, f := range features {
+		if err := writer.Write(columnValues(f, columns)); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// OutputColumnsJSON writes features as JSON objects holding only the given columns
+func OutputColumnsJSON(w io.Writer, features []*fogit.Feature, columns []string) error {
+	return OutputAsJSON(w, columnRows(features, columns))
+}
+
+// columnValues returns the values of the columns of a feature
+func columnValues(f *fogit.Feature, columns []string) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = ColumnValue(f, column)
+	}
+	return values
+}
+
+// columnRows maps features to objects keyed by column
+func columnRows(features []*fogit.Feature, columns []string) []map[string]string {
+	rows := make([]map[string]string, 0, len(features))
+	for _, f := range features {
+		row := make(map[string]string, len(columns))
+		for _, column := range columns {
+			row[column] = ColumnValue(f, column)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// IsTemplateFormat reports whether an output format is a text/template
+// (e.g. '{{.Name}}\t{{.GetPriority}}') rather than a format name
+func IsTemplateFormat(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+// listTemplateFuncs are the helper functions available to list format templates
+var listTemplateFuncs = template.FuncMap{
+	"field": ColumnValue,
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+}
+
+// ParseListTemplate parses a list format template. The escapes \t and \n are
+// replaced by a tab and a newline, so templates can be written in the shell
+// as '{{.Name}}\t{{.GetPriority}}'.
+func ParseListTemplate(format string) (*template.Template, error) {
+	format = strings.NewReplacer(`\t`, "\t", `\n`, "\n").Replace(format)
+	tmpl, err := template.New("format").Funcs(listTemplateFuncs).Parse(format)
+	if err != nil {
+		return nil, fmt.Errorf("invalid format template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// OutputTemplate writes each feature with the template, one per line
+func OutputTemplate(w io.Writer, features []*fogit.Feature, tmpl *template.Template) error {
+	for _, f := range features {
+		var b strings.Builder
+		if err := tmpl.Execute(&b, f); err != nil {
+			return fmt.Errorf("failed to render format template: %w", err)
+		}
+		line := b.String()
+		if !strings.HasSuffix(line, "\n") {
+			line += "\n"
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestParseColumns(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    []string
+		wantErr bool
+	}{
+		{"name,state", []string{"name", "state"}, false},
+		{" name , metadata.team ,relationships.count", []string{"name", "metadata.team", "relationships.count"}, false},
+		{"relationships.depends-on", []string{"relationships.depends-on"}, false},
+		{"name,bogus", nil, true},
+		{"relationships.", nil, true},
+		{",", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseColumns(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseColumns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("ParseColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumnValue(t *testing.T) {
+	auth := fogit.NewFeature("Auth")
+	api := fogit.NewFeature("API")
+	api.SetMetadata("team", "backend")
+	api.SetMetadata("jira", "API-7")
+	api.Tags = []string{"core", "http"}
+	api.Relationships = []fogit.Relationship{
+		fogit.NewRelationship("depends-on", auth.ID, auth.Name),
+		fogit.NewRelationship("blocked-by", auth.ID, auth.Name),
+	}
+
+	tests := map[string]string{
+		"name":                     "API",
+		"team":                     "backend",
+		"metadata.team":            "backend",
+		"metadata.jira":            "API-7",
+		"metadata.missing":         "",
+		"tags":                     "core, http",
+		"relationships.count":      "2",
+		"relationships.depends-on": "Auth",
+		"relationships.related-to": "",
+	}
+	for column, want := range tests {
+		if got := ColumnValue(api, column); got != want {
+			t.Errorf("ColumnValue(%q) = %q, want %q", column, got, want)
+		}
+	}
+}
+
+func TestOutputColumns(t *testing.T) {
+	f := fogit.NewFeature("Login")
+	f.SetMetadata("team", "security")
+	columns := []string{"name", "metadata.team"}
+
+	var buf bytes.Buffer
+	if err := OutputColumnsTable(&buf, []*fogit.Feature{f}, columns); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "NAME") || !strings.Contains(lines[0], "TEAM") {
+		t.Fatalf("unexpected table:\n%s", buf.String())
+	}
+	if !strings.Contains(lines[2], "Login") || !strings.Contains(lines[2], "security") {
+		t.Errorf("row missing values: %q", lines[2])
+	}
+
+	buf.Reset()
+	if err := OutputColumnsCSV(&buf, []*fogit.Feature{f}, columns); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "name,metadata.team\nLogin,security\n"; got != want {
+		t.Errorf("CSV = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := OutputColumnsJSON(&buf, []*fogit.Feature{f}, columns); err != nil {
+		t.Fatal(err)
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0]["metadata.team"] != "security" || len(rows[0]) != 2 {
+		t.Errorf("JSON rows = %v", rows)
+	}
+}
+
+func TestOutputTemplate(t *testing.T) {
+	a := fogit.NewFeature("A")
+	a.SetMetadata("priority", "high")
+	a.SetMetadata("jira", "X-1")
+	b := fogit.NewFeature("B")
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{`{{.Name}}\t{{.GetPriority}}`, "A\thigh\nB\tmedium\n"},
+		{`{{.Name}}: {{field . "metadata.jira"}}`, "A: X-1\nB: \n"},
+		{`{{upper .Name}}\n`, "A\nB\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			if !IsTemplateFormat(tt.format) {
+				t.Fatal("IsTemplateFormat() = false")
+			}
+			tmpl, err := ParseListTemplate(tt.format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf bytes.Buffer
+			if err := OutputTemplate(&buf, []*fogit.Feature{a, b}, tmpl); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+
+	if IsTemplateFormat("table") {
+		t.Error("IsTemplateFormat(table) = true")
+	}
+	if _, err := ParseListTemplate("{{.Name"); err == nil {
+		t.Error("expected error for unterminated template")
+	}
+}
@@ -0,0 +1,230 @@
+package printer
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// Graph output formats
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+	GraphFormatGraphML = "graphml"
+	GraphFormatJSON    = "json"
+)
+
+// IsValidGraphFormat checks if the graph output format is supported
+func IsValidGraphFormat(format string) bool {
+	switch format {
+	case GraphFormatDOT, GraphFormatMermaid, GraphFormatGraphML, GraphFormatJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+// OutputGraph writes the graph in the given format
+func OutputGraph(w io.Writer, format string, graph *features.Graph) error {
+	switch format {
+	case GraphFormatDOT:
+		return OutputDOT(w, graph)
+	case GraphFormatMermaid:
+		return OutputMermaid(w, graph)
+	case GraphFormatGraphML:
+		return OutputGraphML(w, graph)
+	case GraphFormatJSON:
+		return OutputAsJSON(w, graph)
+	default:
+		return fmt.Errorf("invalid format: must be one of dot, mermaid, graphml, json")
+	}
+}
+
+// stateStyle is how a node in a given state is drawn
+type stateStyle struct {
+	class string // Mermaid class name
+	fill  string
+	line  string // Border style: solid or dashed
+}
+
+// graphStateStyle returns the node style for a derived state.
+// Custom workflow states share one style.
+func graphStateStyle(state string) stateStyle {
+	switch fogit.State(state) {
+	case fogit.StateOpen:
+		return stateStyle{class: "open", fill: "#dafbe1", line: "solid"}
+	case fogit.StateInProgress:
+		return stateStyle{class: "inprogress", fill: "#fff8c5", line: "solid"}
+	case fogit.StateClosed:
+		return stateStyle{class: "closed", fill: "#eaeef2", line: "dashed"}
+	default:
+		return stateStyle{class: "custom", fill: "#fbefff", line: "solid"}
+	}
+}
+
+// OutputDOT writes the graph in Graphviz DOT format
+func OutputDOT(w io.Writer, graph *features.Graph) error {
+	fmt.Fprintln(w, "digraph features {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, `  node [shape=box, style="rounded,filled", fontname="Helvetica"];`)
+	fmt.Fprintln(w, `  edge [fontname="Helvetica", fontsize=10];`)
+	for _, n := range graph.Nodes {
+		style := graphStateStyle(n.State)
+		nodeStyle := "rounded,filled"
+		if style.line == "dashed" {
+			nodeStyle += ",dashed"
+		}
+		fmt.Fprintf(w, "  %s [label=%s, fillcolor=%q, style=%q];\n",
+			dotQuote(n.ID), dotQuote(n.Name+"\n"+n.State), style.fill, nodeStyle)
+	}
+	for _, e := range graph.Edges {
+		attrs := "label=" + dotQuote(e.Type)
+		if color := graph.Categories[e.Category].Color; color != "" {
+			attrs += fmt.Sprintf(", color=%q", color)
+		}
+		fmt.Fprintf(w, "  %s -> %s [%s];\n", dotQuote(e.Source), dotQuote(e.Target), attrs)
+	}
+	fmt.Fprintln(w, "}")
+	return nil
+}
+
+// dotQuote quotes a DOT identifier or label
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+// OutputMermaid writes the graph as a Mermaid flowchart
+func OutputMermaid(w io.Writer, graph *features.Graph) error {
+	fmt.Fprintln(w, "flowchart LR")
+
+	// Mermaid IDs must be simple identifiers, so nodes are numbered
+	ids := make(map[string]string, len(graph.Nodes))
+	classes := make(map[string][]string)
+	var classOrder []stateStyle
+	for i, n := range graph.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[n.ID] = id
+		fmt.Fprintf(w, "  %s[\"%s<br/><small>%s</small>\"]\n", id, mermaidEscape(n.Name), mermaidEscape(n.State))
+
+		style := graphStateStyle(n.State)
+		if _, ok := classes[style.class]; !ok {
+			classOrder = append(classOrder, style)
+		}
+		classes[style.class] = append(classes[style.class], id)
+	}
+	for _, e := range graph.Edges {
+		fmt.Fprintf(w, "  %s -->|%s| %s\n", ids[e.Source], mermaidEscape(e.Type), ids[e.Target])
+	}
+	for _, style := range classOrder {
+		fmt.Fprintf(w, "  classDef %s fill:%s,stroke:#57606a", style.class, style.fill)
+		if style.line == "dashed" {
+			fmt.Fprint(w, ",stroke-dasharray:4 3")
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "  class %s %s\n", strings.Join(classes[style.class], ","), style.class)
+	}
+	return nil
+}
+
+// mermaidEscape escapes text for use inside Mermaid labels
+func mermaidEscape(s string) string {
+	replacer := strings.NewReplacer(`"`, "#quot;", "|", "#124;", "<", "#lt;", ">", "#gt;", "\n", " ")
+	return replacer.Replace(s)
+}
+
+// graphML document structure
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	ID     string        `xml:"id,attr,omitempty"`
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// OutputGraphML writes the graph as GraphML (readable by yEd, Gephi and networkx)
+func OutputGraphML(w io.Writer, graph *features.Graph) error {
+	doc := graphMLDoc{
+		Xmlns: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "name", For: "node", AttrName: "name", AttrType: "string"},
+			{ID: "state", For: "node", AttrName: "state", AttrType: "string"},
+			{ID: "priority", For: "node", AttrName: "priority", AttrType: "string"},
+			{ID: "type", For: "node", AttrName: "type", AttrType: "string"},
+			{ID: "fill", For: "node", AttrName: "fill", AttrType: "string"},
+			{ID: "relationship", For: "edge", AttrName: "relationship", AttrType: "string"},
+			{ID: "category", For: "edge", AttrName: "category", AttrType: "string"},
+		},
+		Graph: graphMLGraph{ID: "features", EdgeDefault: "directed"},
+	}
+	for _, n := range graph.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, graphMLNode{
+			ID: n.ID,
+			Data: []graphMLData{
+				{Key: "name", Value: n.Name},
+				{Key: "state", Value: n.State},
+				{Key: "priority", Value: n.Priority},
+				{Key: "type", Value: n.Type},
+				{Key: "fill", Value: graphStateStyle(n.State).fill},
+			},
+		})
+	}
+	for _, e := range graph.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			ID:     e.ID,
+			Source: e.Source,
+			Target: e.Target,
+			Data: []graphMLData{
+				{Key: "relationship", Value: e.Type},
+				{Key: "category", Value: e.Category},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode GraphML: %w", err)
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
@@ -0,0 +1,83 @@
+package printer
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/features"
+)
+
+func testGraph() *features.Graph {
+	return &features.Graph{
+		Nodes: []features.GraphNode{
+			{ID: "id-1", Name: `Login "v2"`, State: "open"},
+			{ID: "id-2", Name: "Auth", State: "closed"},
+			{ID: "id-3", Name: "Review", State: "review"},
+		},
+		Edges: []features.GraphEdge{
+			{ID: "rel-1", Source: "id-1", Target: "id-2", Type: "depends-on", Category: "structural"},
+		},
+		Categories: map[string]features.GraphCategory{
+			"structural": {Color: "#ff0000"},
+		},
+	}
+}
+
+func TestOutputGraph(t *testing.T) {
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{GraphFormatDOT, []string{
+			"digraph features {",
+			`"id-1" [label="Login \"v2\"\nopen", fillcolor="#dafbe1"`,
+			`style="rounded,filled,dashed"`,
+			`"id-1" -> "id-2" [label="depends-on", color="#ff0000"];`,
+		}},
+		{GraphFormatMermaid, []string{
+			"flowchart LR",
+			`n0["Login #quot;v2#quot;<br/><small>open</small>"]`,
+			"n0 -->|depends-on| n1",
+			"class n0 open",
+			"classDef closed fill:#eaeef2,stroke:#57606a,stroke-dasharray:4 3",
+			"class n2 custom",
+		}},
+		{GraphFormatGraphML, []string{
+			`<graph id="features" edgedefault="directed">`,
+			`<node id="id-1">`,
+			`<data key="name">Login &#34;v2&#34;</data>`,
+			`<edge id="rel-1" source="id-1" target="id-2">`,
+			`<data key="relationship">depends-on</data>`,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := OutputGraph(&buf, tt.format, testGraph()); err != nil {
+				t.Fatalf("OutputGraph() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestOutputGraphML_WellFormed(t *testing.T) {
+	var buf bytes.Buffer
+	if err := OutputGraphML(&buf, testGraph()); err != nil {
+		t.Fatalf("OutputGraphML() error = %v", err)
+	}
+	var doc graphMLDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid XML: %v", err)
+	}
+	if len(doc.Graph.Nodes) != 3 || len(doc.Graph.Edges) != 1 {
+		t.Errorf("parsed %d nodes and %d edges, want 3 and 1", len(doc.Graph.Nodes), len(doc.Graph.Edges))
+	}
+}
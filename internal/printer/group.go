@@ -0,0 +1,172 @@
+package printer
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// NoGroupValue is the group of features without a value for the group field
+const NoGroupValue = "(none)"
+
+// FeatureGroup is the features sharing a value of the group field
+type FeatureGroup struct {
+	Value    string           `json:"value"`
+	Count    int              `json:"count"`
+	States   map[string]int   `json:"states"` // Feature count per state
+	Features []*fogit.Feature `json:"features"`
+}
+
+// GroupFeatures groups features by a column, keeping their order within each
+// group. Groups are sorted by value with features lacking one last. Grouping
+// by tags puts a feature in the group of each of its tags.
+func GroupFeatures(features []*fogit.Feature, field string) []*FeatureGroup {
+	groups := make(map[string]*FeatureGroup)
+	for _, f := range features {
+		for _, value := range groupValues(f, field) {
+			group, ok := groups[value]
+			if !ok {
+				group = &FeatureGroup{Value: value, States: make(map[string]int)}
+				groups[value] = group
+			}
+			group.Count++
+			group.States[string(f.DeriveState())]++
+			group.Features = append(group.Features, f)
+		}
+	}
+
+	result := make([]*FeatureGroup, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if (result[i].Value == NoGroupValue) != (result[j].Value == NoGroupValue) {
+			return result[j].Value == NoGroupValue
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// groupValues returns the groups a feature belongs to
+func groupValues(f *fogit.Feature, field string) []string {
+	if strings.EqualFold(field, "tags") {
+		if len(f.Tags) == 0 {
+			return []string{NoGroupValue}
+		}
+		return f.Tags
+	}
+	if value := ColumnValue(f, field); value != "" {
+		return []string{value}
+	}
+	return []string{NoGroupValue}
+}
+
+// groupSummary describes the size of a group, e.g. "3 features: 2 open, 1 closed"
+func groupSummary(group *FeatureGroup) string {
+	states := make([]string, 0, len(group.States))
+	for state := range group.States {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return stateRank(states[i]) < stateRank(states[j]) ||
+			stateRank(states[i]) == stateRank(states[j]) && states[i] < states[j]
+	})
+
+	counts := make([]string, len(states))
+	for i, state := range states {
+		counts[i] = fmt.Sprintf("%d %s", group.States[state], state)
+	}
+	return fmt.Sprintf("%s: %s", pluralFeatures(group.Count), strings.Join(counts, ", "))
+}
+
+// stateRank orders the default states by progress; other states sort after them
+func stateRank(state string) int {
+	switch fogit.State(state) {
+	case fogit.StateOpen:
+		return 0
+	case fogit.StateInProgress:
+		return 1
+	case fogit.StateClosed:
+		return 3
+	default:
+		return 2
+	}
+}
+
+func pluralFeatures(n int) string {
+	if n == 1 {
+		return "1 feature"
+	}
+	return fmt.Sprintf("%d features", n)
+}
+
+// OutputGroupedTable writes one table of the given columns per group, each
+// headed by its value and counts, followed by the totals. A feature in
+// several groups (tags) is counted once in the total.
+func OutputGroupedTable(w io.Writer, groups []*FeatureGroup, field string, columns []string) error {
+	seen := make(map[*fogit.Feature]bool)
+	for i, group := range groups {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "%s: %s  (%s)\n", columnHeader(field), group.Value, groupSummary(group))
+		if err := OutputColumnsTable(w, group.Features, columns); err != nil {
+			return err
+		}
+		for _, f := range group.Features {
+			seen[f] = true
+		}
+	}
+
+	groupWord := "groups"
+	if len(groups) == 1 {
+		groupWord = "group"
+	}
+	fmt.Fprintf(w, "\nTotal: %s in %d %s\n", pluralFeatures(len(seen)), len(groups), groupWord)
+	return nil
+}
+
+// OutputGroupedCSV writes features as CSV with the group value as first column
+func OutputGroupedCSV(w io.Writer, groups []*FeatureGroup, field string, columns []string) error {
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write(append([]string{field}, columns...)); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, group := range groups {
+		for _, f := range group.Features {
+			if err := writer.Write(append([]string{group.Value}, columnValues(f, columns)...)); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// groupedJSON is a group as written by OutputGroupedJSON
+type groupedJSON struct {
+	Value    string         `json:"value"`
+	Count    int            `json:"count"`
+	States   map[string]int `json:"states"`
+	Features interface{}    `json:"features"`
+}
+
+// OutputGroupedJSON writes the groups as JSON. Features hold only the given
+// columns when columns are set.
+func OutputGroupedJSON(w io.Writer, groups []*FeatureGroup, columns []string) error {
+	out := make([]groupedJSON, len(groups))
+	for i, group := range groups {
+		out[i] = groupedJSON{Value: group.Value, Count: group.Count, States: group.States, Features: group.Features}
+		if len(columns) > 0 {
+			out[i].Features = columnRows(group.Features, columns)
+		}
+	}
+	return OutputAsJSON(w, out)
+}
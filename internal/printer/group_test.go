@@ -0,0 +1,92 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func groupTestFeatures(t *testing.T) []*fogit.Feature {
+	t.Helper()
+	newFeature := func(name, team string, state fogit.State, tags ...string) *fogit.Feature {
+		f := fogit.NewFeature(name)
+		if team != "" {
+			f.SetMetadata("team", team)
+		}
+		if state != fogit.StateOpen {
+			if err := f.UpdateState(state); err != nil {
+				t.Fatal(err)
+			}
+		}
+		f.Tags = tags
+		return f
+	}
+	return []*fogit.Feature{
+		newFeature("Login", "security", fogit.StateOpen, "auth"),
+		newFeature("API", "backend", fogit.StateInProgress, "auth", "http"),
+		newFeature("Docs", "", fogit.StateOpen),
+		newFeature("Cache", "backend", fogit.StateOpen),
+	}
+}
+
+func TestGroupFeatures(t *testing.T) {
+	features := groupTestFeatures(t)
+
+	groups := GroupFeatures(features, "metadata.team")
+	var got []string
+	for _, g := range groups {
+		got = append(got, g.Value)
+	}
+	if strings.Join(got, ",") != "backend,security,(none)" {
+		t.Fatalf("groups = %v", got)
+	}
+	backend := groups[0]
+	if backend.Count != 2 || backend.States["open"] != 1 || backend.States["in-progress"] != 1 {
+		t.Errorf("backend group = %+v", backend)
+	}
+	if backend.Features[0].Name != "API" || backend.Features[1].Name != "Cache" {
+		t.Error("features should keep their order within a group")
+	}
+
+	// A feature is in the group of each of its tags
+	groups = GroupFeatures(features, "tags")
+	if len(groups) != 3 || groups[0].Value != "auth" || groups[0].Count != 2 || groups[1].Value != "http" {
+		t.Errorf("tag groups = %+v", groups)
+	}
+}
+
+func TestOutputGroupedTable(t *testing.T) {
+	groups := GroupFeatures(groupTestFeatures(t), "tags")
+
+	var buf bytes.Buffer
+	if err := OutputGroupedTable(&buf, groups, "tags", []string{"name", "state"}); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"TAGS: auth  (2 features: 1 open, 1 in-progress)",
+		"TAGS: http  (1 feature: 1 in-progress)",
+		"TAGS: (none)  (2 features: 2 open)",
+		"Total: 4 features in 3 groups", // API is counted once
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOutputGroupedCSV(t *testing.T) {
+	groups := GroupFeatures(groupTestFeatures(t), "team")
+
+	var buf bytes.Buffer
+	if err := OutputGroupedCSV(&buf, groups, "team", []string{"name"}); err != nil {
+		t.Fatal(err)
+	}
+	want := "team,name\nbackend,API\nbackend,Cache\nsecurity,Login\n(none),Docs\n"
+	if buf.String() != want {
+		t.Errorf("CSV = %q, want %q", buf.String(), want)
+	}
+}
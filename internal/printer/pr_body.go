@@ -0,0 +1,118 @@
+package printer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/eg3r/fogit/internal/features"
+)
+
+// DefaultPRBodyTemplate renders a pull request description when
+// .fogit/templates/pr.md.tmpl doesn't exist
+const DefaultPRBodyTemplate = `## Summary
+
+{{if eq (len .Features) 1}}This pull request covers **{{(index .Features 0).Name}}**{{else}}This pull request covers {{len .Features}} features{{end}} (` + "`{{.Branch}}`" + ` → ` + "`{{.BaseBranch}}`" + `).
+{{range .Features}}
+### {{.Name}}
+{{if .Description}}
+{{.Description}}
+{{end}}
+- **State:** {{.State}}
+{{- if .Type}}
+- **Type:** {{.Type}}
+{{- end}}
+{{- if .Priority}}
+- **Priority:** {{.Priority}}
+{{- end}}
+{{- if .Tags}}
+- **Tags:** {{join .Tags ", "}}
+{{- end}}
+{{if .Versions}}
+#### Versions
+{{range .Versions}}
+- v{{.Version}}{{if .Branch}} on ` + "`{{.Branch}}`" + `{{end}}{{if .Authors}} by {{join .Authors ", "}}{{end}}{{if .Notes}}: {{.Notes}}{{end}}
+{{- end}}
+{{end}}
+{{- if .Files}}
+#### Files
+{{range .Files}}
+- ` + "`{{.}}`" + `
+{{- end}}
+{{end}}
+{{- if .Relationships}}
+#### Relationships
+{{range .Relationships}}
+- {{.Type}} **{{.TargetName}}**{{if .Description}}: {{.Description}}{{end}}
+{{- end}}
+{{end}}
+#### Impact
+{{if .Impacts}}
+{{len .Impacts}} feature(s) may be affected:
+{{range .Impacts}}
+- **{{.Name}}** ({{.Relationship}}{{if gt .Depth 1}}, via {{join .Path " → "}}{{end}}){{if .Warning}} ⚠ {{.Warning}}{{end}}
+{{- end}}
+{{else}}
+No other features are affected.
+{{end}}
+{{- end}}
+## Validation
+{{if .Validation.Passed}}
+✅ All checks passed
+{{- else}}
+❌ {{.Validation.Errors}} error(s), {{.Validation.Warnings}} warning(s)
+{{range .Validation.Issues}}
+- [{{.Code}}] {{.Feature}}: {{.Message}}
+{{- end}}
+{{- end}}
+`
+
+// PR description output formats
+const (
+	PRBodyFormatMarkdown = "markdown"
+	PRBodyFormatJSON     = "json"
+)
+
+// prBodyFuncs are the helper functions available to PR description templates
+var prBodyFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"date":  formatPRDate,
+}
+
+// formatPRDate formats a time.Time or *time.Time as YYYY-MM-DD; a nil time
+// (e.g. .ClosedAt of an open version) renders empty
+func formatPRDate(t interface{}) (string, error) {
+	switch t := t.(type) {
+	case time.Time:
+		return t.Format("2006-01-02"), nil
+	case *time.Time:
+		if t == nil {
+			return "", nil
+		}
+		return t.Format("2006-01-02"), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("date: expected a time, got %T", t)
+	}
+}
+
+// OutputPRBody renders a pull request description with a text/template;
+// empty tmplText uses DefaultPRBodyTemplate
+func OutputPRBody(w io.Writer, tmplText string, body *features.PRBody) error {
+	if tmplText == "" {
+		tmplText = DefaultPRBodyTemplate
+	}
+	tmpl, err := template.New("pr").Funcs(prBodyFuncs).Parse(tmplText)
+	if err != nil {
+		return fmt.Errorf("invalid PR template: %w", err)
+	}
+	if err := tmpl.Execute(w, body); err != nil {
+		return fmt.Errorf("failed to render PR template: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestOutputPRBody(t *testing.T) {
+	auth := fogit.NewFeature("Auth")
+	auth.Description = "OAuth login"
+	auth.Files = []string{"src/auth.go"}
+	auth.Relationships = []fogit.Relationship{{Type: "depends-on", TargetID: "db-1", TargetName: "Database"}}
+
+	body := &features.PRBody{
+		Branch:     "feature/auth",
+		BaseBranch: "main",
+		Features: []features.PRFeature{features.NewPRFeature(auth, &features.ImpactResult{
+			ImpactedFeatures: []features.ImpactedFeature{{Name: "Billing", Relationship: "depends-on", Depth: 1}},
+		})},
+		Validation: features.PRValidation{
+			Errors: 1,
+			Issues: []features.PRIssue{{Code: "E001", Feature: "Auth", Message: "target not found"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := OutputPRBody(&buf, "", body); err != nil {
+		t.Fatalf("OutputPRBody() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"This pull request covers **Auth** (`feature/auth` → `main`).",
+		"### Auth\n\nOAuth login\n",
+		"#### Files\n\n- `src/auth.go`\n",
+		"- depends-on **Database**\n",
+		"1 feature(s) may be affected:\n\n- **Billing** (depends-on)\n",
+		"❌ 1 error(s), 0 warning(s)\n\n- [E001] Auth: target not found\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	buf.Reset()
+	if err := OutputPRBody(&buf, "{{range .Features}}{{upper .Name}}{{end}}", body); err != nil {
+		t.Fatalf("OutputPRBody() error = %v", err)
+	}
+	if buf.String() != "AUTH" {
+		t.Errorf("custom template output = %q, want AUTH", buf.String())
+	}
+
+	if err := OutputPRBody(&buf, "{{.Missing", body); err == nil {
+		t.Error("expected an error for an invalid template")
+	}
+}
+
+func TestOutputPRBody_Date(t *testing.T) {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	closed := created.AddDate(0, 0, 9)
+	open := fogit.NewFeature("Open")
+	open.Versions["1"].CreatedAt = created
+	done := fogit.NewFeature("Done")
+	done.Versions["1"].CreatedAt = created
+	done.Versions["1"].ClosedAt = &closed
+
+	body := &features.PRBody{Features: []features.PRFeature{
+		features.NewPRFeature(open, nil),
+		features.NewPRFeature(done, nil),
+	}}
+
+	var buf bytes.Buffer
+	tmpl := "{{range .Features}}{{.Name}} {{range .Versions}}{{date .CreatedAt}}..{{date .ClosedAt}}{{end}};{{end}}"
+	if err := OutputPRBody(&buf, tmpl, body); err != nil {
+		t.Fatalf("OutputPRBody() error = %v", err)
+	}
+	if want := "Open 2025-03-01..;Done 2025-03-01..2025-03-10;"; buf.String() != want {
+		t.Errorf("output = %q, want %q", buf.String(), want)
+	}
+
+	if err := OutputPRBody(&buf, "{{date .Branch}}", body); err == nil {
+		t.Error("expected an error for date of a string")
+	}
+}
@@ -0,0 +1,283 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Token is an analyzed word of a text
+type Token struct {
+	Term  string // Lowercased, stemmed form
+	Pos   int    // Position among the indexed words (stop words are not counted)
+	Start int    // Byte offset of the word in the text
+	End   int    // Byte offset just past the word
+}
+
+// stopWords are common English words that are not indexed
+var stopWords = map[string]bool{
+	"a": true, "about": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "been": true, "but": true, "by": true, "can": true, "do": true, "does": true,
+	"for": true, "from": true, "has": true, "have": true, "if": true, "in": true, "into": true,
+	"is": true, "it": true, "its": true, "no": true, "not": true, "of": true, "on": true,
+	"or": true, "should": true, "so": true, "such": true, "than": true, "that": true, "the": true,
+	"their": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"to": true, "was": true, "we": true, "were": true, "will": true, "with": true, "when": true,
+	"which": true, "while": true, "who": true, "would": true, "you": true, "your": true,
+}
+
+// Analyze splits text into lowercased, stemmed words, leaving out stop words.
+// Words are runs of letters and digits.
+func Analyze(text string) []Token {
+	var tokens []Token
+	pos := 0
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		word := strings.ToLower(text[start:end])
+		if !stopWords[word] {
+			tokens = append(tokens, Token{Term: Stem(word), Pos: pos, Start: start, End: end})
+			pos++
+		}
+		start = -1
+	}
+	for i, r := range text {
+		if isWordRune(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(text))
+	return tokens
+}
+
+// analyzeValues analyzes several values of one field. Positions of consecutive
+// values are one apart, so a phrase never spans two values.
+func analyzeValues(values []string) []Token {
+	var tokens []Token
+	next := 0
+	for _, value := range values {
+		analyzed := Analyze(value)
+		for _, tok := range analyzed {
+			tok.Pos += next
+			tokens = append(tokens, tok)
+		}
+		if len(analyzed) > 0 {
+			next = tokens[len(tokens)-1].Pos + 2
+		}
+	}
+	return tokens
+}
+
+// Stem reduces an English word to its stem with the Porter algorithm, so that
+// "authenticate", "authenticated" and "authentication" are indexed alike.
+// Words that aren't plain ASCII letters are returned unchanged.
+func Stem(word string) string {
+	if len(word) <= 2 {
+		return word
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'a' || word[i] > 'z' {
+			return word
+		}
+	}
+
+	w := []byte(word)
+	w = stemStep1a(w)
+	w = stemStep1b(w)
+	w = stemStep1c(w)
+	w = replaceSuffix(w, step2Rules, 0)
+	w = replaceSuffix(w, step3Rules, 0)
+	w = stemStep4(w)
+	w = stemStep5(w)
+	return string(w)
+}
+
+type suffixRule struct {
+	suffix, replacement string
+}
+
+var step2Rules = []suffixRule{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"abli", "able"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+}
+
+var step3Rules = []suffixRule{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+// Longer suffixes come first; only the first suffix that matches is considered
+var step4Suffixes = []string{
+	"ement", "ment", "ent", "al", "ance", "ence", "er", "ic", "able", "ible",
+	"ant", "ou", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+}
+
+// isConsonant reports whether w[i] is a consonant; y is a consonant unless it follows one
+func isConsonant(w []byte, i int) bool {
+	switch w[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !isConsonant(w, i-1)
+	}
+	return true
+}
+
+// measure counts the vowel-consonant sequences of w
+func measure(w []byte) int {
+	n, i := 0, 0
+	for i < len(w) && isConsonant(w, i) {
+		i++
+	}
+	for i < len(w) {
+		for i < len(w) && !isConsonant(w, i) {
+			i++
+		}
+		if i >= len(w) {
+			break
+		}
+		for i < len(w) && isConsonant(w, i) {
+			i++
+		}
+		n++
+	}
+	return n
+}
+
+func hasVowel(w []byte) bool {
+	for i := range w {
+		if !isConsonant(w, i) {
+			return true
+		}
+	}
+	return false
+}
+
+func endsDoubleConsonant(w []byte) bool {
+	l := len(w)
+	return l >= 2 && w[l-1] == w[l-2] && isConsonant(w, l-1)
+}
+
+// endsCVC reports whether w ends consonant-vowel-consonant, the last not w, x or y
+func endsCVC(w []byte) bool {
+	l := len(w)
+	if l < 3 || !isConsonant(w, l-3) || isConsonant(w, l-2) || !isConsonant(w, l-1) {
+		return false
+	}
+	c := w[l-1]
+	return c != 'w' && c != 'x' && c != 'y'
+}
+
+func hasSuffix(w []byte, suffix string) bool {
+	return len(w) >= len(suffix) && string(w[len(w)-len(suffix):]) == suffix
+}
+
+// replaceSuffix applies the first rule whose suffix w ends with, if the
+// remaining stem has a measure above minMeasure
+func replaceSuffix(w []byte, rules []suffixRule, minMeasure int) []byte {
+	for _, rule := range rules {
+		if !hasSuffix(w, rule.suffix) {
+			continue
+		}
+		stem := w[:len(w)-len(rule.suffix)]
+		if measure(stem) > minMeasure {
+			return append(stem, rule.replacement...)
+		}
+		return w
+	}
+	return w
+}
+
+func stemStep1a(w []byte) []byte {
+	switch {
+	case hasSuffix(w, "sses"), hasSuffix(w, "ies"):
+		return w[:len(w)-2]
+	case hasSuffix(w, "ss"):
+		return w
+	case hasSuffix(w, "s"):
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+func stemStep1b(w []byte) []byte {
+	if hasSuffix(w, "eed") {
+		if measure(w[:len(w)-3]) > 0 {
+			return w[:len(w)-1]
+		}
+		return w
+	}
+
+	var stem []byte
+	switch {
+	case hasSuffix(w, "ed") && hasVowel(w[:len(w)-2]):
+		stem = w[:len(w)-2]
+	case hasSuffix(w, "ing") && hasVowel(w[:len(w)-3]):
+		stem = w[:len(w)-3]
+	default:
+		return w
+	}
+
+	switch {
+	case hasSuffix(stem, "at"), hasSuffix(stem, "bl"), hasSuffix(stem, "iz"):
+		return append(stem, 'e')
+	case endsDoubleConsonant(stem):
+		if c := stem[len(stem)-1]; c != 'l' && c != 's' && c != 'z' {
+			return stem[:len(stem)-1]
+		}
+	case measure(stem) == 1 && endsCVC(stem):
+		return append(stem, 'e')
+	}
+	return stem
+}
+
+func stemStep1c(w []byte) []byte {
+	if hasSuffix(w, "y") && hasVowel(w[:len(w)-1]) {
+		w[len(w)-1] = 'i'
+	}
+	return w
+}
+
+func stemStep4(w []byte) []byte {
+	for _, suffix := range step4Suffixes {
+		if !hasSuffix(w, suffix) {
+			continue
+		}
+		stem := w[:len(w)-len(suffix)]
+		if suffix == "ion" && !hasSuffix(stem, "s") && !hasSuffix(stem, "t") {
+			return w
+		}
+		if measure(stem) > 1 {
+			return stem
+		}
+		return w
+	}
+	return w
+}
+
+func stemStep5(w []byte) []byte {
+	if hasSuffix(w, "e") {
+		stem := w[:len(w)-1]
+		if m := measure(stem); m > 1 || (m == 1 && !endsCVC(stem)) {
+			w = stem
+		}
+	}
+	if measure(w) > 1 && hasSuffix(w, "ll") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+// isWordRune reports whether r is part of a word
+func isWordRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
@@ -0,0 +1,71 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"caresses", "caress"},
+		{"ponies", "poni"},
+		{"cats", "cat"},
+		{"agreed", "agre"},
+		{"running", "run"},
+		{"hopping", "hop"},
+		{"filing", "file"},
+		{"happy", "happi"},
+		{"relational", "relat"},
+		{"generalization", "gener"},
+		{"authenticate", "authent"},
+		{"authenticated", "authent"},
+		{"authentication", "authent"},
+		{"payments", "payment"},
+		{"login", "login"},
+		{"go", "go"},
+		{"oauth2", "oauth2"},
+		{"café", "café"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			if got := Stem(tt.word); got != tt.want {
+				t.Errorf("Stem(%q) = %q, want %q", tt.word, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	text := "The user-login flow, for Payments!"
+	tokens := Analyze(text)
+
+	var terms []string
+	for _, tok := range tokens {
+		terms = append(terms, tok.Term)
+		if tok.Pos != len(terms)-1 {
+			t.Errorf("token %q at position %d, want %d", tok.Term, tok.Pos, len(terms)-1)
+		}
+	}
+	if want := []string{"user", "login", "flow", "payment"}; !reflect.DeepEqual(terms, want) {
+		t.Errorf("Analyze() terms = %v, want %v", terms, want)
+	}
+	if last := tokens[len(tokens)-1]; text[last.Start:last.End] != "Payments" {
+		t.Errorf("last token covers %q, want %q", text[last.Start:last.End], "Payments")
+	}
+}
+
+func TestAnalyzeValues(t *testing.T) {
+	tokens := analyzeValues([]string{"single sign", "", "sign on"})
+	var positions []int
+	for _, tok := range tokens {
+		positions = append(positions, tok.Pos)
+	}
+	// "on" is a stop word; values are kept apart
+	if want := []int{0, 1, 3}; !reflect.DeepEqual(positions, want) {
+		t.Errorf("positions = %v, want %v", positions, want)
+	}
+}
@@ -0,0 +1,484 @@
+package search
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// IndexFormatVersion is bumped whenever the index layout or the analyzer changes,
+// so that indexes built by older versions are rebuilt
+const IndexFormatVersion = 1
+
+// Indexed fields
+const (
+	FieldName          = "name"
+	FieldDescription   = "description"
+	FieldTags          = "tags"
+	FieldMetadata      = "metadata"
+	FieldNotes         = "notes"         // Version notes
+	FieldRelationships = "relationships" // Relationship descriptions
+)
+
+// Fields lists the indexed fields
+var Fields = []string{FieldName, FieldDescription, FieldTags, FieldMetadata, FieldNotes, FieldRelationships}
+
+// BM25 parameters
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	prefixWeight = 0.5 // Weight of a term only matched as the prefix of an indexed term
+)
+
+// DefaultBoosts returns the default weight of each field
+func DefaultBoosts() map[string]float64 {
+	return map[string]float64{
+		FieldName:          3.0,
+		FieldTags:          2.0,
+		FieldDescription:   1.0,
+		FieldMetadata:      1.0,
+		FieldNotes:         0.8,
+		FieldRelationships: 0.5,
+	}
+}
+
+// IsField reports whether name is an indexed field
+func IsField(name string) bool {
+	for _, field := range Fields {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Index is an inverted full-text index over features, ranked with BM25.
+// It is not safe for concurrent use.
+type Index struct {
+	Version  int                           `json:"version"`
+	Docs     map[string]*Document          `json:"docs"`     // Feature ID -> document
+	Postings map[string]map[string]Posting `json:"postings"` // Term -> feature ID -> positions
+
+	dirty bool // True if the index has changes that have not been saved
+}
+
+// Document is an indexed feature
+type Document struct {
+	Fingerprint string         `json:"fingerprint"` // Hash of the indexed text, to detect changes
+	Lengths     map[string]int `json:"lengths"`     // Field -> number of indexed words
+	Terms       []string       `json:"terms"`       // Distinct terms of the feature
+}
+
+// Posting holds the positions of a term in each field of a feature
+type Posting map[string][]int
+
+// Result is a feature matching a query
+type Result struct {
+	ID     string
+	Score  float64
+	Fields []string // Fields that matched, sorted
+}
+
+// SearchOptions controls ranking
+type SearchOptions struct {
+	Boosts map[string]float64 // Field weights (nil = DefaultBoosts)
+	Limit  int                // Maximum number of results (0 = all)
+}
+
+// NewIndex creates an empty index
+func NewIndex() *Index {
+	return &Index{
+		Version:  IndexFormatVersion,
+		Docs:     make(map[string]*Document),
+		Postings: make(map[string]map[string]Posting),
+	}
+}
+
+// IsValid reports whether a decoded index has the current format
+func (idx *Index) IsValid() bool {
+	return idx.Version == IndexFormatVersion && idx.Docs != nil && idx.Postings != nil
+}
+
+// IsDirty reports whether the index has changes that have not been saved
+func (idx *Index) IsDirty() bool {
+	return idx.dirty
+}
+
+// MarkSaved records that the index was saved
+func (idx *Index) MarkSaved() {
+	idx.dirty = false
+}
+
+// Len returns the number of indexed features
+func (idx *Index) Len() int {
+	return len(idx.Docs)
+}
+
+// Sync indexes features that are new or changed since they were indexed.
+// Returns the number of features (re-)indexed.
+func (idx *Index) Sync(features []*fogit.Feature) int {
+	updated := 0
+	for _, f := range features {
+		fields := featureFields(f)
+		fingerprint := fieldsFingerprint(fields)
+		if doc := idx.Docs[f.ID]; doc != nil && doc.Fingerprint == fingerprint {
+			continue
+		}
+		idx.Remove(f.ID)
+		idx.add(f.ID, fingerprint, fields)
+		updated++
+	}
+	return updated
+}
+
+// Prune removes features that are not in present
+func (idx *Index) Prune(present map[string]bool) {
+	for id := range idx.Docs {
+		if !present[id] {
+			idx.Remove(id)
+		}
+	}
+}
+
+// Remove drops a feature from the index
+func (idx *Index) Remove(id string) {
+	doc := idx.Docs[id]
+	if doc == nil {
+		return
+	}
+	for _, term := range doc.Terms {
+		delete(idx.Postings[term], id)
+		if len(idx.Postings[term]) == 0 {
+			delete(idx.Postings, term)
+		}
+	}
+	delete(idx.Docs, id)
+	idx.dirty = true
+}
+
+// add indexes the fields of a feature
+func (idx *Index) add(id, fingerprint string, fields map[string][]string) {
+	doc := &Document{Fingerprint: fingerprint, Lengths: make(map[string]int)}
+	for _, field := range Fields {
+		tokens := analyzeValues(fields[field])
+		if len(tokens) == 0 {
+			continue
+		}
+		doc.Lengths[field] = len(tokens)
+		for _, tok := range tokens {
+			postings := idx.Postings[tok.Term]
+			if postings == nil {
+				postings = make(map[string]Posting)
+				idx.Postings[tok.Term] = postings
+			}
+			posting := postings[id]
+			if posting == nil {
+				posting = make(Posting)
+				postings[id] = posting
+				doc.Terms = append(doc.Terms, tok.Term)
+			}
+			posting[field] = append(posting[field], tok.Pos)
+		}
+	}
+	sort.Strings(doc.Terms)
+	idx.Docs[id] = doc
+	idx.dirty = true
+}
+
+// featureFields returns the text of each indexed field of a feature
+func featureFields(f *fogit.Feature) map[string][]string {
+	fields := map[string][]string{
+		FieldName:        {f.Name},
+		FieldDescription: {f.Description},
+		FieldTags:        f.Tags,
+	}
+
+	keys := make([]string, 0, len(f.Metadata))
+	for key := range f.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fields[FieldMetadata] = append(fields[FieldMetadata], metadataValues(f.Metadata[key])...)
+	}
+
+	for _, key := range f.GetSortedVersionKeys() {
+		if notes := f.Versions[key].Notes; notes != "" {
+			fields[FieldNotes] = append(fields[FieldNotes], notes)
+		}
+	}
+
+	for _, rel := range f.Relationships {
+		if rel.Description != "" {
+			fields[FieldRelationships] = append(fields[FieldRelationships], rel.Description)
+		}
+	}
+	return fields
+}
+
+// metadataValues flattens a metadata value into strings
+func metadataValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, metadataValues(item)...)
+		}
+		return values
+	case []string:
+		return v
+	case map[string]interface{}:
+		return nil // Nested objects are not searchable
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// fieldsFingerprint hashes the indexed text of a feature
+func fieldsFingerprint(fields map[string][]string) string {
+	h := fnv.New64a()
+	for _, field := range Fields {
+		h.Write([]byte(field))
+		for _, value := range fields[field] {
+			h.Write([]byte{0})
+			h.Write([]byte(value))
+		}
+		h.Write([]byte{1})
+	}
+	return fmt.Sprintf("%016x", h.Sum64())
+}
+
+// Search returns the features matching every clause of the query that isn't
+// excluded, ranked by BM25 with per-field boosts
+func (idx *Index) Search(q Query, opts SearchOptions) []Result {
+	boosts := DefaultBoosts()
+	for field, boost := range opts.Boosts {
+		boosts[field] = boost
+	}
+
+	var required []Clause
+	for _, c := range q.Clauses {
+		if !c.Exclude {
+			required = append(required, c)
+		}
+	}
+	if len(required) == 0 {
+		return nil
+	}
+
+	avgLengths := idx.averageLengths()
+	scores := make(map[string]float64)
+	matched := make(map[string]map[string]bool)
+	for i, c := range required {
+		clauseScores, clauseFields := idx.scoreClause(c, boosts, avgLengths)
+		for id := range scores {
+			if _, ok := clauseScores[id]; !ok {
+				delete(scores, id)
+			}
+		}
+		for id, score := range clauseScores {
+			if i > 0 {
+				if _, ok := scores[id]; !ok {
+					continue
+				}
+			}
+			scores[id] += score
+			if matched[id] == nil {
+				matched[id] = make(map[string]bool)
+			}
+			for field := range clauseFields[id] {
+				matched[id][field] = true
+			}
+		}
+	}
+
+	for _, c := range q.Clauses {
+		if c.Exclude {
+			excluded, _ := idx.scoreClause(c, boosts, avgLengths)
+			for id := range excluded {
+				delete(scores, id)
+			}
+		}
+	}
+
+	results := make([]Result, 0, len(scores))
+	for id, score := range scores {
+		fields := make([]string, 0, len(matched[id]))
+		for field := range matched[id] {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		results = append(results, Result{ID: id, Score: score, Fields: fields})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].ID < results[j].ID
+	})
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results
+}
+
+// averageLengths returns the average number of words of each field
+func (idx *Index) averageLengths() map[string]float64 {
+	avg := make(map[string]float64)
+	if len(idx.Docs) == 0 {
+		return avg
+	}
+	for _, doc := range idx.Docs {
+		for field, length := range doc.Lengths {
+			avg[field] += float64(length)
+		}
+	}
+	for field := range avg {
+		avg[field] /= float64(len(idx.Docs))
+	}
+	return avg
+}
+
+// scoreClause scores the features matching a clause, and returns the fields they matched in
+func (idx *Index) scoreClause(c Clause, boosts map[string]float64, avgLengths map[string]float64) (map[string]float64, map[string]map[string]bool) {
+	scores := make(map[string]float64)
+	fields := make(map[string]map[string]bool)
+
+	if len(c.Terms) == 1 && !c.Phrase {
+		for term, weight := range idx.expandTerm(c.Terms[0]) {
+			for id, posting := range idx.Postings[term] {
+				score, hitFields := idx.termScore(term, id, posting, c.Field, boosts, avgLengths)
+				if score == 0 {
+					continue
+				}
+				scores[id] += weight * score
+				addFields(fields, id, hitFields)
+			}
+		}
+		return scores, fields
+	}
+
+	// Phrase: every term at consecutive positions of the same field
+	first := idx.Postings[c.Terms[0]]
+	for id := range first {
+		phraseFields := idx.phraseFields(c, id)
+		if len(phraseFields) == 0 {
+			continue
+		}
+		score := 0.0
+		for _, term := range c.Terms {
+			for _, field := range phraseFields {
+				s, _ := idx.termScore(term, id, idx.Postings[term][id], field, boosts, avgLengths)
+				score += s
+			}
+		}
+		scores[id] = score
+		addFields(fields, id, phraseFields)
+	}
+	return scores, fields
+}
+
+// expandTerm returns the indexed terms a query term matches: itself, and the
+// terms it is a prefix of at a lower weight
+func (idx *Index) expandTerm(term string) map[string]float64 {
+	terms := make(map[string]float64)
+	if _, ok := idx.Postings[term]; ok {
+		terms[term] = 1
+	}
+	if len([]rune(term)) < 2 {
+		return terms
+	}
+	for indexed := range idx.Postings {
+		if indexed != term && strings.HasPrefix(indexed, term) {
+			terms[indexed] = prefixWeight
+		}
+	}
+	return terms
+}
+
+// termScore computes the BM25F score of a term for a feature, restricted to
+// one field unless field is empty
+func (idx *Index) termScore(term, id string, posting Posting, field string, boosts map[string]float64, avgLengths map[string]float64) (float64, []string) {
+	doc := idx.Docs[id]
+	if doc == nil {
+		return 0, nil
+	}
+
+	var hitFields []string
+	tf := 0.0
+	for f, positions := range posting {
+		if field != "" && f != field {
+			continue
+		}
+		norm := 1.0
+		if avg := avgLengths[f]; avg > 0 {
+			norm = 1 - bm25B + bm25B*float64(doc.Lengths[f])/avg
+		}
+		tf += boosts[f] * float64(len(positions)) / norm
+		hitFields = append(hitFields, f)
+	}
+	if tf == 0 {
+		return 0, nil
+	}
+
+	n := float64(len(idx.Docs))
+	df := float64(len(idx.Postings[term]))
+	idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+	return idf * tf * (bm25K1 + 1) / (tf + bm25K1), hitFields
+}
+
+// phraseFields returns the fields of a feature in which the clause's terms
+// appear at consecutive positions
+func (idx *Index) phraseFields(c Clause, id string) []string {
+	var found []string
+	for _, field := range Fields {
+		if c.Field != "" && field != c.Field {
+			continue
+		}
+		for _, start := range idx.Postings[c.Terms[0]][id][field] {
+			if idx.phraseAt(c.Terms, id, field, start) {
+				found = append(found, field)
+				break
+			}
+		}
+	}
+	return found
+}
+
+// phraseAt reports whether terms follow each other from position start
+func (idx *Index) phraseAt(terms []string, id, field string, start int) bool {
+	for i, term := range terms[1:] {
+		if !containsInt(idx.Postings[term][id][field], start+i+1) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsInt(values []int, want int) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
+
+func addFields(fields map[string]map[string]bool, id string, hitFields []string) {
+	if fields[id] == nil {
+		fields[id] = make(map[string]bool)
+	}
+	for _, f := range hitFields {
+		fields[id][f] = true
+	}
+}
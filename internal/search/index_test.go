@@ -0,0 +1,138 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// indexedFeatures returns an index over a few features, keyed by name
+func indexedFeatures(t *testing.T) (*Index, map[string]*fogit.Feature) {
+	t.Helper()
+
+	auth := fogit.NewFeature("User Authentication")
+	auth.Description = "Login and registration with single sign on"
+	auth.Tags = []string{"security"}
+
+	api := fogit.NewFeature("API Keys")
+	api.Description = "Key management for authenticating API clients"
+	api.SetMetadata("team", "platform")
+
+	payment := fogit.NewFeature("Payment Processing")
+	payment.Description = "Credit card handling through the legacy gateway"
+	payment.Tags = []string{"billing"}
+	payment.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", auth.ID, auth.Name)}
+	payment.Relationships[0].Description = "Charges need a signed in user"
+
+	dashboard := fogit.NewFeature("Billing Dashboard")
+	dashboard.Description = "Shows payments of the month"
+	dashboard.Tags = []string{"payment"}
+
+	features := map[string]*fogit.Feature{}
+	idx := NewIndex()
+	list := []*fogit.Feature{auth, api, payment, dashboard}
+	if got := idx.Sync(list); got != len(list) {
+		t.Fatalf("Sync() indexed %d features, want %d", got, len(list))
+	}
+	for _, f := range list {
+		features[f.Name] = f
+	}
+	return idx, features
+}
+
+// resultNames maps search results to feature names
+func resultNames(results []Result, features map[string]*fogit.Feature) []string {
+	byID := make(map[string]string)
+	for name, f := range features {
+		byID[f.ID] = name
+	}
+	names := []string{}
+	for _, r := range results {
+		names = append(names, byID[r.ID])
+	}
+	return names
+}
+
+func TestIndex_Search(t *testing.T) {
+	idx, features := indexedFeatures(t)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{"stemmed word", "authenticate", []string{"User Authentication", "API Keys"}},
+		{"prefix", "auth", []string{"User Authentication", "API Keys"}},
+		{"all words must match", "payment gateway", []string{"Payment Processing"}},
+		{"name ranks above tags and description", "payment", []string{"Payment Processing", "Billing Dashboard"}},
+		{"phrase", `"single sign"`, []string{"User Authentication"}},
+		{"phrase in wrong order", `"sign single"`, []string{}},
+		{"field", "name:payment", []string{"Payment Processing"}},
+		{"metadata value", "platform", []string{"API Keys"}},
+		{"relationship description", "charges", []string{"Payment Processing"}},
+		{"exclusion", "payment -legacy", []string{"Billing Dashboard"}},
+		{"no match", "kubernetes", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resultNames(idx.Search(ParseQuery(tt.query), SearchOptions{}), features)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex_SearchBoostsAndLimit(t *testing.T) {
+	idx, features := indexedFeatures(t)
+
+	// With tags weighted far above names, the dashboard's payment tag wins
+	results := idx.Search(ParseQuery("payment"), SearchOptions{Boosts: map[string]float64{FieldName: 0.1, FieldTags: 10}})
+	if got := resultNames(results, features); len(got) != 2 || got[0] != "Billing Dashboard" {
+		t.Errorf("boosted Search() = %v, want Billing Dashboard first", got)
+	}
+	if !reflect.DeepEqual(results[0].Fields, []string{FieldDescription, FieldTags}) {
+		t.Errorf("matched fields = %v, want [description tags]", results[0].Fields)
+	}
+
+	if got := idx.Search(ParseQuery("payment"), SearchOptions{Limit: 1}); len(got) != 1 {
+		t.Errorf("Search() with Limit 1 returned %d results", len(got))
+	}
+}
+
+func TestIndex_SyncIsIncremental(t *testing.T) {
+	idx, features := indexedFeatures(t)
+	idx.MarkSaved()
+
+	var list []*fogit.Feature
+	for _, f := range features {
+		list = append(list, f)
+	}
+	if got := idx.Sync(list); got != 0 || idx.IsDirty() {
+		t.Errorf("Sync() of unchanged features re-indexed %d, dirty = %v", got, idx.IsDirty())
+	}
+
+	dashboard := features["Billing Dashboard"]
+	dashboard.Description = "Monthly revenue charts"
+	if got := idx.Sync(list); got != 1 {
+		t.Errorf("Sync() after an edit re-indexed %d features, want 1", got)
+	}
+	if got := resultNames(idx.Search(ParseQuery("revenue"), SearchOptions{}), features); !reflect.DeepEqual(got, []string{"Billing Dashboard"}) {
+		t.Errorf("Search(revenue) = %v, want the edited feature", got)
+	}
+	if got := resultNames(idx.Search(ParseQuery("shows"), SearchOptions{}), features); len(got) != 0 {
+		t.Errorf("Search(shows) = %v, want the old description forgotten", got)
+	}
+
+	idx.Prune(map[string]bool{dashboard.ID: true})
+	if idx.Len() != 1 {
+		t.Errorf("Len() after Prune = %d, want 1", idx.Len())
+	}
+	for term, postings := range idx.Postings {
+		if _, ok := postings[dashboard.ID]; !ok {
+			t.Errorf("term %q still indexed for a pruned feature", term)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/eg3r/fogit/internal/common"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// Query is a parsed full-text query. A feature matches when it matches every
+// clause that isn't excluded and none that are.
+type Query struct {
+	Clauses []Clause
+}
+
+// Clause is a word or a quoted phrase of a query
+type Clause struct {
+	Terms   []string // Analyzed terms
+	Phrase  bool     // Terms must appear next to each other, and are not prefix-matched
+	Field   string   // Only match in this field (empty = any field)
+	Exclude bool     // Features matching the clause are left out
+}
+
+// ParseQuery parses a query of words and "quoted phrases". A word or phrase can
+// be limited to a field (name:login, description:"single sign") or excluded
+// with a leading minus (-legacy). Words that analyze to several terms, such as
+// "sign-in", are matched as a phrase. Stop words are ignored.
+func ParseQuery(text string) Query {
+	var q Query
+	rest := strings.TrimSpace(text)
+	for rest != "" {
+		var c Clause
+		if strings.HasPrefix(rest, "-") && len(rest) > 1 {
+			c.Exclude = true
+			rest = rest[1:]
+		}
+		if i := strings.IndexAny(rest, ": \t\""); i > 0 && rest[i] == ':' && IsField(strings.ToLower(rest[:i])) {
+			c.Field = strings.ToLower(rest[:i])
+			rest = rest[i+1:]
+		}
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			c.Phrase = true
+			end := strings.Index(rest[1:], `"`)
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end := strings.IndexAny(rest, " \t")
+			if end < 0 {
+				value, rest = rest, ""
+			} else {
+				value, rest = rest[:end], rest[end:]
+			}
+		}
+		rest = strings.TrimSpace(rest)
+
+		for _, tok := range Analyze(value) {
+			c.Terms = append(c.Terms, tok.Term)
+		}
+		if len(c.Terms) == 0 {
+			continue
+		}
+		if len(c.Terms) > 1 {
+			c.Phrase = true
+		}
+		q.Clauses = append(q.Clauses, c)
+	}
+	return q
+}
+
+// IsEmpty reports whether the query has nothing to match
+func (q Query) IsEmpty() bool {
+	for _, c := range q.Clauses {
+		if !c.Exclude {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesTerm reports whether an analyzed word matches one of the query's
+// terms in the given field
+func (q Query) matchesTerm(term, field string) bool {
+	for _, c := range q.Clauses {
+		if c.Exclude || (c.Field != "" && c.Field != field) {
+			continue
+		}
+		for _, t := range c.Terms {
+			if term == t || (!c.Phrase && len([]rune(t)) >= 2 && strings.HasPrefix(term, t)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// snippetFields are the fields a snippet is taken from, in order of preference.
+// The name is left out since it is always shown.
+var snippetFields = []string{FieldDescription, FieldNotes, FieldRelationships, FieldMetadata, FieldTags}
+
+// Snippet returns an excerpt of about maxLen bytes of the first field of the
+// feature that matches the query, with the matching words wrapped in open and
+// close. Returns empty strings if only the name matches.
+func Snippet(f *fogit.Feature, q Query, maxLen int, open, close string) (field, snippet string) {
+	fields := featureFields(f)
+	for _, field := range snippetFields {
+		text := strings.Join(fields[field], ", ")
+		var hits []Token
+		for _, tok := range Analyze(text) {
+			if q.matchesTerm(tok.Term, field) {
+				hits = append(hits, tok)
+			}
+		}
+		if len(hits) == 0 {
+			continue
+		}
+		return field, highlight(text, hits, maxLen, open, close)
+	}
+	return "", ""
+}
+
+// highlight cuts a snippet around the first hit and marks the hits inside it
+func highlight(text string, hits []Token, maxLen int, open, close string) string {
+	start, end := common.SnippetBounds(text, hits[0].Start, maxLen)
+
+	// Words separated only by spaces are marked together, e.g. a matched phrase
+	var spans []Token
+	for _, hit := range hits {
+		if n := len(spans); n > 0 && strings.TrimSpace(text[spans[n-1].End:hit.Start]) == "" {
+			spans[n-1].End = hit.End
+			continue
+		}
+		spans = append(spans, hit)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("...")
+	}
+	pos := start
+	for _, hit := range spans {
+		if hit.Start < start || hit.End > end {
+			continue
+		}
+		b.WriteString(text[pos:hit.Start])
+		b.WriteString(open)
+		b.WriteString(text[hit.Start:hit.End])
+		b.WriteString(close)
+		pos = hit.End
+	}
+	b.WriteString(text[pos:end])
+	if end < len(text) {
+		b.WriteString("...")
+	}
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []Clause
+	}{
+		{"words", "user login", []Clause{{Terms: []string{"user"}}, {Terms: []string{"login"}}}},
+		{"stop words dropped", "the login", []Clause{{Terms: []string{"login"}}}},
+		{"phrase", `"single sign on" api`, []Clause{{Terms: []string{"singl", "sign"}, Phrase: true}, {Terms: []string{"api"}}}},
+		{"field", "name:Payments", []Clause{{Terms: []string{"payment"}, Field: FieldName}}},
+		{"field phrase", `description:"credit card"`, []Clause{{Terms: []string{"credit", "card"}, Phrase: true, Field: FieldDescription}}},
+		{"unknown field is text", "owner:alice", []Clause{{Terms: []string{"owner", "alic"}, Phrase: true}}},
+		{"exclusion", "-legacy", []Clause{{Terms: []string{"legaci"}, Exclude: true}}},
+		{"hyphenated word is a phrase", "user-login", []Clause{{Terms: []string{"user", "login"}, Phrase: true}}},
+		{"unterminated quote", `"credit card`, []Clause{{Terms: []string{"credit", "card"}, Phrase: true}}},
+		{"only stop words", "the and", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseQuery(tt.query).Clauses
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery(%q) = %+v, want %+v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuery_IsEmpty(t *testing.T) {
+	if !ParseQuery("-legacy").IsEmpty() {
+		t.Error("a query with only exclusions should be empty")
+	}
+	if ParseQuery("login -legacy").IsEmpty() {
+		t.Error("a query with a word should not be empty")
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	f := fogit.NewFeature("Payment Processing")
+	f.Description = "Handles credit card payments through the payment gateway"
+	f.Tags = []string{"billing"}
+
+	tests := []struct {
+		name      string
+		query     string
+		wantField string
+		want      string
+	}{
+		{"description words", "payment", FieldDescription, "Handles credit card [payments] through the [payment] gateway"},
+		{"phrase marked as one", `"credit card"`, FieldDescription, "Handles [credit card] payments through the payment gateway"},
+		{"tags", "bill", FieldTags, "[billing]"},
+		{"name only", "processing", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field, snippet := Snippet(f, ParseQuery(tt.query), 100, "[", "]")
+			if field != tt.wantField || snippet != tt.want {
+				t.Errorf("Snippet(%q) = %q, %q; want %q, %q", tt.query, field, snippet, tt.wantField, tt.want)
+			}
+		})
+	}
+
+	_, snippet := Snippet(f, ParseQuery("gateway"), 20, "[", "]")
+	if snippet != "...ment [gateway]" {
+		t.Errorf("Snippet() of a long text = %q, want it cut around the match", snippet)
+	}
+}
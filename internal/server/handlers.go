@@ -0,0 +1,431 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/features/validator"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// createRequest is the body of POST /api/features
+type createRequest struct {
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Type        string                 `json:"type"`
+	Priority    string                 `json:"priority"`
+	Category    string                 `json:"category"`
+	Domain      string                 `json:"domain"`
+	Team        string                 `json:"team"`
+	Epic        string                 `json:"epic"`
+	Module      string                 `json:"module"`
+	Tags        []string               `json:"tags"`
+	Metadata    map[string]interface{} `json:"metadata"`
+	Parent      string                 `json:"parent"`   // Parent feature ID or name
+	Template    string                 `json:"template"` // Template name from .fogit/templates
+}
+
+// updateRequest is the body of PATCH /api/features/{id}; omitted fields are left unchanged
+type updateRequest struct {
+	Name        *string                `json:"name"`
+	Description *string                `json:"description"`
+	State       *string                `json:"state"`
+	Priority    *string                `json:"priority"`
+	Type        *string                `json:"type"`
+	Category    *string                `json:"category"`
+	Domain      *string                `json:"domain"`
+	Team        *string                `json:"team"`
+	Epic        *string                `json:"epic"`
+	Module      *string                `json:"module"`
+	Metadata    map[string]interface{} `json:"metadata"`
+}
+
+// linkRequest is the body of POST /api/features/{id}/relationships
+type linkRequest struct {
+	Target            string `json:"target"` // Target feature ID or name
+	Type              string `json:"type"`
+	Description       string `json:"description"`
+	VersionConstraint string `json:"version_constraint"`
+}
+
+// handleListFeatures serves GET /api/features.
+// Accepts the same filters as 'fogit list' plus an optional 'filter' expression.
+func (s *Server) handleListFeatures(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	sortBy := q.Get("sort")
+	if sortBy == "" {
+		sortBy = string(fogit.SortByCreated) // Same default as 'fogit list'
+	}
+	filter := &fogit.Filter{
+		State:       fogit.State(q.Get("state")),
+		Priority:    fogit.Priority(q.Get("priority")),
+		Type:        q.Get("type"),
+		Category:    q.Get("category"),
+		Domain:      q.Get("domain"),
+		Team:        q.Get("team"),
+		Epic:        q.Get("epic"),
+		Parent:      q.Get("parent"),
+		Tags:        q["tag"],
+		Contributor: q.Get("contributor"),
+		SortBy:      fogit.SortField(sortBy),
+		SortOrder:   fogit.SortOrder(q.Get("order")),
+	}
+	if err := filter.ValidateWith(s.cfg.Workflow.StateMachine()); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	expr, err := s.parseFilterExpr(q.Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	list, err := s.repo.List(r.Context(), filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list features: %w", err))
+		return
+	}
+	if expr != nil && fogit.UsesFilterGraph(expr) {
+		all, err := s.repo.List(r.Context(), nil)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list features: %w", err))
+			return
+		}
+		fogit.BindFilterGraph(expr, all)
+	}
+
+	result := make([]*fogit.Feature, 0, len(list))
+	for _, f := range list {
+		if expr == nil || expr.Matches(f) {
+			result = append(result, f)
+		}
+	}
+	fogit.SortFeatures(result, filter)
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleGetFeature serves GET /api/features/{id}
+func (s *Server) handleGetFeature(w http.ResponseWriter, r *http.Request) {
+	feature, ok := s.findFeature(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	etag, err := ETag(feature)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if match := r.Header.Get("If-None-Match"); match != "" && etagListContains(match, etag) {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	writeFeature(w, http.StatusOK, feature)
+}
+
+// handleCreateFeature serves POST /api/features
+func (s *Server) handleCreateFeature(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	opts := features.CreateOptions{
+		Name:        req.Name,
+		Description: req.Description,
+		Type:        req.Type,
+		Priority:    req.Priority,
+		Category:    req.Category,
+		Domain:      req.Domain,
+		Team:        req.Team,
+		Epic:        req.Epic,
+		Module:      req.Module,
+		Tags:        req.Tags,
+		Metadata:    req.Metadata,
+		SkipBranch:  true, // The server never switches the checked-out branch
+	}
+
+	if req.Template != "" {
+		tpl, err := storage.LoadTemplate(s.fogitDir, req.Template)
+		if err != nil {
+			writeError(w, errorStatus(err, http.StatusBadRequest), fmt.Errorf("failed to load template: %w", err))
+			return
+		}
+		opts.Template = tpl
+	}
+
+	if req.Parent != "" {
+		parent, ok := s.findFeature(w, r, req.Parent)
+		if !ok {
+			return
+		}
+		opts.ParentID = parent.ID
+	}
+
+	feature, err := features.Create(r.Context(), s.repo, opts, s.cfg, s.fogitDir)
+	if err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	w.Header().Set("Location", "/api/features/"+feature.ID)
+	writeFeature(w, http.StatusCreated, feature)
+}
+
+// handleUpdateFeature serves PATCH /api/features/{id}.
+// An If-Match header makes the update conditional on the feature's current ETag.
+func (s *Server) handleUpdateFeature(w http.ResponseWriter, r *http.Request) {
+	var req updateRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	feature, ok := s.findFeature(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if err := checkIfMatch(r, feature); err != nil {
+		writeError(w, errorStatus(err, http.StatusInternalServerError), err)
+		return
+	}
+
+	opts := features.UpdateOptions{
+		Name:        req.Name,
+		Description: req.Description,
+		State:       req.State,
+		Priority:    req.Priority,
+		Type:        req.Type,
+		Category:    req.Category,
+		Domain:      req.Domain,
+		Team:        req.Team,
+		Epic:        req.Epic,
+		Module:      req.Module,
+		Metadata:    req.Metadata,
+		States:      s.cfg.Workflow.StateMachine(),
+		FogitDir:    s.fogitDir,
+		Schema:      s.cfg.MetadataSchema,
+	}
+	if _, err := features.Update(r.Context(), s.repo, feature, opts); err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	writeFeature(w, http.StatusOK, feature)
+}
+
+// handleLink serves POST /api/features/{id}/relationships
+func (s *Server) handleLink(w http.ResponseWriter, r *http.Request) {
+	var req linkRequest
+	if err := decodeBody(r, &req); err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+	if req.Target == "" || req.Type == "" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("target and type are required"))
+		return
+	}
+
+	source, ok := s.findFeature(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if err := checkIfMatch(r, source); err != nil {
+		writeError(w, errorStatus(err, http.StatusInternalServerError), err)
+		return
+	}
+	target, ok := s.findFeature(w, r, req.Target)
+	if !ok {
+		return
+	}
+
+	rel, err := features.Link(r.Context(), s.repo, source, target, fogit.RelationshipType(req.Type), req.Description, req.VersionConstraint, s.cfg, s.fogitDir)
+	if err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	if etag, err := ETag(source); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	writeJSON(w, http.StatusCreated, rel)
+}
+
+// handleUnlink serves DELETE /api/features/{id}/relationships/{relID}
+func (s *Server) handleUnlink(w http.ResponseWriter, r *http.Request) {
+	source, ok := s.findFeature(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+	if err := checkIfMatch(r, source); err != nil {
+		writeError(w, errorStatus(err, http.StatusInternalServerError), err)
+		return
+	}
+
+	rel, err := features.Unlink(r.Context(), s.repo, source, r.PathValue("relID"), s.fogitDir, s.cfg)
+	if err != nil {
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return
+	}
+
+	if etag, err := ETag(source); err == nil {
+		w.Header().Set("ETag", etag)
+	}
+	writeJSON(w, http.StatusOK, rel)
+}
+
+// handleImpacts serves GET /api/features/{id}/impacts
+func (s *Server) handleImpacts(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	depth, err := intParam(q.Get("depth"), 0)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	feature, ok := s.findFeature(w, r, r.PathValue("id"))
+	if !ok {
+		return
+	}
+
+	categories := features.GetIncludedCategories(s.cfg, features.ImpactOptions{
+		MaxDepth:          depth,
+		IncludeCategories: q["include_category"],
+		ExcludeCategories: q["exclude_category"],
+		AllCategories:     q.Get("all_categories") == "true",
+	})
+	result, err := features.AnalyzeImpacts(r.Context(), feature, s.repo, s.cfg, categories, depth)
+	if err != nil {
+		writeError(w, errorStatus(err, http.StatusInternalServerError), err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleTree serves GET /api/tree.
+// Returns the hierarchy below ?root=, or below every root feature when omitted.
+func (s *Server) handleTree(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	depth, err := intParam(q.Get("depth"), -1)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	hierarchyTypes, err := features.DetermineTreeRelationshipTypes(s.cfg, q["type"])
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	all, err := s.repo.List(r.Context(), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list features: %w", err))
+		return
+	}
+
+	roots := features.FindRoots(all, hierarchyTypes)
+	if id := q.Get("root"); id != "" {
+		root, ok := s.findFeature(w, r, id)
+		if !ok {
+			return
+		}
+		roots = []*fogit.Feature{root}
+	}
+
+	nodes := make([]*features.TreeNode, 0, len(roots))
+	for _, root := range roots {
+		nodes = append(nodes, features.BuildTree(root, all, hierarchyTypes, depth))
+	}
+	writeJSON(w, http.StatusOK, nodes)
+}
+
+// handleValidate serves GET /api/validate
+func (s *Server) handleValidate(w http.ResponseWriter, r *http.Request) {
+	all, err := s.repo.List(r.Context(), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list features: %w", err))
+		return
+	}
+
+	result, err := validator.New(s.repo, s.cfg).ValidateFeatures(r.Context(), all)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleGraph serves GET /api/graph.
+// An optional 'filter' expression limits the graph to matching features.
+func (s *Server) handleGraph(w http.ResponseWriter, r *http.Request) {
+	expr, err := s.parseFilterExpr(r.URL.Query().Get("filter"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	all, err := s.repo.List(r.Context(), nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("failed to list features: %w", err))
+		return
+	}
+	if expr != nil {
+		fogit.BindFilterGraph(expr, all)
+		matched := make([]*fogit.Feature, 0, len(all))
+		for _, f := range all {
+			if expr.Matches(f) {
+				matched = append(matched, f)
+			}
+		}
+		all = matched
+	}
+
+	writeJSON(w, http.StatusOK, features.BuildGraph(all, s.cfg, features.GraphOptions{}))
+}
+
+// parseFilterExpr parses a 'filter' query parameter; nil if empty
+func (s *Server) parseFilterExpr(raw string) (fogit.FilterExpr, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	expr, err := fogit.ParseFilterExprWith(raw, fogit.FilterParseOptions{RelationType: s.cfg.ResolveRelationshipType})
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter expression: %w", err)
+	}
+	return expr, nil
+}
+
+// findFeature resolves a feature by ID or name, writing an error response when it can't
+func (s *Server) findFeature(w http.ResponseWriter, r *http.Request, identifier string) (*fogit.Feature, bool) {
+	result, err := features.Find(r.Context(), s.repo, identifier, s.cfg)
+	if err != nil {
+		if fogit.IsNotFound(err) {
+			err = fogit.NewNotFoundError("feature", identifier)
+		}
+		writeError(w, errorStatus(err, http.StatusBadRequest), err)
+		return nil, false
+	}
+	return result.Feature, true
+}
+
+// intParam parses an optional integer query parameter
+func intParam(value string, fallback int) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer %q", value)
+	}
+	return n, nil
+}
@@ -0,0 +1,232 @@
+// Package server exposes a fogit repository over an HTTP JSON API and an embedded web UI
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/eg3r/fogit/internal/printer"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// maxBodySize limits request bodies to keep a misbehaving client from exhausting memory
+const maxBodySize = 1 << 20
+
+// errPreconditionFailed is returned when an If-Match header does not match the current ETag
+var errPreconditionFailed = errors.New("feature was modified since it was read (ETag mismatch)")
+
+// Server serves the REST API for a single fogit repository.
+// Writes are serialized; reads run concurrently with each other.
+type Server struct {
+	repo     fogit.Repository
+	cfg      *fogit.Config
+	fogitDir string
+	addr     string // Address the server is bound to, for Host checks
+
+	mu  sync.RWMutex
+	mux *http.ServeMux
+}
+
+// New creates a server for the repository at fogitDir.
+// addr is the host:port the server listens on; requests for other hosts are refused.
+func New(repo fogit.Repository, cfg *fogit.Config, fogitDir, addr string) *Server {
+	s := &Server{
+		repo:     repo,
+		cfg:      cfg,
+		fogitDir: fogitDir,
+		addr:     addr,
+		mux:      http.NewServeMux(),
+	}
+	s.routes()
+	return s
+}
+
+// Handler returns the HTTP handler serving the API and web UI.
+// Requests whose Host doesn't name the server are refused, so a DNS
+// rebinding page can't read or change the repository.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedHost(r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("host %q is not allowed", r.Host))
+			return
+		}
+		s.mux.ServeHTTP(w, r)
+	})
+}
+
+// allowedHost reports whether a Host header names this server: the bound
+// port on localhost, an IP address or the bound host name
+func (s *Server) allowedHost(host string) bool {
+	boundHost, boundPort, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return false
+	}
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		hostname, port = host, "80"
+	}
+	if port != boundPort {
+		return false
+	}
+	hostname = strings.TrimSuffix(strings.Trim(hostname, "[]"), ".")
+	return strings.EqualFold(hostname, "localhost") ||
+		net.ParseIP(hostname) != nil ||
+		strings.EqualFold(hostname, boundHost)
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /api/features", s.read(s.handleListFeatures))
+	s.mux.HandleFunc("POST /api/features", s.write(s.handleCreateFeature))
+	s.mux.HandleFunc("GET /api/features/{id}", s.read(s.handleGetFeature))
+	s.mux.HandleFunc("PATCH /api/features/{id}", s.write(s.handleUpdateFeature))
+	s.mux.HandleFunc("POST /api/features/{id}/relationships", s.write(s.handleLink))
+	s.mux.HandleFunc("DELETE /api/features/{id}/relationships/{relID}", s.write(s.handleUnlink))
+	s.mux.HandleFunc("GET /api/features/{id}/impacts", s.read(s.handleImpacts))
+	s.mux.HandleFunc("GET /api/tree", s.read(s.handleTree))
+	s.mux.HandleFunc("GET /api/validate", s.read(s.handleValidate))
+	s.mux.HandleFunc("GET /api/graph", s.read(s.handleGraph))
+	s.mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown endpoint: %s %s", r.Method, r.URL.Path))
+	})
+	s.mux.Handle("/", uiHandler())
+}
+
+// read wraps a handler that only reads the repository
+func (s *Server) read(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+		h(w, r)
+	}
+}
+
+// write wraps a handler that modifies the repository. Only same-origin
+// JSON requests are accepted, so other web pages can't submit forms or
+// text/plain requests to the server.
+func (s *Server) write(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !sameOrigin(origin, r.Host) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("cross-origin request from %s is not allowed", origin))
+			return
+		}
+		if r.Method != http.MethodDelete || r.ContentLength > 0 {
+			mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			if err != nil || mediaType != "application/json" {
+				writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("Content-Type must be application/json"))
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+		h(w, r)
+	}
+}
+
+// ETag returns the entity tag for a feature: a hash of its stored YAML
+func ETag(feature *fogit.Feature) (string, error) {
+	data, err := storage.MarshalFeature(feature)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`, nil
+}
+
+// checkIfMatch verifies the If-Match header (if any) against the feature's current ETag
+func checkIfMatch(r *http.Request, feature *fogit.Feature) error {
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		return nil
+	}
+	etag, err := ETag(feature)
+	if err != nil {
+		return err
+	}
+	if !etagListContains(header, etag) {
+		return errPreconditionFailed
+	}
+	return nil
+}
+
+// sameOrigin reports whether an Origin header names the host the request was sent to
+func sameOrigin(origin, host string) bool {
+	u, err := url.Parse(origin)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && strings.EqualFold(u.Host, host)
+}
+
+// etagListContains reports whether a comma-separated If-Match/If-None-Match header contains
+// etag or the wildcard. The comparison is strong: weak tags never match.
+func etagListContains(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeFeature writes a feature with its ETag
+func writeFeature(w http.ResponseWriter, status int, feature *fogit.Feature) {
+	etag, err := ETag(feature)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	writeJSON(w, status, feature)
+}
+
+// writeJSON writes data using the same encoding as the CLI's --format json output
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = printer.OutputAsJSON(w, data)
+}
+
+// writeError writes an error as {"error": "..."}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// errorStatus maps service errors to HTTP status codes.
+// Errors that don't match a known kind get the fallback status.
+func errorStatus(err error, fallback int) int {
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.Is(err, errPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case fogit.IsNotFound(err):
+		return http.StatusNotFound
+	case fogit.IsDuplicateError(err):
+		return http.StatusConflict
+	case fogit.IsValidationError(err):
+		return http.StatusBadRequest
+	case errors.As(err, &maxBytesErr):
+		return http.StatusRequestEntityTooLarge
+	default:
+		return fallback
+	}
+}
+
+// decodeBody decodes a JSON request body, rejecting unknown fields
+func decodeBody(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid request body: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/features"
+	"github.com/eg3r/fogit/internal/storage"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func newTestServer(t *testing.T) (*httptest.Server, fogit.Repository) {
+	t.Helper()
+	fogitDir := t.TempDir()
+	repo := storage.NewFileRepository(fogitDir)
+	srv := httptest.NewUnstartedServer(nil)
+	srv.Config.Handler = New(repo, fogit.DefaultConfig(), fogitDir, srv.Listener.Addr().String()).Handler()
+	srv.Start()
+	t.Cleanup(srv.Close)
+	return srv, repo
+}
+
+func createFeature(t *testing.T, repo fogit.Repository, name string, priority fogit.Priority) *fogit.Feature {
+	t.Helper()
+	f := fogit.NewFeature(name)
+	f.SetPriority(priority)
+	if err := repo.Create(context.Background(), f); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return f
+}
+
+func do(t *testing.T, method, url, body string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range header {
+		if k == "Host" {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s error = %v", method, url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+}
+
+func TestServer_ListFeatures(t *testing.T) {
+	srv, repo := newTestServer(t)
+	createFeature(t, repo, "Login", fogit.PriorityHigh)
+	createFeature(t, repo, "Logout", fogit.PriorityLow)
+	createFeature(t, repo, "Signup", fogit.PriorityHigh)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		want   []string
+	}{
+		{"all sorted by name", "sort=name", http.StatusOK, []string{"Login", "Logout", "Signup"}},
+		{"flag filter", "priority=low", http.StatusOK, []string{"Logout"}},
+		{"expression", "sort=name&filter=" + url.QueryEscape("priority:high AND name:*log*"), http.StatusOK, []string{"Login"}},
+		{"bad expression", "filter=" + url.QueryEscape("(priority:high"), http.StatusBadRequest, nil},
+		{"bad state", "state=bogus", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, http.MethodGet, srv.URL+"/api/features?"+tt.query, "", nil)
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+			var got []*fogit.Feature
+			decode(t, resp, &got)
+			var names []string
+			for _, f := range got {
+				names = append(names, f.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("names = %v, want %v", names, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_GetFeature(t *testing.T) {
+	srv, repo := newTestServer(t)
+	feature := createFeature(t, repo, "Login", fogit.PriorityHigh)
+
+	resp := do(t, http.MethodGet, srv.URL+"/api/features/login", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("missing ETag header")
+	}
+	var got fogit.Feature
+	decode(t, resp, &got)
+	if got.ID != feature.ID {
+		t.Errorf("ID = %s, want %s", got.ID, feature.ID)
+	}
+
+	resp = do(t, http.MethodGet, srv.URL+"/api/features/"+feature.ID, "", map[string]string{"If-None-Match": etag})
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("If-None-Match status = %d, want 304", resp.StatusCode)
+	}
+
+	resp = do(t, http.MethodGet, srv.URL+"/api/features/missing", "", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("missing feature status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestServer_CreateFeature(t *testing.T) {
+	srv, repo := newTestServer(t)
+	parent := createFeature(t, repo, "Auth", fogit.PriorityMedium)
+
+	body := `{"name": "Login", "priority": "high", "tags": ["auth"], "parent": "Auth"}`
+	resp := do(t, http.MethodPost, srv.URL+"/api/features", body, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("status = %d, want 201", resp.StatusCode)
+	}
+	var created fogit.Feature
+	decode(t, resp, &created)
+	if resp.Header.Get("Location") != "/api/features/"+created.ID {
+		t.Errorf("Location = %s", resp.Header.Get("Location"))
+	}
+
+	saved, err := repo.Get(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if saved.GetPriority() != fogit.PriorityHigh {
+		t.Errorf("priority = %s, want high", saved.GetPriority())
+	}
+	if len(saved.Relationships) != 1 || saved.Relationships[0].TargetID != parent.ID {
+		t.Errorf("relationships = %+v, want contained-by %s", saved.Relationships, parent.ID)
+	}
+
+	resp = do(t, http.MethodPost, srv.URL+"/api/features", `{"name": "X", "bogus": 1}`, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown field status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestServer_UpdateFeatureETag(t *testing.T) {
+	srv, repo := newTestServer(t)
+	feature := createFeature(t, repo, "Login", fogit.PriorityLow)
+
+	resp := do(t, http.MethodGet, srv.URL+"/api/features/"+feature.ID, "", nil)
+	etag := resp.Header.Get("ETag")
+
+	resp = do(t, http.MethodPatch, srv.URL+"/api/features/"+feature.ID, `{"priority": "high"}`, map[string]string{"If-Match": etag})
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	newETag := resp.Header.Get("ETag")
+	if newETag == "" || newETag == etag {
+		t.Errorf("ETag after update = %q, want a new value", newETag)
+	}
+
+	// A second writer holding the old ETag is rejected
+	resp = do(t, http.MethodPatch, srv.URL+"/api/features/"+feature.ID, `{"priority": "critical"}`, map[string]string{"If-Match": etag})
+	if resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("stale If-Match status = %d, want 412", resp.StatusCode)
+	}
+
+	// If-Match compares strongly, so a weak tag never matches
+	resp = do(t, http.MethodPatch, srv.URL+"/api/features/"+feature.ID, `{"priority": "critical"}`, map[string]string{"If-Match": "W/" + newETag})
+	if resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("weak If-Match status = %d, want 412", resp.StatusCode)
+	}
+
+	saved, err := repo.Get(context.Background(), feature.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if saved.GetPriority() != fogit.PriorityHigh {
+		t.Errorf("priority = %s, want high", saved.GetPriority())
+	}
+
+	resp = do(t, http.MethodPatch, srv.URL+"/api/features/"+feature.ID, `{"state": "bogus"}`, nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("invalid state status = %d, want 400", resp.StatusCode)
+	}
+}
+
+func TestServer_RejectsForeignRequests(t *testing.T) {
+	srv, repo := newTestServer(t)
+	feature := createFeature(t, repo, "Login", fogit.PriorityLow)
+	featureURL := srv.URL + "/api/features/" + feature.ID
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{"form content type", map[string]string{"Content-Type": "application/x-www-form-urlencoded"}, http.StatusUnsupportedMediaType},
+		{"text/plain content type", map[string]string{"Content-Type": "text/plain"}, http.StatusUnsupportedMediaType},
+		{"cross-site origin", map[string]string{"Origin": "http://evil.example"}, http.StatusForbidden},
+		{"rebound host", map[string]string{"Host": fmt.Sprintf("evil.example:%d", port)}, http.StatusForbidden},
+		{"other port", map[string]string{"Host": fmt.Sprintf("localhost:%d", port+1)}, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := do(t, http.MethodPatch, featureURL, `{"priority": "high"}`, tt.header)
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	// A rebound host can't read either
+	resp := do(t, http.MethodGet, featureURL, "", map[string]string{"Host": fmt.Sprintf("evil.example:%d", port)})
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("GET with rebound host status = %d, want 403", resp.StatusCode)
+	}
+
+	// Same-origin requests, such as those of the web UI, are accepted
+	resp = do(t, http.MethodPatch, featureURL, `{"priority": "medium"}`, map[string]string{"Origin": srv.URL})
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("same-origin status = %d, want 200", resp.StatusCode)
+	}
+
+	saved, err := repo.Get(context.Background(), feature.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if saved.GetPriority() != fogit.PriorityMedium {
+		t.Errorf("priority = %s, want medium", saved.GetPriority())
+	}
+}
+
+func TestServer_LinkUnlinkAndTree(t *testing.T) {
+	srv, repo := newTestServer(t)
+	parent := createFeature(t, repo, "Auth", fogit.PriorityMedium)
+	child := createFeature(t, repo, "Login", fogit.PriorityMedium)
+
+	body := `{"target": "Auth", "type": "contained-by"}`
+	resp := do(t, http.MethodPost, srv.URL+"/api/features/"+child.ID+"/relationships", body, nil)
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("link status = %d, want 201", resp.StatusCode)
+	}
+	var rel fogit.Relationship
+	decode(t, resp, &rel)
+	if rel.TargetID != parent.ID {
+		t.Errorf("TargetID = %s, want %s", rel.TargetID, parent.ID)
+	}
+
+	resp = do(t, http.MethodGet, srv.URL+"/api/tree?type=contained-by", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("tree status = %d, want 200", resp.StatusCode)
+	}
+	var nodes []*features.TreeNode
+	decode(t, resp, &nodes)
+	if len(nodes) != 1 || nodes[0].Feature.ID != parent.ID || len(nodes[0].Children) != 1 || nodes[0].Children[0].Feature.ID != child.ID {
+		t.Fatalf("tree = %+v, want Auth > Login", nodes)
+	}
+
+	resp = do(t, http.MethodDelete, srv.URL+"/api/features/"+child.ID+"/relationships/"+rel.ID, "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unlink status = %d, want 200", resp.StatusCode)
+	}
+	resp = do(t, http.MethodDelete, srv.URL+"/api/features/"+child.ID+"/relationships/"+rel.ID, "", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("second unlink status = %d, want 404", resp.StatusCode)
+	}
+}
+
+func TestServer_GraphAndUI(t *testing.T) {
+	srv, repo := newTestServer(t)
+	parent := createFeature(t, repo, "Auth", fogit.PriorityMedium)
+	child := createFeature(t, repo, "Login", fogit.PriorityMedium)
+	if err := child.AddRelationship(fogit.NewRelationship("contained-by", parent.ID, parent.Name)); err != nil {
+		t.Fatalf("AddRelationship() error = %v", err)
+	}
+	if err := repo.Update(context.Background(), child); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	resp := do(t, http.MethodGet, srv.URL+"/api/graph", "", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("graph status = %d, want 200", resp.StatusCode)
+	}
+	var graph features.Graph
+	decode(t, resp, &graph)
+	if len(graph.Nodes) != 2 || len(graph.Edges) != 1 || graph.Edges[0].Category != "structural" {
+		t.Errorf("graph = %+v", graph)
+	}
+
+	resp = do(t, http.MethodGet, srv.URL+"/api/graph?filter="+url.QueryEscape("name:Auth"), "", nil)
+	decode(t, resp, &graph)
+	if len(graph.Nodes) != 1 || len(graph.Edges) != 0 {
+		t.Errorf("filtered graph = %+v", graph)
+	}
+
+	for _, path := range []string{"/", "/app.js", "/style.css"} {
+		resp = do(t, http.MethodGet, srv.URL+path, "", nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s status = %d, want 200", path, resp.StatusCode)
+		}
+	}
+
+	resp = do(t, http.MethodGet, srv.URL+"/api/unknown", "", nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown endpoint status = %d, want 404", resp.StatusCode)
+	}
+}
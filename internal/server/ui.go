@@ -0,0 +1,21 @@
+package server
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+// uiFiles holds the static web UI; it talks to the server only through /api
+//
+//go:embed ui
+var uiFiles embed.FS
+
+// uiHandler serves the embedded web UI
+func uiHandler() http.Handler {
+	sub, err := fs.Sub(uiFiles, "ui")
+	if err != nil {
+		panic(err) // The embedded directory is fixed at build time
+	}
+	return http.FileServerFS(sub)
+}
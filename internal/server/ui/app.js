@@ -0,0 +1,466 @@
+// fogit web UI: a small single-page app over the /api endpoints served by 'fogit serve'
+(function () {
+  'use strict';
+
+  const app = document.getElementById('app');
+  const palette = ['#0969da', '#cf222e', '#1a7f37', '#8250df', '#bc4c00', '#bf3989', '#0598bc', '#6e7781'];
+  const stateColors = { open: '#2da44e', 'in-progress': '#d4a72c', closed: '#8c959f' };
+
+  // --- helpers ---
+
+  function esc(value) {
+    return String(value == null ? '' : value)
+      .replace(/&/g, '&amp;')
+      .replace(/</g, '&lt;')
+      .replace(/>/g, '&gt;')
+      .replace(/"/g, '&quot;');
+  }
+
+  async function api(path) {
+    const resp = await fetch('/api' + path);
+    const body = await resp.json();
+    if (!resp.ok) {
+      throw new Error(body.error || resp.statusText);
+    }
+    return body;
+  }
+
+  function showError(err) {
+    app.innerHTML = '<p class="error">' + esc(err.message) + '</p>';
+  }
+
+  function formatDate(value) {
+    if (!value || value.startsWith('0001-')) {
+      return '';
+    }
+    return new Date(value).toLocaleString();
+  }
+
+  // compareVersions orders version keys numerically by component ("2" < "10", "1.2.0" < "1.10.0")
+  function compareVersions(a, b) {
+    const pa = a.split('.').map(Number);
+    const pb = b.split('.').map(Number);
+    for (let i = 0; i < Math.max(pa.length, pb.length); i++) {
+      const diff = (pa[i] || 0) - (pb[i] || 0);
+      if (diff !== 0) {
+        return diff;
+      }
+    }
+    return 0;
+  }
+
+  function versionKeys(feature) {
+    return Object.keys(feature.Versions || {}).sort(compareVersions);
+  }
+
+  // versionState mirrors Feature.DeriveState for a single version
+  function versionState(version) {
+    if (!version) {
+      return 'open';
+    }
+    if (version.State) {
+      return version.State;
+    }
+    if (version.ClosedAt) {
+      return 'closed';
+    }
+    return version.CreatedAt === version.ModifiedAt ? 'open' : 'in-progress';
+  }
+
+  function featureState(feature) {
+    const keys = versionKeys(feature);
+    return versionState(feature.Versions && feature.Versions[keys[keys.length - 1]]);
+  }
+
+  function stateBadge(state) {
+    return '<span class="state state-' + esc(state) + '">' + esc(state) + '</span>';
+  }
+
+  function tagList(tags) {
+    return (tags || []).map((t) => '<span class="tag">' + esc(t) + '</span>').join('');
+  }
+
+  function featureLink(id, name) {
+    return '<a href="#/features/' + encodeURIComponent(id) + '">' + esc(name || id) + '</a>';
+  }
+
+  function meta(feature, key) {
+    return (feature.Metadata && feature.Metadata[key]) || '';
+  }
+
+  function setActiveNav(name) {
+    document.querySelectorAll('header nav a').forEach((a) => {
+      a.classList.toggle('active', a.dataset.nav === name);
+    });
+  }
+
+  // --- feature list ---
+
+  const listFields = ['filter', 'state', 'priority', 'type', 'tag', 'sort'];
+
+  async function renderList(params) {
+    setActiveNav('features');
+    const value = (name) => esc(params.get(name) || '');
+    const priorities = ['', 'low', 'medium', 'high', 'critical'];
+    const sorts = ['created', 'modified', 'name', 'priority'];
+
+    app.innerHTML =
+      '<form class="filters">' +
+      '<input name="filter" placeholder="Filter expression, e.g. priority:high AND NOT state:closed" value="' + value('filter') + '">' +
+      '<input name="state" placeholder="state" list="states" value="' + value('state') + '">' +
+      '<datalist id="states"><option value="open"><option value="in-progress"><option value="closed"></datalist>' +
+      '<select name="priority">' + priorities.map((p) =>
+        '<option value="' + p + '"' + (params.get('priority') === p ? ' selected' : '') + '>' + (p || 'any priority') + '</option>').join('') + '</select>' +
+      '<input name="type" placeholder="type" value="' + value('type') + '">' +
+      '<input name="tag" placeholder="tag" value="' + value('tag') + '">' +
+      '<select name="sort">' + sorts.map((s) =>
+        '<option value="' + s + '"' + (params.get('sort') === s ? ' selected' : '') + '>sort: ' + s + '</option>').join('') + '</select>' +
+      '<button type="submit">Apply</button>' +
+      '</form>' +
+      '<div id="results" class="muted">Loading…</div>';
+
+    app.querySelector('form').addEventListener('submit', (event) => {
+      event.preventDefault();
+      const next = new URLSearchParams();
+      new FormData(event.target).forEach((v, k) => {
+        if (v) {
+          next.set(k, v);
+        }
+      });
+      location.hash = '#/?' + next.toString();
+    });
+
+    const query = new URLSearchParams();
+    listFields.forEach((name) => {
+      if (params.get(name)) {
+        query.set(name, params.get(name));
+      }
+    });
+
+    const results = document.getElementById('results');
+    try {
+      const list = await api('/features?' + query.toString());
+      if (list.length === 0) {
+        results.textContent = 'No features found';
+        return;
+      }
+      results.className = '';
+      results.innerHTML =
+        '<table><thead><tr><th>Name</th><th>State</th><th>Priority</th><th>Type</th><th>Tags</th><th>Modified</th></tr></thead><tbody>' +
+        list.map((f) => {
+          const keys = versionKeys(f);
+          const current = f.Versions && f.Versions[keys[keys.length - 1]];
+          return '<tr><td>' + featureLink(f.ID, f.Name) + '</td>' +
+            '<td>' + stateBadge(featureState(f)) + '</td>' +
+            '<td>' + esc(meta(f, 'priority')) + '</td>' +
+            '<td>' + esc(meta(f, 'type')) + '</td>' +
+            '<td>' + tagList(f.Tags) + '</td>' +
+            '<td class="muted">' + esc(formatDate(current && current.ModifiedAt)) + '</td></tr>';
+        }).join('') +
+        '</tbody></table>';
+    } catch (err) {
+      results.className = 'error';
+      results.textContent = err.message;
+    }
+  }
+
+  // --- feature detail ---
+
+  async function renderFeature(id) {
+    setActiveNav('features');
+    app.innerHTML = '<p class="muted">Loading…</p>';
+
+    let feature;
+    try {
+      feature = await api('/features/' + encodeURIComponent(id));
+    } catch (err) {
+      showError(err);
+      return;
+    }
+
+    const metadata = Object.keys(feature.Metadata || {}).sort();
+    const keys = versionKeys(feature).reverse();
+    const rels = feature.Relationships || [];
+
+    app.innerHTML =
+      '<div class="detail">' +
+      '<h1>' + esc(feature.Name) + '</h1>' +
+      '<p>' + stateBadge(featureState(feature)) + ' <span class="muted">' + esc(feature.ID) + '</span></p>' +
+      (feature.Tags && feature.Tags.length ? '<p>' + tagList(feature.Tags) + '</p>' : '') +
+      (feature.Description ? '<section><h2>Description</h2><div class="description">' + esc(feature.Description) + '</div></section>' : '') +
+      (metadata.length ? '<section><h2>Metadata</h2><dl class="meta">' +
+        metadata.map((k) => '<dt>' + esc(k) + '</dt><dd>' + esc(formatValue(feature.Metadata[k])) + '</dd>').join('') +
+        '</dl></section>' : '') +
+      '<section><h2>Relationships</h2>' + (rels.length ?
+        '<table><thead><tr><th>Type</th><th>Target</th><th>Description</th></tr></thead><tbody>' +
+        rels.map((r) => '<tr><td>' + esc(r.Type) + '</td><td>' + featureLink(r.TargetID, r.TargetName) + '</td><td>' + esc(r.Description) + '</td></tr>').join('') +
+        '</tbody></table>' : '<p class="muted">No relationships</p>') +
+      '</section>' +
+      '<section><h2>Version history</h2>' + (keys.length ?
+        '<table><thead><tr><th>Version</th><th>State</th><th>Created</th><th>Modified</th><th>Closed</th><th>Branch</th><th>Authors</th><th>Notes</th></tr></thead><tbody>' +
+        keys.map((k) => {
+          const v = feature.Versions[k];
+          return '<tr><td>' + esc(k) + '</td><td>' + stateBadge(versionState(v)) + '</td>' +
+            '<td>' + esc(formatDate(v.CreatedAt)) + '</td><td>' + esc(formatDate(v.ModifiedAt)) + '</td>' +
+            '<td>' + esc(formatDate(v.ClosedAt)) + '</td><td>' + esc(v.Branch) + '</td>' +
+            '<td>' + esc((v.Authors || []).join(', ')) + '</td><td>' + esc(v.Notes) + '</td></tr>';
+        }).join('') +
+        '</tbody></table>' : '<p class="muted">No versions</p>') +
+      '</section>' +
+      '<p><a href="#/graph?focus=' + encodeURIComponent(feature.ID) + '">Show in graph</a></p>' +
+      '</div>';
+  }
+
+  function formatValue(value) {
+    if (Array.isArray(value)) {
+      return value.join(', ');
+    }
+    if (value !== null && typeof value === 'object') {
+      return JSON.stringify(value);
+    }
+    return value;
+  }
+
+  // --- relationship graph ---
+
+  const svgNS = 'http://www.w3.org/2000/svg';
+
+  function svgEl(name, attrs) {
+    const el = document.createElementNS(svgNS, name);
+    Object.keys(attrs || {}).forEach((k) => el.setAttribute(k, attrs[k]));
+    return el;
+  }
+
+  function categoryColors(categories) {
+    const colors = {};
+    Object.keys(categories).sort().forEach((name, i) => {
+      colors[name] = categories[name].color || palette[i % palette.length];
+    });
+    return colors;
+  }
+
+  async function renderGraph(params) {
+    setActiveNav('graph');
+    app.innerHTML = '<p class="muted">Loading…</p>';
+
+    let graph;
+    try {
+      graph = await api('/graph' + (params.get('filter') ? '?filter=' + encodeURIComponent(params.get('filter')) : ''));
+    } catch (err) {
+      showError(err);
+      return;
+    }
+
+    const colors = categoryColors(graph.categories);
+    const hidden = new Set();
+    const focus = params.get('focus');
+
+    app.innerHTML =
+      '<div class="graph-toolbar">' +
+      '<form class="filters"><input name="filter" placeholder="Filter expression" value="' + esc(params.get('filter') || '') + '"><button type="submit">Apply</button></form>' +
+      '<div class="legend">' + Object.keys(colors).sort().map((name) =>
+        '<label title="' + esc(graph.categories[name].description) + '"><input type="checkbox" data-category="' + esc(name) + '" checked> ' +
+        '<span class="swatch" style="background:' + esc(colors[name]) + '"></span>' + esc(name) + '</label>').join('') +
+      '</div></div>' +
+      (graph.nodes.length ? '' : '<p class="muted">No features found</p>');
+
+    app.querySelector('form').addEventListener('submit', (event) => {
+      event.preventDefault();
+      const value = new FormData(event.target).get('filter');
+      location.hash = '#/graph' + (value ? '?filter=' + encodeURIComponent(value) : '');
+    });
+
+    if (!graph.nodes.length) {
+      return;
+    }
+
+    const svg = svgEl('svg', { id: 'graph' });
+    app.appendChild(svg);
+    const width = svg.clientWidth || 960;
+    const height = svg.clientHeight || 600;
+
+    // Arrow heads per category
+    const defs = svgEl('defs');
+    Object.keys(colors).forEach((name, i) => {
+      const marker = svgEl('marker', {
+        id: 'arrow-' + i, viewBox: '0 0 10 10', refX: 20, refY: 5,
+        markerWidth: 6, markerHeight: 6, orient: 'auto-start-reverse',
+      });
+      marker.appendChild(svgEl('path', { d: 'M 0 0 L 10 5 L 0 10 z', fill: colors[name] }));
+      defs.appendChild(marker);
+    });
+    svg.appendChild(defs);
+    const markerIndex = {};
+    Object.keys(colors).forEach((name, i) => { markerIndex[name] = i; });
+
+    const byId = {};
+    const nodes = graph.nodes.map((n, i) => {
+      const angle = (2 * Math.PI * i) / graph.nodes.length;
+      const node = Object.assign({}, n, {
+        x: width / 2 + (width / 3) * Math.cos(angle),
+        y: height / 2 + (height / 3) * Math.sin(angle),
+        vx: 0, vy: 0,
+      });
+      byId[n.id] = node;
+      return node;
+    });
+    const edges = graph.edges.filter((e) => byId[e.source] && byId[e.target]);
+
+    const edgeLayer = svgEl('g');
+    const nodeLayer = svgEl('g');
+    svg.appendChild(edgeLayer);
+    svg.appendChild(nodeLayer);
+
+    edges.forEach((e) => {
+      e.el = svgEl('line', { stroke: colors[e.category] || '#8c959f', 'marker-end': 'url(#arrow-' + markerIndex[e.category] + ')' });
+      const title = svgEl('title');
+      title.textContent = e.type;
+      e.el.appendChild(title);
+      edgeLayer.appendChild(e.el);
+    });
+
+    let dragging = null;
+    nodes.forEach((n) => {
+      n.el = svgEl('g', { class: 'node' });
+      const circle = svgEl('circle', { r: n.id === focus ? 11 : 8, fill: stateColors[n.state] || '#8250df' });
+      const title = svgEl('title');
+      title.textContent = n.name + ' (' + n.state + ')';
+      circle.appendChild(title);
+      const label = svgEl('text', { x: 12, y: 4 });
+      label.textContent = n.name;
+      n.el.appendChild(circle);
+      n.el.appendChild(label);
+      nodeLayer.appendChild(n.el);
+
+      let moved = false;
+      circle.addEventListener('pointerdown', (event) => {
+        dragging = n;
+        moved = false;
+        circle.setPointerCapture(event.pointerId);
+      });
+      circle.addEventListener('pointermove', (event) => {
+        if (dragging !== n) {
+          return;
+        }
+        const rect = svg.getBoundingClientRect();
+        n.x = event.clientX - rect.left;
+        n.y = event.clientY - rect.top;
+        moved = true;
+        kick();
+      });
+      circle.addEventListener('pointerup', () => {
+        dragging = null;
+        if (!moved) {
+          location.hash = '#/features/' + encodeURIComponent(n.id);
+        }
+      });
+    });
+
+    app.querySelectorAll('.legend input').forEach((box) => {
+      box.addEventListener('change', () => {
+        if (box.checked) {
+          hidden.delete(box.dataset.category);
+        } else {
+          hidden.add(box.dataset.category);
+        }
+        draw();
+        kick();
+      });
+    });
+
+    function visibleEdges() {
+      return edges.filter((e) => !hidden.has(e.category));
+    }
+
+    // Simple force layout: node repulsion, edge springs and a pull towards the center
+    function tick(alpha) {
+      for (let i = 0; i < nodes.length; i++) {
+        for (let j = i + 1; j < nodes.length; j++) {
+          const a = nodes[i];
+          const b = nodes[j];
+          let dx = b.x - a.x;
+          let dy = b.y - a.y;
+          const dist2 = Math.max(dx * dx + dy * dy, 25);
+          const force = (2000 * alpha) / dist2;
+          dx *= force;
+          dy *= force;
+          a.vx -= dx; a.vy -= dy;
+          b.vx += dx; b.vy += dy;
+        }
+      }
+      visibleEdges().forEach((e) => {
+        const a = byId[e.source];
+        const b = byId[e.target];
+        const dx = b.x - a.x;
+        const dy = b.y - a.y;
+        const dist = Math.sqrt(dx * dx + dy * dy) || 1;
+        const force = ((dist - 90) / dist) * 0.05 * alpha;
+        a.vx += dx * force; a.vy += dy * force;
+        b.vx -= dx * force; b.vy -= dy * force;
+      });
+      nodes.forEach((n) => {
+        n.vx += (width / 2 - n.x) * 0.005 * alpha;
+        n.vy += (height / 2 - n.y) * 0.005 * alpha;
+        if (n !== dragging) {
+          n.x = Math.min(width - 10, Math.max(10, n.x + n.vx));
+          n.y = Math.min(height - 10, Math.max(10, n.y + n.vy));
+        }
+        n.vx *= 0.6;
+        n.vy *= 0.6;
+      });
+    }
+
+    function draw() {
+      edges.forEach((e) => {
+        const a = byId[e.source];
+        const b = byId[e.target];
+        e.el.setAttribute('x1', a.x);
+        e.el.setAttribute('y1', a.y);
+        e.el.setAttribute('x2', b.x);
+        e.el.setAttribute('y2', b.y);
+        e.el.style.display = hidden.has(e.category) ? 'none' : '';
+      });
+      nodes.forEach((n) => n.el.setAttribute('transform', 'translate(' + n.x + ',' + n.y + ')'));
+    }
+
+    let alpha = 0;
+    function kick() {
+      const running = alpha > 0.01;
+      alpha = 1;
+      if (!running) {
+        requestAnimationFrame(step);
+      }
+    }
+    function step() {
+      if (!svg.isConnected) {
+        return; // Navigated away
+      }
+      tick(alpha);
+      draw();
+      alpha *= 0.97;
+      if (alpha > 0.01) {
+        requestAnimationFrame(step);
+      }
+    }
+    kick();
+  }
+
+  // --- routing ---
+
+  function route() {
+    const hash = location.hash.replace(/^#/, '') || '/';
+    const [path, query] = hash.split('?');
+    const params = new URLSearchParams(query || '');
+
+    if (path.startsWith('/features/')) {
+      renderFeature(decodeURIComponent(path.slice('/features/'.length)));
+    } else if (path === '/graph') {
+      renderGraph(params);
+    } else {
+      renderList(params);
+    }
+  }
+
+  window.addEventListener('hashchange', route);
+  route();
+})();
@@ -0,0 +1,451 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// cacheFormatVersion is bumped whenever the on-disk cache layout changes.
+// A cache written with a different version is discarded on load.
+const cacheFormatVersion = 1
+
+// FeatureCache is a persistent cache of parsed features with secondary indexes.
+// This is stored in .fogit/metadata/feature_cache.json
+//
+// Entries are keyed by feature filename and invalidated per file: an entry is
+// reused as long as the file's modification time and size are unchanged, or
+// when its content hash still matches after a re-read.
+type FeatureCache struct {
+	Version int                    `json:"version"`
+	Entries map[string]*CacheEntry `json:"entries"`
+	Indexes CacheIndexes           `json:"indexes"`
+
+	basePath string // Path to .fogit directory
+	dirty    bool   // True if the cache has unsaved changes
+	stale    bool   // True if entries changed since indexes were built
+	mu       sync.RWMutex
+}
+
+// CacheEntry holds the parsed form of a single feature file
+type CacheEntry struct {
+	ID      string          `json:"id"`
+	ModTime time.Time       `json:"mod_time"`
+	Size    int64           `json:"size"`
+	Hash    string          `json:"hash"`    // SHA-256 of the YAML content
+	Feature json.RawMessage `json:"feature"` // Parsed feature, JSON encoded
+}
+
+// CacheIndexes contains secondary indexes over cached features.
+// All index values are feature IDs, sorted for deterministic output.
+type CacheIndexes struct {
+	State    map[string][]string            `json:"state"`    // derived state -> IDs
+	Tags     map[string][]string            `json:"tags"`     // lowercased tag -> IDs
+	Metadata map[string]map[string][]string `json:"metadata"` // key -> lowercased scalar value -> IDs
+}
+
+// cachePath returns the path to the cache file
+func (c *FeatureCache) cachePath() string {
+	return filepath.Join(c.basePath, "metadata", "feature_cache.json")
+}
+
+// NewFeatureCache creates a new, empty feature cache for the given .fogit directory
+func NewFeatureCache(basePath string) *FeatureCache {
+	return &FeatureCache{
+		Version:  cacheFormatVersion,
+		Entries:  make(map[string]*CacheEntry),
+		Indexes:  newCacheIndexes(),
+		basePath: basePath,
+	}
+}
+
+func newCacheIndexes() CacheIndexes {
+	return CacheIndexes{
+		State:    make(map[string][]string),
+		Tags:     make(map[string][]string),
+		Metadata: make(map[string]map[string][]string),
+	}
+}
+
+// Load reads the cache from disk. A missing, corrupted or outdated cache
+// results in an empty cache rather than an error.
+func (c *FeatureCache) Load() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Version = cacheFormatVersion
+	c.Entries = make(map[string]*CacheEntry)
+	c.Indexes = newCacheIndexes()
+	c.dirty = false
+	c.stale = false
+
+	data, err := os.ReadFile(c.cachePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read feature cache: %w", err)
+	}
+
+	var stored FeatureCache
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != cacheFormatVersion || stored.Entries == nil {
+		// Corrupted or outdated cache, start fresh
+		c.dirty = true
+		c.stale = true
+		return nil
+	}
+
+	c.Entries = stored.Entries
+	c.Indexes = stored.Indexes
+	if c.Indexes.State == nil || c.Indexes.Tags == nil || c.Indexes.Metadata == nil {
+		c.dirty = true
+		c.stale = true
+	}
+	return nil
+}
+
+// Save writes the cache to disk atomically, rebuilding the secondary
+// indexes first if any entry changed.
+func (c *FeatureCache) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stale {
+		c.rebuildIndexesLocked()
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature cache: %w", err)
+	}
+
+	if err := writeMetadataFile(c.cachePath(), data); err != nil {
+		return err
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// IsDirty reports whether the cache has changes that have not been saved
+func (c *FeatureCache) IsDirty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dirty
+}
+
+// Read returns the feature stored in the given file, serving it from the cache
+// when the file is unchanged. info may be nil, in which case the file is stat'ed.
+func (c *FeatureCache) Read(path string, info os.FileInfo) (*fogit.Feature, error) {
+	entry, feature, err := c.refresh(path, info)
+	if err != nil {
+		return nil, err
+	}
+	if feature != nil {
+		return feature, nil
+	}
+	return decodeCachedFeature(entry.Feature)
+}
+
+// Refresh brings the entry for the given file up to date without decoding it.
+// Returns an error if the file is missing or does not contain a valid feature.
+func (c *FeatureCache) Refresh(path string, info os.FileInfo) error {
+	_, _, err := c.refresh(path, info)
+	return err
+}
+
+// refresh validates the cache entry for a file, re-reading and re-parsing the
+// file only when needed. The returned feature is non-nil only if it was parsed.
+func (c *FeatureCache) refresh(path string, info os.FileInfo) (*CacheEntry, *fogit.Feature, error) {
+	filename := filepath.Base(path)
+
+	if info == nil {
+		var err error
+		info, err = os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				c.Invalidate(filename)
+				return nil, nil, fogit.ErrNotFound
+			}
+			return nil, nil, fmt.Errorf("failed to stat file: %w", err)
+		}
+	}
+
+	// Fast path: modification time and size unchanged
+	c.mu.RLock()
+	entry := c.Entries[filename]
+	c.mu.RUnlock()
+	if entry != nil && entry.Size == info.Size() && entry.ModTime.Equal(info.ModTime()) {
+		return entry, nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.Invalidate(filename)
+			return nil, nil, fogit.ErrNotFound
+		}
+		return nil, nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	hash := contentHash(data)
+
+	// File was touched but content is identical: refresh stat info only
+	if entry != nil && entry.Hash == hash {
+		c.mu.Lock()
+		entry.ModTime = info.ModTime()
+		entry.Size = info.Size()
+		c.dirty = true
+		c.mu.Unlock()
+		return entry, nil, nil
+	}
+
+	feature, err := UnmarshalFeature(data)
+	if err != nil {
+		c.Invalidate(filename)
+		return nil, nil, err
+	}
+	if err := feature.Validate(); err != nil {
+		c.Invalidate(filename)
+		return nil, nil, fmt.Errorf("invalid feature in file: %w", err)
+	}
+
+	encoded, err := json.Marshal(feature)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode feature for cache: %w", err)
+	}
+
+	entry = &CacheEntry{
+		ID:      feature.ID,
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Hash:    hash,
+		Feature: encoded,
+	}
+	c.mu.Lock()
+	c.Entries[filename] = entry
+	c.dirty = true
+	c.stale = true
+	c.mu.Unlock()
+
+	return entry, feature, nil
+}
+
+// Invalidate removes the entry for a feature file
+func (c *FeatureCache) Invalidate(filename string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, exists := c.Entries[filename]; exists {
+		delete(c.Entries, filename)
+		c.dirty = true
+		c.stale = true
+	}
+}
+
+// Prune removes entries for files that are no longer present
+func (c *FeatureCache) Prune(present map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for filename := range c.Entries {
+		if !present[filename] {
+			delete(c.Entries, filename)
+			c.dirty = true
+			c.stale = true
+		}
+	}
+}
+
+// EntryID returns the feature ID cached for a filename, or empty string if not cached
+func (c *FeatureCache) EntryID(filename string) string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if entry := c.Entries[filename]; entry != nil {
+		return entry.ID
+	}
+	return ""
+}
+
+// Candidates returns the set of feature IDs that may match the filter according
+// to the secondary indexes. The second return value is false when the filter
+// has no indexed criteria, meaning every feature is a candidate.
+// Candidates are a superset of matches; callers must still apply filter.Matches.
+func (c *FeatureCache) Candidates(filter *fogit.Filter) (map[string]bool, bool) {
+	if filter == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stale {
+		c.rebuildIndexesLocked()
+	}
+
+	var sets [][]string
+	if filter.State != "" {
+		sets = append(sets, c.Indexes.State[string(filter.State)])
+	}
+	for _, tag := range filter.Tags {
+		sets = append(sets, c.Indexes.Tags[strings.ToLower(tag)])
+	}
+
+	metadataFilters := map[string]string{
+		"priority": string(filter.Priority),
+		"type":     filter.Type,
+		"category": filter.Category,
+		"domain":   filter.Domain,
+		"team":     filter.Team,
+		"epic":     filter.Epic,
+	}
+	for key, value := range metadataFilters {
+		if value == "" {
+			continue
+		}
+		sets = append(sets, c.Indexes.Metadata[key][strings.ToLower(value)])
+	}
+
+	if len(sets) == 0 {
+		return nil, false
+	}
+
+	candidates := make(map[string]bool, len(sets[0]))
+	for _, id := range sets[0] {
+		candidates[id] = true
+	}
+	for _, set := range sets[1:] {
+		next := make(map[string]bool, len(candidates))
+		for _, id := range set {
+			if candidates[id] {
+				next[id] = true
+			}
+		}
+		candidates = next
+	}
+	return candidates, true
+}
+
+// rebuildIndexesLocked reconstructs all secondary indexes from the cached entries.
+// Caller must hold the write lock.
+func (c *FeatureCache) rebuildIndexesLocked() {
+	indexes := newCacheIndexes()
+
+	for _, entry := range c.Entries {
+		feature, err := decodeCachedFeature(entry.Feature)
+		if err != nil {
+			continue
+		}
+		id := feature.ID
+
+		state := string(feature.DeriveState())
+		indexes.State[state] = append(indexes.State[state], id)
+
+		for _, tag := range feature.Tags {
+			key := strings.ToLower(tag)
+			indexes.Tags[key] = append(indexes.Tags[key], id)
+		}
+
+		for key, value := range feature.Metadata {
+			scalar, ok := scalarString(value)
+			if !ok {
+				continue
+			}
+			if indexes.Metadata[key] == nil {
+				indexes.Metadata[key] = make(map[string][]string)
+			}
+			scalar = strings.ToLower(scalar)
+			indexes.Metadata[key][scalar] = append(indexes.Metadata[key][scalar], id)
+		}
+	}
+
+	// Sort for deterministic output
+	for _, ids := range indexes.State {
+		sort.Strings(ids)
+	}
+	for _, ids := range indexes.Tags {
+		sort.Strings(ids)
+	}
+	for _, values := range indexes.Metadata {
+		for _, ids := range values {
+			sort.Strings(ids)
+		}
+	}
+
+	c.Indexes = indexes
+	c.stale = false
+}
+
+// contentHash returns the hex-encoded SHA-256 of data
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// scalarString converts a scalar metadata value to its string form
+func scalarString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case int, int64, float64, bool:
+		return fmt.Sprintf("%v", v), true
+	default:
+		return "", false
+	}
+}
+
+// decodeCachedFeature decodes a JSON encoded feature, restoring the value types
+// that YAML decoding produces for untyped fields (metadata, version constraints).
+func decodeCachedFeature(data json.RawMessage) (*fogit.Feature, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var feature fogit.Feature
+	if err := decoder.Decode(&feature); err != nil {
+		return nil, fmt.Errorf("failed to decode cached feature: %w", err)
+	}
+
+	for key, value := range feature.Metadata {
+		feature.Metadata[key] = normalizeJSONValue(value)
+	}
+	for i := range feature.Relationships {
+		if vc := feature.Relationships[i].VersionConstraint; vc != nil {
+			vc.Version = normalizeJSONValue(vc.Version)
+		}
+	}
+
+	return &feature, nil
+}
+
+// normalizeJSONValue converts json.Number values (recursively) to int or float64,
+// matching how yaml.v3 decodes numbers into interface{} values.
+func normalizeJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeJSONValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeJSONValue(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
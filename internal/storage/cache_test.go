@@ -0,0 +1,218 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestFeatureCache_ServesUnchangedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	feature := fogit.NewFeature("Cached Feature")
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// First list populates and saves the cache
+	if _, err := repo.List(ctx, nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "metadata", "feature_cache.json")); err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+
+	// Overwrite the file with same-size garbage and restore its mtime:
+	// a fresh repository must serve the feature without reading the file.
+	path := filepath.Join(tmpDir, "features", "cached-feature.yml")
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	garbage := make([]byte, info.Size())
+	for i := range garbage {
+		garbage[i] = '#'
+	}
+	if err := os.WriteFile(path, garbage, 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	features, err := NewFileRepository(tmpDir).List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(features) != 1 || features[0].ID != feature.ID {
+		t.Fatalf("List() = %v, want cached feature %s", features, feature.ID)
+	}
+}
+
+func TestFeatureCache_DetectsModifiedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	feature := fogit.NewFeature("Original Name")
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repo.List(ctx, nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	// Edit the file behind the repository's back
+	path := filepath.Join(tmpDir, "features", "original-name.yml")
+	feature.Description = "Edited externally"
+	if err := WriteFeatureFile(path, feature); err != nil {
+		t.Fatalf("WriteFeatureFile() error = %v", err)
+	}
+
+	features, err := NewFileRepository(tmpDir).List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(features) != 1 || features[0].Description != "Edited externally" {
+		t.Errorf("List() did not pick up external edit: %+v", features)
+	}
+}
+
+func TestFeatureCache_PrunesDeletedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	keep := fogit.NewFeature("Keep")
+	drop := fogit.NewFeature("Drop")
+	for _, f := range []*fogit.Feature{keep, drop} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if _, err := repo.List(ctx, nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(tmpDir, "features", "drop.yml")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+
+	features, err := NewFileRepository(tmpDir).List(ctx, nil)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(features) != 1 || features[0].ID != keep.ID {
+		t.Errorf("List() = %d features, want only %q", len(features), keep.Name)
+	}
+}
+
+func TestFeatureCache_Candidates(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	parent := fogit.NewFeature("Parent")
+	backend := fogit.NewFeature("Backend Task")
+	backend.SetType("Task")
+	backend.Tags = []string{"Backend", "api"}
+	backend.Relationships = []fogit.Relationship{fogit.NewRelationship("contained-by", parent.ID, parent.Name)}
+	frontend := fogit.NewFeature("Frontend Task")
+	frontend.SetType("task")
+	frontend.Tags = []string{"frontend"}
+	if err := frontend.UpdateState(fogit.StateClosed); err != nil {
+		t.Fatalf("UpdateState() error = %v", err)
+	}
+
+	for _, f := range []*fogit.Feature{parent, backend, frontend} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	if _, err := repo.List(ctx, nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	cache := repo.getCache()
+
+	tests := []struct {
+		name   string
+		filter *fogit.Filter
+		want   []string
+		narrow bool
+	}{
+		{"nil filter", nil, nil, false},
+		{"unindexed criteria only", &fogit.Filter{Search: "task"}, nil, false},
+		{"type is case-insensitive", &fogit.Filter{Type: "TASK"}, []string{backend.ID, frontend.ID}, true},
+		{"state", &fogit.Filter{State: fogit.StateClosed}, []string{frontend.ID}, true},
+		{"tags intersect", &fogit.Filter{Tags: []string{"backend", "API"}}, []string{backend.ID}, true},
+		{"parent is resolved by the reverse index", &fogit.Filter{Parent: parent.ID}, nil, false},
+		{"no match", &fogit.Filter{Type: "task", Tags: []string{"missing"}}, []string{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, narrowed := cache.Candidates(tt.filter)
+			if narrowed != tt.narrow {
+				t.Fatalf("narrowed = %v, want %v", narrowed, tt.narrow)
+			}
+			if !tt.narrow {
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Candidates() = %v, want %v", got, tt.want)
+			}
+			for _, id := range tt.want {
+				if !got[id] {
+					t.Errorf("Candidates() missing %s", id)
+				}
+			}
+		})
+	}
+
+	// Narrowed listing still returns exactly the matching features
+	features, err := repo.List(ctx, &fogit.Filter{Type: "task", State: fogit.StateOpen})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(features) != 1 || features[0].ID != backend.ID {
+		t.Errorf("List() = %d features, want only %q", len(features), backend.Name)
+	}
+}
+
+func TestFeatureCache_PreservesMetadataTypes(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	feature := fogit.NewFeature("Typed Metadata")
+	feature.SetMetadata("points", 5)
+	feature.SetMetadata("ratio", 0.5)
+	feature.SetMetadata("labels", []interface{}{"a", 2})
+	if err := repo.Create(ctx, feature); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if _, err := repo.List(ctx, nil); err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	// Second repository reads from the persisted cache
+	got, err := NewFileRepository(tmpDir).Get(ctx, feature.ID)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if v, ok := got.Metadata["points"].(int); !ok || v != 5 {
+		t.Errorf("points = %#v, want int 5", got.Metadata["points"])
+	}
+	if v, ok := got.Metadata["ratio"].(float64); !ok || v != 0.5 {
+		t.Errorf("ratio = %#v, want float64 0.5", got.Metadata["ratio"])
+	}
+	labels, ok := got.Metadata["labels"].([]interface{})
+	if !ok || len(labels) != 2 || labels[1] != 2 {
+		t.Errorf("labels = %#v, want [a 2]", got.Metadata["labels"])
+	}
+}
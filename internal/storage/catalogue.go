@@ -0,0 +1,263 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// catalogueFormatVersion is bumped whenever the on-disk catalogue layout changes.
+// A catalogue written with a different version is discarded on load.
+const catalogueFormatVersion = 1
+
+// BranchCatalogue is a persistent cache of the features on Git branches.
+// This is stored in .fogit/metadata/branch_catalogue.json
+//
+// Branches are keyed by name and the tip commit they were catalogued at, so a
+// branch that hasn't moved is served without touching Git. Parsed features are
+// shared by blob hash, so a moved branch only re-parses the files that changed,
+// and branches with the same features tree share their file list.
+type BranchCatalogue struct {
+	Version  int                          `json:"version"`
+	Branches map[string]*CataloguedBranch `json:"branches"`
+	Blobs    map[string]json.RawMessage   `json:"blobs"` // Blob hash -> parsed feature, JSON encoded (null = not a valid feature)
+
+	basePath string // Path to .fogit directory
+	dirty    bool   // True if the catalogue has unsaved changes
+	mu       sync.RWMutex
+}
+
+// CataloguedBranch records the feature files of a branch at a tip commit
+type CataloguedBranch struct {
+	Tip          string           `json:"tip"`
+	Tree         string           `json:"tree"` // Hash of the features tree, empty if the branch has none
+	Files        []CataloguedFile `json:"files"`
+	CataloguedAt time.Time        `json:"catalogued_at"`
+}
+
+// CataloguedFile is a feature file on a branch
+type CataloguedFile struct {
+	Path string `json:"path"`
+	Blob string `json:"blob"`
+}
+
+// CatalogueStats summarizes the contents of a branch catalogue
+type CatalogueStats struct {
+	Path     string
+	Branches int
+	Blobs    int
+	Size     int64 // Size of the catalogue file, 0 if it was never saved
+}
+
+// cataloguePath returns the path to the catalogue file
+func (c *BranchCatalogue) cataloguePath() string {
+	return filepath.Join(c.basePath, "metadata", "branch_catalogue.json")
+}
+
+// NewBranchCatalogue creates a new, empty branch catalogue for the given .fogit directory
+func NewBranchCatalogue(basePath string) *BranchCatalogue {
+	return &BranchCatalogue{
+		Version:  catalogueFormatVersion,
+		Branches: make(map[string]*CataloguedBranch),
+		Blobs:    make(map[string]json.RawMessage),
+		basePath: basePath,
+	}
+}
+
+// Load reads the catalogue from disk. A missing, corrupted or outdated catalogue
+// results in an empty catalogue rather than an error.
+func (c *BranchCatalogue) Load() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Version = catalogueFormatVersion
+	c.Branches = make(map[string]*CataloguedBranch)
+	c.Blobs = make(map[string]json.RawMessage)
+	c.dirty = false
+
+	data, err := os.ReadFile(c.cataloguePath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read branch catalogue: %w", err)
+	}
+
+	var stored BranchCatalogue
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Version != catalogueFormatVersion || stored.Branches == nil || stored.Blobs == nil {
+		// Corrupted or outdated catalogue, start fresh
+		c.dirty = true
+		return nil
+	}
+
+	c.Branches = stored.Branches
+	c.Blobs = stored.Blobs
+	return nil
+}
+
+// Save writes the catalogue to disk atomically
+func (c *BranchCatalogue) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("failed to marshal branch catalogue: %w", err)
+	}
+
+	if err := writeMetadataFile(c.cataloguePath(), data); err != nil {
+		return err
+	}
+
+	c.dirty = false
+	return nil
+}
+
+// Clear empties the catalogue and removes it from disk
+func (c *BranchCatalogue) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Branches = make(map[string]*CataloguedBranch)
+	c.Blobs = make(map[string]json.RawMessage)
+	c.dirty = false
+
+	if err := os.Remove(c.cataloguePath()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove branch catalogue: %w", err)
+	}
+	return nil
+}
+
+// IsDirty reports whether the catalogue has changes that have not been saved
+func (c *BranchCatalogue) IsDirty() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.dirty
+}
+
+// Branch returns the catalogued state of a branch
+func (c *BranchCatalogue) Branch(name string) (CataloguedBranch, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if b := c.Branches[name]; b != nil {
+		return *b, true
+	}
+	return CataloguedBranch{}, false
+}
+
+// FilesForTree returns the file list of any branch catalogued with the given features tree
+func (c *BranchCatalogue) FilesForTree(tree string) ([]CataloguedFile, bool) {
+	if tree == "" {
+		return nil, false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, b := range c.Branches {
+		if b.Tree == tree {
+			return b.Files, true
+		}
+	}
+	return nil, false
+}
+
+// SetBranch records the feature files of a branch at a tip commit
+func (c *BranchCatalogue) SetBranch(name string, branch CataloguedBranch) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Branches[name] = &branch
+	c.dirty = true
+}
+
+// Feature returns the feature parsed from a blob. The second return value is
+// false when the blob isn't catalogued; a catalogued blob that isn't a valid
+// feature returns nil, true.
+func (c *BranchCatalogue) Feature(blob string) (*fogit.Feature, bool) {
+	c.mu.RLock()
+	data, ok := c.Blobs[blob]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	if string(data) == "null" {
+		return nil, true
+	}
+	feature, err := decodeCachedFeature(data)
+	if err != nil {
+		return nil, false
+	}
+	return feature, true
+}
+
+// PutFeature catalogues the feature parsed from a blob; nil records that the
+// blob is not a valid feature
+func (c *BranchCatalogue) PutFeature(blob string, feature *fogit.Feature) error {
+	encoded, err := json.Marshal(feature)
+	if err != nil {
+		return fmt.Errorf("failed to encode feature for catalogue: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Blobs[blob] = encoded
+	c.dirty = true
+	return nil
+}
+
+// Prune removes branches that are not in present, and blobs no remaining branch refers to
+func (c *BranchCatalogue) Prune(present map[string]bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for name := range c.Branches {
+		if !present[name] {
+			delete(c.Branches, name)
+			c.dirty = true
+		}
+	}
+
+	referenced := make(map[string]bool)
+	for _, b := range c.Branches {
+		for _, f := range b.Files {
+			referenced[f.Blob] = true
+		}
+	}
+	for blob := range c.Blobs {
+		if !referenced[blob] {
+			delete(c.Blobs, blob)
+			c.dirty = true
+		}
+	}
+}
+
+// Stats summarizes the catalogue
+func (c *BranchCatalogue) Stats() CatalogueStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := CatalogueStats{
+		Path:     c.cataloguePath(),
+		Branches: len(c.Branches),
+		Blobs:    len(c.Blobs),
+	}
+	if info, err := os.Stat(stats.Path); err == nil {
+		stats.Size = info.Size()
+	}
+	return stats
+}
+
+// BranchNames returns the names of the catalogued branches, sorted
+func (c *BranchCatalogue) BranchNames() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, 0, len(c.Branches))
+	for name := range c.Branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
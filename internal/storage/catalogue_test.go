@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestBranchCatalogue_SaveLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	cat := NewBranchCatalogue(tmpDir)
+
+	feature := fogit.NewFeature("Login")
+	cat.SetBranch("feature/login", CataloguedBranch{
+		Tip:          "abc123",
+		Tree:         "tree1",
+		Files:        []CataloguedFile{{Path: ".fogit/features/login.yml", Blob: "blob1"}, {Path: ".fogit/features/broken.yml", Blob: "blob2"}},
+		CataloguedAt: time.Now().UTC(),
+	})
+	if err := cat.PutFeature("blob1", feature); err != nil {
+		t.Fatalf("PutFeature() error = %v", err)
+	}
+	if err := cat.PutFeature("blob2", nil); err != nil {
+		t.Fatalf("PutFeature(nil) error = %v", err)
+	}
+	if !cat.IsDirty() {
+		t.Error("catalogue should be dirty after changes")
+	}
+	if err := cat.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if cat.IsDirty() {
+		t.Error("catalogue should not be dirty after Save")
+	}
+
+	loaded := NewBranchCatalogue(tmpDir)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	entry, ok := loaded.Branch("feature/login")
+	if !ok || entry.Tip != "abc123" || len(entry.Files) != 2 {
+		t.Fatalf("Branch() = %+v, %v; want the saved entry", entry, ok)
+	}
+	if files, ok := loaded.FilesForTree("tree1"); !ok || len(files) != 2 {
+		t.Errorf("FilesForTree(tree1) = %v, %v; want 2 files", files, ok)
+	}
+	if got, ok := loaded.Feature("blob1"); !ok || got == nil || got.ID != feature.ID {
+		t.Errorf("Feature(blob1) = %v, %v; want %s", got, ok, feature.ID)
+	}
+	if got, ok := loaded.Feature("blob2"); !ok || got != nil {
+		t.Errorf("Feature(blob2) = %v, %v; want nil, true for an invalid blob", got, ok)
+	}
+	if _, ok := loaded.Feature("unknown"); ok {
+		t.Error("Feature(unknown) should not be catalogued")
+	}
+}
+
+func TestBranchCatalogue_LoadCorrupted(t *testing.T) {
+	tmpDir := t.TempDir()
+	cat := NewBranchCatalogue(tmpDir)
+	if err := writeMetadataFile(cat.cataloguePath(), []byte(`{"version": 0, "branches": {}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cat.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if stats := cat.Stats(); stats.Branches != 0 || stats.Blobs != 0 {
+		t.Errorf("Stats() = %+v, want an empty catalogue", stats)
+	}
+}
+
+func TestBranchCatalogue_PruneAndClear(t *testing.T) {
+	tmpDir := t.TempDir()
+	cat := NewBranchCatalogue(tmpDir)
+	cat.SetBranch("main", CataloguedBranch{Tip: "a", Files: []CataloguedFile{{Path: "x.yml", Blob: "shared"}}})
+	cat.SetBranch("feature/gone", CataloguedBranch{Tip: "b", Files: []CataloguedFile{{Path: "x.yml", Blob: "shared"}, {Path: "y.yml", Blob: "only-gone"}}})
+	for _, blob := range []string{"shared", "only-gone"} {
+		if err := cat.PutFeature(blob, fogit.NewFeature(blob)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cat.Prune(map[string]bool{"main": true})
+	if names := cat.BranchNames(); len(names) != 1 || names[0] != "main" {
+		t.Errorf("BranchNames() = %v, want [main]", names)
+	}
+	if _, ok := cat.Feature("shared"); !ok {
+		t.Error("blob still referenced by main was pruned")
+	}
+	if _, ok := cat.Feature("only-gone"); ok {
+		t.Error("blob of the pruned branch was kept")
+	}
+
+	if err := cat.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if err := cat.Clear(); err != nil {
+		t.Fatalf("Clear() error = %v", err)
+	}
+	if _, err := os.Stat(cat.cataloguePath()); !os.IsNotExist(err) {
+		t.Errorf("catalogue file still exists after Clear: %v", err)
+	}
+	if stats := cat.Stats(); stats.Branches != 0 || stats.Blobs != 0 || stats.Size != 0 {
+		t.Errorf("Stats() after Clear = %+v, want empty", stats)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 )
 
@@ -63,44 +64,13 @@ func (idx *IDIndex) Save() error {
 	idx.mu.RLock()
 	defer idx.mu.RUnlock()
 
-	path := idx.indexPath()
-
-	// Ensure metadata directory exists
-	metadataDir := filepath.Dir(path)
-	if err := os.MkdirAll(metadataDir, 0755); err != nil {
-		return fmt.Errorf("failed to create metadata directory: %w", err)
-	}
-
 	// Marshal to JSON with indentation for readability
 	data, err := json.MarshalIndent(idx.Entries, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal index: %w", err)
 	}
 
-	// Atomic write using temp file + rename
-	tmpFile, err := os.CreateTemp(metadataDir, ".id_index-*.tmp")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tmpPath := tmpFile.Name()
-
-	if _, err := tmpFile.Write(data); err != nil {
-		tmpFile.Close()
-		os.Remove(tmpPath)
-		return fmt.Errorf("failed to write index: %w", err)
-	}
-
-	if err := tmpFile.Close(); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("failed to close temp file: %w", err)
-	}
-
-	if err := os.Rename(tmpPath, path); err != nil {
-		os.Remove(tmpPath)
-		return fmt.Errorf("failed to rename index file: %w", err)
-	}
-
-	return nil
+	return writeMetadataFile(idx.indexPath(), data)
 }
 
 // Get returns the filename for a given ID, or empty string if not found
@@ -169,6 +139,58 @@ func (idx *IDIndex) Rebuild(featuresDir string) error {
 	return nil
 }
 
+// ensureMetadataDir creates the metadata directory if needed and makes it
+// ignore itself, so generated indexes never show up as uncommitted changes
+// even when .fogit/.gitignore is missing or was edited.
+func ensureMetadataDir(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	if _, err := os.Stat(gitignorePath); os.IsNotExist(err) {
+		if err := os.WriteFile(gitignorePath, []byte("*\n"), 0600); err != nil {
+			return fmt.Errorf("failed to create metadata .gitignore: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeMetadataFile writes data to a file in the metadata directory atomically
+// using a temp file + rename
+func writeMetadataFile(path string, data []byte) error {
+	metadataDir := filepath.Dir(path)
+	if err := ensureMetadataDir(metadataDir); err != nil {
+		return err
+	}
+
+	name := filepath.Base(path)
+	tmpFile, err := os.CreateTemp(metadataDir, "."+strings.TrimSuffix(name, filepath.Ext(name))+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to rename %s: %w", name, err)
+	}
+
+	return nil
+}
+
 // isYAMLFile checks if filename has a YAML extension
 func isYAMLFile(name string) bool {
 	ext := filepath.Ext(name)
@@ -13,9 +13,24 @@ import (
 
 // FileRepository implements fogit.Repository using YAML files
 type FileRepository struct {
-	basePath string   // Path to .fogit directory
-	index    *IDIndex // ID-to-filename index for O(1) lookups
-	indexMu  sync.Once
+	basePath  string   // Path to .fogit directory
+	index     *IDIndex // ID-to-filename index for O(1) lookups
+	indexMu   sync.Once
+	cache     *FeatureCache // Parsed feature cache with secondary indexes
+	cacheMu   sync.Once
+	reverse   *ReverseIndex // Target-to-source relationship index
+	reverseMu sync.Once
+
+	catalogue   *BranchCatalogue // Features on other Git branches, keyed by branch tip
+	catalogueMu sync.Once
+	searchIdx   *SearchIndex // Full-text search index
+	searchMu    sync.Once
+}
+
+// featureFile is a feature file found in the features directory
+type featureFile struct {
+	path string
+	info os.FileInfo
 }
 
 // NewFileRepository creates a new file-based repository
@@ -42,6 +57,59 @@ func (r *FileRepository) getIndex() *IDIndex {
 	return r.index
 }
 
+// getCache returns the feature cache, lazily loading it from disk
+func (r *FileRepository) getCache() *FeatureCache {
+	r.cacheMu.Do(func() {
+		r.cache = NewFeatureCache(r.basePath)
+		if err := r.cache.Load(); err != nil {
+			// Failed to load, start fresh
+			r.cache = NewFeatureCache(r.basePath)
+		}
+	})
+	return r.cache
+}
+
+// BranchCatalogue returns the cross-branch feature catalogue, lazily loading it from disk
+func (r *FileRepository) BranchCatalogue() *BranchCatalogue {
+	r.catalogueMu.Do(func() {
+		r.catalogue = NewBranchCatalogue(r.basePath)
+		if err := r.catalogue.Load(); err != nil {
+			// Failed to load, start fresh
+			r.catalogue = NewBranchCatalogue(r.basePath)
+		}
+	})
+	return r.catalogue
+}
+
+// SearchIndex returns the full-text search index, lazily loading it from disk
+func (r *FileRepository) SearchIndex() *SearchIndex {
+	r.searchMu.Do(func() {
+		r.searchIdx = NewSearchIndex(r.basePath)
+		if err := r.searchIdx.Load(); err != nil {
+			// Failed to load, start fresh
+			r.searchIdx = NewSearchIndex(r.basePath)
+		}
+	})
+	return r.searchIdx
+}
+
+// getReverseIndex returns the reverse-relationship index, lazily loading it from disk
+func (r *FileRepository) getReverseIndex() *ReverseIndex {
+	r.reverseMu.Do(func() {
+		r.reverse = NewReverseIndex(r.basePath)
+		if err := r.reverse.Load(); err != nil {
+			// Failed to load, start fresh
+			r.reverse = NewReverseIndex(r.basePath)
+		}
+	})
+	return r.reverse
+}
+
+// readFeature reads a feature file through the cache
+func (r *FileRepository) readFeature(path string, info os.FileInfo) (*fogit.Feature, error) {
+	return r.getCache().Read(path, info)
+}
+
 // featuresDir returns the path to the features directory
 func (r *FileRepository) featuresDir() string {
 	return filepath.Join(r.basePath, "features")
@@ -91,7 +159,7 @@ func (r *FileRepository) findFeatureFile(ctx context.Context, id string) (string
 		}
 
 		path := filepath.Join(featuresDir, entry.Name())
-		feature, err := ReadFeatureFile(path)
+		feature, err := r.readFeature(path, nil)
 		if err != nil {
 			continue
 		}
@@ -171,6 +239,8 @@ func (r *FileRepository) Create(ctx context.Context, feature *fogit.Feature) err
 	if err := WriteFeatureFile(path, feature); err != nil {
 		return err
 	}
+	r.getCache().Invalidate(filepath.Base(path))
+	r.indexRelationships(path, feature)
 
 	// Update index with new entry
 	idx := r.getIndex()
@@ -191,10 +261,13 @@ func (r *FileRepository) Get(ctx context.Context, id string) (*fogit.Feature, er
 		return nil, err
 	}
 
-	return ReadFeatureFile(path)
+	return r.readFeature(path, nil)
 }
 
-// List retrieves features matching the given filter
+// List retrieves features matching the given filter.
+// Unchanged feature files are served from the feature cache, and indexed filter
+// criteria (state, tags, metadata fields, parent) skip non-candidate features
+// without decoding them.
 func (r *FileRepository) List(ctx context.Context, filter *fogit.Filter) ([]*fogit.Feature, error) {
 	featuresDir := r.featuresDir()
 
@@ -203,12 +276,84 @@ func (r *FileRepository) List(ctx context.Context, filter *fogit.Filter) ([]*fog
 		return []*fogit.Feature{}, nil
 	}
 
+	allFiles, err := r.listFeatureFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := r.getCache()
+
+	// Pass 1: refresh cache entries for new or modified files
+	var files []featureFile
+	present := make(map[string]bool)
+	for _, file := range allFiles {
+		if err := cache.Refresh(file.path, file.info); err != nil {
+			// Skip invalid files but continue processing other features
+			continue
+		}
+		present[filepath.Base(file.path)] = true
+		files = append(files, file)
+	}
+	cache.Prune(present)
+
+	// Pass 2: collect matching features, skipping non-candidates
+	candidates, narrowed := cache.Candidates(filter)
+	if filter != nil && filter.Parent != "" {
+		candidates, narrowed = r.childCandidates(files, filter.Parent, candidates, narrowed)
+	}
+	var features []*fogit.Feature
+	for _, file := range files {
+		if narrowed && !candidates[cache.EntryID(filepath.Base(file.path))] {
+			continue
+		}
+
+		feature, err := cache.Read(file.path, file.info)
+		if err != nil {
+			continue
+		}
+
+		// Use Filter.Matches method for consistent filtering
+		if filter == nil || filter.Matches(feature) {
+			features = append(features, feature)
+		}
+	}
+
+	if cache.IsDirty() {
+		_ = cache.Save() // Best effort
+	}
+
+	return features, nil
+}
+
+// childCandidates narrows candidates to the features that are contained by
+// the parent according to the reverse-relationship index
+func (r *FileRepository) childCandidates(files []featureFile, parentID string, candidates map[string]bool, narrowed bool) (map[string]bool, bool) {
+	reverse := r.getReverseIndex()
+	r.syncReverseIndex(reverse, files)
+
+	children := make(map[string]bool)
+	for _, edge := range reverse.Incoming(parentID) {
+		if edge.Type == "contained-by" || edge.Type == "parent" {
+			if !narrowed || candidates[edge.SourceID] {
+				children[edge.SourceID] = true
+			}
+		}
+	}
+	return children, true
+}
+
+// listFeatureFiles returns the feature files in the features directory
+func (r *FileRepository) listFeatureFiles(ctx context.Context) ([]featureFile, error) {
+	featuresDir := r.featuresDir()
 	entries, err := os.ReadDir(featuresDir)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
 		return nil, fmt.Errorf("failed to read features directory: %w", err)
 	}
 
-	var features []*fogit.Feature
+	var files []featureFile
 	for _, entry := range entries {
 		// Check for cancellation before processing each file (if context provided)
 		if ctx != nil {
@@ -223,21 +368,89 @@ func (r *FileRepository) List(ctx context.Context, filter *fogit.Filter) ([]*fog
 		if entry.IsDir() || !common.IsYAMLFile(entry.Name()) {
 			continue
 		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, featureFile{path: filepath.Join(featuresDir, entry.Name()), info: info})
+	}
+	return files, nil
+}
+
+// syncReverseIndex re-indexes feature files that changed since they were
+// indexed and drops files that no longer exist
+func (r *FileRepository) syncReverseIndex(reverse *ReverseIndex, files []featureFile) {
+	present := make(map[string]bool, len(files))
+	for _, file := range files {
+		filename := filepath.Base(file.path)
+		present[filename] = true
+		if reverse.IsCurrent(filename, file.info) {
+			continue
+		}
 
-		path := filepath.Join(featuresDir, entry.Name())
-		feature, err := ReadFeatureFile(path)
+		feature, err := r.readFeature(file.path, file.info)
 		if err != nil {
-			// Log error but continue processing other features
+			reverse.Remove(filename)
 			continue
 		}
+		reverse.Set(filename, file.info, feature)
+	}
+	reverse.Prune(present)
 
-		// Use Filter.Matches method for consistent filtering
-		if filter == nil || filter.Matches(feature) {
-			features = append(features, feature)
+	if reverse.IsDirty() {
+		_ = reverse.Save() // Best effort
+	}
+}
+
+// indexRelationships records the relationships of a feature that was just written
+func (r *FileRepository) indexRelationships(path string, feature *fogit.Feature) {
+	reverse := r.getReverseIndex()
+	info, err := os.Stat(path)
+	if err != nil {
+		reverse.Remove(filepath.Base(path))
+	} else {
+		reverse.Set(filepath.Base(path), info, feature)
+	}
+	_ = reverse.Save() // Best effort
+}
+
+// IncomingEdges returns the relationships pointing at the target feature,
+// using the reverse-relationship index instead of reading every feature
+func (r *FileRepository) IncomingEdges(ctx context.Context, targetID string) ([]fogit.IncomingEdge, error) {
+	files, err := r.listFeatureFiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reverse := r.getReverseIndex()
+	r.syncReverseIndex(reverse, files)
+	return reverse.Incoming(targetID), nil
+}
+
+// VerifyReverseIndex rebuilds the reverse-relationship index from every feature
+// file and compares it with the stored index. A stale index is replaced and saved.
+func (r *FileRepository) VerifyReverseIndex(ctx context.Context) (bool, error) {
+	files, err := r.listFeatureFiles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	fresh := NewReverseIndex(r.basePath)
+	for _, file := range files {
+		feature, err := r.readFeature(file.path, file.info)
+		if err != nil {
+			continue
 		}
+		fresh.Set(filepath.Base(file.path), file.info, feature)
 	}
 
-	return features, nil
+	reverse := r.getReverseIndex()
+	stale := !reverse.SameEdges(fresh)
+	reverse.Replace(fresh)
+	if err := reverse.Save(); err != nil {
+		return stale, err
+	}
+	return stale, nil
 }
 
 // Update updates an existing feature
@@ -262,9 +475,16 @@ func (r *FileRepository) Update(ctx context.Context, feature *fogit.Feature) err
 		return fmt.Errorf("failed to read existing feature: %w", err)
 	}
 
+	cache := r.getCache()
+	cache.Invalidate(filepath.Base(oldPath))
+
 	// If name hasn't changed, just update in place (no rename needed)
 	if oldFeature.Name == feature.Name {
-		return WriteFeatureFile(oldPath, feature)
+		if err := WriteFeatureFile(oldPath, feature); err != nil {
+			return err
+		}
+		r.indexRelationships(oldPath, feature)
+		return nil
 	}
 
 	// Name changed - need to generate new path and rename file
@@ -283,6 +503,8 @@ func (r *FileRepository) Update(ctx context.Context, feature *fogit.Feature) err
 		os.Remove(newPath)
 		return fmt.Errorf("failed to remove old feature file: %w", err)
 	}
+	r.getReverseIndex().Remove(filepath.Base(oldPath))
+	r.indexRelationships(newPath, feature)
 	// Update index with new filename
 	idx := r.getIndex()
 	idx.Set(feature.ID, filepath.Base(newPath))
@@ -304,6 +526,10 @@ func (r *FileRepository) Delete(ctx context.Context, id string) error {
 	if err := os.Remove(path); err != nil {
 		return fmt.Errorf("failed to delete feature: %w", err)
 	}
+	r.getCache().Invalidate(filepath.Base(path))
+	reverse := r.getReverseIndex()
+	reverse.Remove(filepath.Base(path))
+	_ = reverse.Save() // Best effort
 
 	// Remove from index
 	idx := r.getIndex()
@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// ReverseIndex maps target feature IDs to the relationships pointing at them.
+// This is stored in .fogit/metadata/reverse_index.json
+//
+// Each indexed source file is stamped with its modification time and size so
+// that files changed outside of fogit (git checkout, manual edits) can be
+// re-indexed individually.
+type ReverseIndex struct {
+	Edges   map[string][]fogit.IncomingEdge `json:"edges"`   // target ID -> incoming edges
+	Sources map[string]*ReverseSource       `json:"sources"` // filename -> indexed source
+
+	basePath string // Path to .fogit directory
+	dirty    bool   // True if the index has unsaved changes
+	mu       sync.RWMutex
+}
+
+// ReverseSource records which targets a feature file links to
+type ReverseSource struct {
+	ID      string    `json:"id"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+	Targets []string  `json:"targets"` // Distinct target IDs
+}
+
+// indexPath returns the path to the reverse index file
+func (ri *ReverseIndex) indexPath() string {
+	return filepath.Join(ri.basePath, "metadata", "reverse_index.json")
+}
+
+// NewReverseIndex creates a new, empty reverse index for the given .fogit directory
+func NewReverseIndex(basePath string) *ReverseIndex {
+	return &ReverseIndex{
+		Edges:    make(map[string][]fogit.IncomingEdge),
+		Sources:  make(map[string]*ReverseSource),
+		basePath: basePath,
+	}
+}
+
+// Load reads the index from disk. A missing or corrupted index results in an
+// empty index rather than an error.
+func (ri *ReverseIndex) Load() error {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.Edges = make(map[string][]fogit.IncomingEdge)
+	ri.Sources = make(map[string]*ReverseSource)
+	ri.dirty = false
+
+	data, err := os.ReadFile(ri.indexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read reverse index: %w", err)
+	}
+
+	var stored ReverseIndex
+	if err := json.Unmarshal(data, &stored); err != nil || stored.Edges == nil || stored.Sources == nil {
+		// Corrupted index, every source will be re-indexed
+		ri.dirty = true
+		return nil
+	}
+
+	ri.Edges = stored.Edges
+	ri.Sources = stored.Sources
+	return nil
+}
+
+// Save writes the index to disk atomically
+func (ri *ReverseIndex) Save() error {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	data, err := json.Marshal(ri)
+	if err != nil {
+		return fmt.Errorf("failed to marshal reverse index: %w", err)
+	}
+
+	if err := writeMetadataFile(ri.indexPath(), data); err != nil {
+		return err
+	}
+
+	ri.dirty = false
+	return nil
+}
+
+// IsDirty reports whether the index has changes that have not been saved
+func (ri *ReverseIndex) IsDirty() bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	return ri.dirty
+}
+
+// IsCurrent reports whether the file is indexed with the given stat info
+func (ri *ReverseIndex) IsCurrent(filename string, info os.FileInfo) bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	source := ri.Sources[filename]
+	return source != nil && source.Size == info.Size() && source.ModTime.Equal(info.ModTime())
+}
+
+// Set indexes the relationships of the feature stored in filename,
+// replacing whatever was previously indexed for that file
+func (ri *ReverseIndex) Set(filename string, info os.FileInfo, feature *fogit.Feature) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+
+	ri.removeLocked(filename)
+
+	source := &ReverseSource{ID: feature.ID}
+	if info != nil {
+		source.ModTime = info.ModTime()
+		source.Size = info.Size()
+	}
+
+	seen := make(map[string]bool)
+	for _, rel := range feature.Relationships {
+		ri.Edges[rel.TargetID] = append(ri.Edges[rel.TargetID], fogit.IncomingEdge{
+			SourceID:       feature.ID,
+			RelationshipID: rel.ID,
+			Type:           string(rel.Type),
+		})
+		if !seen[rel.TargetID] {
+			seen[rel.TargetID] = true
+			source.Targets = append(source.Targets, rel.TargetID)
+		}
+	}
+	for _, target := range source.Targets {
+		sortEdges(ri.Edges[target])
+	}
+	sort.Strings(source.Targets)
+
+	ri.Sources[filename] = source
+	ri.dirty = true
+}
+
+// Remove drops everything indexed for a feature file
+func (ri *ReverseIndex) Remove(filename string) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.removeLocked(filename)
+}
+
+// Prune removes sources for files that are no longer present
+func (ri *ReverseIndex) Prune(present map[string]bool) {
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	for filename := range ri.Sources {
+		if !present[filename] {
+			ri.removeLocked(filename)
+		}
+	}
+}
+
+// removeLocked drops the edges contributed by a feature file.
+// Caller must hold the write lock.
+func (ri *ReverseIndex) removeLocked(filename string) {
+	source := ri.Sources[filename]
+	if source == nil {
+		return
+	}
+
+	for _, target := range source.Targets {
+		var remaining []fogit.IncomingEdge
+		for _, edge := range ri.Edges[target] {
+			if edge.SourceID != source.ID {
+				remaining = append(remaining, edge)
+			}
+		}
+		if len(remaining) == 0 {
+			delete(ri.Edges, target)
+		} else {
+			ri.Edges[target] = remaining
+		}
+	}
+
+	delete(ri.Sources, filename)
+	ri.dirty = true
+}
+
+// Incoming returns the edges pointing at the given feature ID
+func (ri *ReverseIndex) Incoming(targetID string) []fogit.IncomingEdge {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	edges := make([]fogit.IncomingEdge, len(ri.Edges[targetID]))
+	copy(edges, ri.Edges[targetID])
+	return edges
+}
+
+// SameEdges reports whether two indexes contain exactly the same edges
+func (ri *ReverseIndex) SameEdges(other *ReverseIndex) bool {
+	ri.mu.RLock()
+	defer ri.mu.RUnlock()
+	other.mu.RLock()
+	defer other.mu.RUnlock()
+	return reflect.DeepEqual(ri.Edges, other.Edges)
+}
+
+// Replace swaps in the contents of another index
+func (ri *ReverseIndex) Replace(other *ReverseIndex) {
+	other.mu.RLock()
+	edges, sources := other.Edges, other.Sources
+	other.mu.RUnlock()
+
+	ri.mu.Lock()
+	defer ri.mu.Unlock()
+	ri.Edges = edges
+	ri.Sources = sources
+	ri.dirty = true
+}
+
+// sortEdges orders edges by source, relationship ID and type for deterministic output
+func sortEdges(edges []fogit.IncomingEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].SourceID != edges[j].SourceID {
+			return edges[i].SourceID < edges[j].SourceID
+		}
+		if edges[i].RelationshipID != edges[j].RelationshipID {
+			return edges[i].RelationshipID < edges[j].RelationshipID
+		}
+		return edges[i].Type < edges[j].Type
+	})
+}
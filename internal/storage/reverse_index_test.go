@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// incomingSources returns the source IDs of the edges pointing at targetID
+func incomingSources(t *testing.T, repo *FileRepository, targetID string) []string {
+	t.Helper()
+	edges, err := repo.IncomingEdges(context.Background(), targetID)
+	if err != nil {
+		t.Fatalf("IncomingEdges() error = %v", err)
+	}
+	var sources []string
+	for _, edge := range edges {
+		sources = append(sources, edge.SourceID)
+	}
+	return sources
+}
+
+func TestReverseIndex_MaintainedOnWrite(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	target := fogit.NewFeature("Target")
+	source := fogit.NewFeature("Source")
+	source.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", target.ID, target.Name)}
+	for _, f := range []*fogit.Feature{target, source} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	if got := incomingSources(t, repo, target.ID); len(got) != 1 || got[0] != source.ID {
+		t.Fatalf("after Create: incoming = %v, want [%s]", got, source.ID)
+	}
+
+	// Rename and relink: old edges must go away with the old file
+	other := fogit.NewFeature("Other")
+	if err := repo.Create(ctx, other); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	source.Name = "Renamed Source"
+	source.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", other.ID, other.Name)}
+	if err := repo.Update(ctx, source); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if got := incomingSources(t, repo, target.ID); len(got) != 0 {
+		t.Errorf("after Update: incoming(target) = %v, want none", got)
+	}
+	if got := incomingSources(t, repo, other.ID); len(got) != 1 || got[0] != source.ID {
+		t.Errorf("after Update: incoming(other) = %v, want [%s]", got, source.ID)
+	}
+
+	if err := repo.Delete(ctx, source.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got := incomingSources(t, repo, other.ID); len(got) != 0 {
+		t.Errorf("after Delete: incoming(other) = %v, want none", got)
+	}
+
+	// The index is persisted for the next process
+	reloaded := NewReverseIndex(tmpDir)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(reloaded.Sources) != 2 || len(reloaded.Edges) != 0 {
+		t.Errorf("reloaded index: %d sources, %d targets; want 2, 0", len(reloaded.Sources), len(reloaded.Edges))
+	}
+}
+
+func TestReverseIndex_PicksUpExternalChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	target := fogit.NewFeature("Target")
+	source := fogit.NewFeature("Source")
+	for _, f := range []*fogit.Feature{target, source} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	// Add a relationship behind the repository's back (e.g. git checkout)
+	source.Relationships = []fogit.Relationship{fogit.NewRelationship("blocks", target.ID, target.Name)}
+	if err := WriteFeatureFile(filepath.Join(tmpDir, "features", "source.yml"), source); err != nil {
+		t.Fatalf("WriteFeatureFile() error = %v", err)
+	}
+
+	if got := incomingSources(t, NewFileRepository(tmpDir), target.ID); len(got) != 1 || got[0] != source.ID {
+		t.Errorf("incoming = %v, want [%s]", got, source.ID)
+	}
+
+	// Remove the source file externally
+	if err := os.Remove(filepath.Join(tmpDir, "features", "source.yml")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if got := incomingSources(t, NewFileRepository(tmpDir), target.ID); len(got) != 0 {
+		t.Errorf("incoming after removal = %v, want none", got)
+	}
+}
+
+func TestReverseIndex_VerifyRebuildsStaleIndex(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	target := fogit.NewFeature("Target")
+	source := fogit.NewFeature("Source")
+	source.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", target.ID, target.Name)}
+	for _, f := range []*fogit.Feature{target, source} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	stale, err := NewFileRepository(tmpDir).VerifyReverseIndex(ctx)
+	if err != nil {
+		t.Fatalf("VerifyReverseIndex() error = %v", err)
+	}
+	if stale {
+		t.Error("VerifyReverseIndex() reported a freshly written index as stale")
+	}
+
+	// Corrupt the stored edges while keeping the file stamps intact
+	index := NewReverseIndex(tmpDir)
+	if err := index.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	index.Edges = map[string][]fogit.IncomingEdge{"bogus": {{SourceID: source.ID, Type: "depends-on"}}}
+	if err := index.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	repo = NewFileRepository(tmpDir)
+	stale, err = repo.VerifyReverseIndex(ctx)
+	if err != nil {
+		t.Fatalf("VerifyReverseIndex() error = %v", err)
+	}
+	if !stale {
+		t.Error("VerifyReverseIndex() did not detect a stale index")
+	}
+	if got := incomingSources(t, repo, target.ID); len(got) != 1 || got[0] != source.ID {
+		t.Errorf("incoming after rebuild = %v, want [%s]", got, source.ID)
+	}
+	if got := incomingSources(t, repo, "bogus"); len(got) != 0 {
+		t.Errorf("bogus edges survived rebuild: %v", got)
+	}
+}
+
+func TestReverseIndex_ParentFilter(t *testing.T) {
+	tmpDir := t.TempDir()
+	repo := NewFileRepository(tmpDir)
+	ctx := context.Background()
+
+	parent := fogit.NewFeature("Parent")
+	child := fogit.NewFeature("Child")
+	child.Relationships = []fogit.Relationship{fogit.NewRelationship("contained-by", parent.ID, parent.Name)}
+	dependent := fogit.NewFeature("Dependent")
+	dependent.Relationships = []fogit.Relationship{fogit.NewRelationship("depends-on", parent.ID, parent.Name)}
+	for _, f := range []*fogit.Feature{parent, child, dependent} {
+		if err := repo.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	features, err := NewFileRepository(tmpDir).List(ctx, &fogit.Filter{Parent: parent.ID})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(features) != 1 || features[0].ID != child.ID {
+		t.Errorf("List(parent) = %d features, want only %q", len(features), child.Name)
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/eg3r/fogit/internal/search"
+)
+
+// SearchIndex is the persisted full-text search index.
+// This is stored in .fogit/metadata/search_index.json
+//
+// Features are re-indexed when their indexed text changes (see search.Index.Sync),
+// so only new and edited features are analyzed again.
+type SearchIndex struct {
+	*search.Index
+
+	basePath string // Path to .fogit directory
+}
+
+// searchIndexPath returns the path to the search index file
+func (s *SearchIndex) searchIndexPath() string {
+	return filepath.Join(s.basePath, "metadata", "search_index.json")
+}
+
+// NewSearchIndex creates a new, empty search index for the given .fogit directory
+func NewSearchIndex(basePath string) *SearchIndex {
+	return &SearchIndex{Index: search.NewIndex(), basePath: basePath}
+}
+
+// Load reads the index from disk. A missing, corrupted or outdated index
+// results in an empty index rather than an error.
+func (s *SearchIndex) Load() error {
+	s.Index = search.NewIndex()
+
+	data, err := os.ReadFile(s.searchIndexPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read search index: %w", err)
+	}
+
+	stored := search.NewIndex()
+	if err := json.Unmarshal(data, stored); err != nil || !stored.IsValid() {
+		// Corrupted or outdated index, every feature will be re-indexed
+		return nil
+	}
+
+	s.Index = stored
+	return nil
+}
+
+// Save writes the index to disk atomically
+func (s *SearchIndex) Save() error {
+	data, err := json.Marshal(s.Index)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+
+	if err := writeMetadataFile(s.searchIndexPath(), data); err != nil {
+		return err
+	}
+
+	s.MarkSaved()
+	return nil
+}
@@ -0,0 +1,58 @@
+package storage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/eg3r/fogit/internal/search"
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestSearchIndex_SaveLoad(t *testing.T) {
+	tmpDir := t.TempDir()
+	idx := NewSearchIndex(tmpDir)
+
+	feature := fogit.NewFeature("User Authentication")
+	feature.Description = "Login with single sign on"
+	idx.Sync([]*fogit.Feature{feature})
+	if err := idx.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if idx.IsDirty() {
+		t.Error("index should not be dirty after Save")
+	}
+
+	loaded := NewSearchIndex(tmpDir)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	results := loaded.Search(search.ParseQuery(`"single sign"`), search.SearchOptions{})
+	if len(results) != 1 || results[0].ID != feature.ID {
+		t.Errorf("Search() on loaded index = %+v, want %s", results, feature.ID)
+	}
+	if got := loaded.Sync([]*fogit.Feature{feature}); got != 0 {
+		t.Errorf("Sync() after Load re-indexed %d features, want 0", got)
+	}
+}
+
+func TestSearchIndex_LoadOutdated(t *testing.T) {
+	tmpDir := t.TempDir()
+	idx := NewSearchIndex(tmpDir)
+	if err := writeMetadataFile(idx.searchIndexPath(), []byte(`{"version": 0, "docs": {"x": {}}, "postings": {}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := idx.Load(); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if idx.Len() != 0 {
+		t.Errorf("Len() = %d, want an outdated index discarded", idx.Len())
+	}
+
+	if err := os.WriteFile(idx.searchIndexPath(), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := idx.Load(); err != nil || idx.Len() != 0 {
+		t.Errorf("Load() of a corrupted index = %v, Len() = %d; want an empty index", err, idx.Len())
+	}
+}
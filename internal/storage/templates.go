@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// TemplatesDir returns the directory holding feature templates
+func TemplatesDir(fogitDir string) string {
+	return filepath.Join(fogitDir, "templates")
+}
+
+// PRTemplateFile is the pull request description template in the templates directory
+const PRTemplateFile = "pr.md.tmpl"
+
+// LoadPRTemplate reads the pull request description template.
+// A missing template yields an empty string.
+func LoadPRTemplate(fogitDir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(TemplatesDir(fogitDir), PRTemplateFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read PR template: %w", err)
+	}
+	return string(data), nil
+}
+
+// ListTemplates loads all templates in .fogit/templates, sorted by name.
+// A missing templates directory yields no templates.
+func ListTemplates(fogitDir string) ([]*fogit.FeatureTemplate, error) {
+	entries, err := os.ReadDir(TemplatesDir(fogitDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var templates []*fogit.FeatureTemplate
+	for _, entry := range entries {
+		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+			continue
+		}
+		tpl, err := readTemplateFile(filepath.Join(TemplatesDir(fogitDir), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tpl)
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// LoadTemplate loads the template with the given name
+func LoadTemplate(fogitDir, name string) (*fogit.FeatureTemplate, error) {
+	if !fogit.IsValidTemplateName(name) {
+		return nil, fogit.NewNotFoundError("template", name)
+	}
+	for _, ext := range []string{".yml", ".yaml"} {
+		path := filepath.Join(TemplatesDir(fogitDir), name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return readTemplateFile(path)
+		}
+	}
+	return nil, fogit.NewNotFoundError("template", name)
+}
+
+// SaveTemplate writes a template to .fogit/templates/<name>.yml.
+// Existing templates are only replaced when overwrite is set.
+func SaveTemplate(fogitDir string, tpl *fogit.FeatureTemplate, overwrite bool) (string, error) {
+	if err := tpl.Validate(); err != nil {
+		return "", fmt.Errorf("invalid template: %w", err)
+	}
+
+	if !overwrite {
+		if _, err := LoadTemplate(fogitDir, tpl.Name); err == nil {
+			return "", fmt.Errorf("template '%s' already exists", tpl.Name)
+		}
+	}
+
+	data, err := yaml.Marshal(tpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal template: %w", err)
+	}
+
+	dir := TemplatesDir(fogitDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create templates directory: %w", err)
+	}
+
+	path := filepath.Join(dir, tpl.Name+".yml")
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up temp file on error
+		return "", fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return path, nil
+}
+
+// readTemplateFile parses a template, naming it after its file when the name is
+// omitted. Templates are looked up by file name, so a different name is rejected.
+func readTemplateFile(path string) (*fogit.FeatureTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var tpl fogit.FeatureTemplate
+	if err := yaml.Unmarshal(data, &tpl); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", filepath.Base(path), err)
+	}
+	stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if tpl.Name == "" {
+		tpl.Name = stem
+	}
+	if tpl.Name != stem {
+		return nil, fmt.Errorf("invalid template %s: name '%s' must match the file name '%s'", filepath.Base(path), tpl.Name, stem)
+	}
+	if err := tpl.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", filepath.Base(path), err)
+	}
+
+	return &tpl, nil
+}
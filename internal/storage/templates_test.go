@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestTemplates_SaveLoadList(t *testing.T) {
+	fogitDir := t.TempDir()
+
+	tpl := &fogit.FeatureTemplate{
+		Name:             "api-endpoint",
+		Type:             "api-endpoint",
+		Tags:             []string{"api"},
+		Metadata:         map[string]interface{}{"sla": "99.9"},
+		RequiredMetadata: []string{"owner"},
+		Relationships:    []fogit.TemplateRelationship{{Type: "contained-by", Target: "API Platform"}},
+	}
+	if _, err := SaveTemplate(fogitDir, tpl, false); err != nil {
+		t.Fatalf("SaveTemplate() error = %v", err)
+	}
+	if _, err := SaveTemplate(fogitDir, tpl, false); err == nil {
+		t.Error("SaveTemplate() overwrote an existing template without overwrite")
+	}
+
+	// Name falls back to the file name
+	if err := os.WriteFile(filepath.Join(TemplatesDir(fogitDir), "bugfix.yaml"), []byte("type: bug\n"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadTemplate(fogitDir, "api-endpoint")
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	if loaded.Type != "api-endpoint" || len(loaded.Relationships) != 1 || loaded.Metadata["sla"] != "99.9" {
+		t.Errorf("LoadTemplate() = %+v", loaded)
+	}
+
+	templates, err := ListTemplates(fogitDir)
+	if err != nil {
+		t.Fatalf("ListTemplates() error = %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "api-endpoint" || templates[1].Name != "bugfix" {
+		t.Errorf("ListTemplates() = %v, want api-endpoint and bugfix", templates)
+	}
+
+	if _, err := LoadTemplate(fogitDir, "missing"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("LoadTemplate(missing) error = %v, want ErrNotFound", err)
+	}
+	if _, err := LoadTemplate(fogitDir, "../config"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("LoadTemplate(../config) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestListTemplates_NoDirectory(t *testing.T) {
+	templates, err := ListTemplates(t.TempDir())
+	if err != nil || len(templates) != 0 {
+		t.Errorf("ListTemplates() = %v, %v; want no templates", templates, err)
+	}
+}
+
+func TestTemplates_NameMustMatchFile(t *testing.T) {
+	fogitDir := t.TempDir()
+	dir := TemplatesDir(fogitDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "bugfix.yml"), []byte("name: hotfix\ntype: bug\n"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	if _, err := LoadTemplate(fogitDir, "bugfix"); err == nil {
+		t.Error("LoadTemplate(bugfix) succeeded for a template named hotfix")
+	}
+	if _, err := LoadTemplate(fogitDir, "hotfix"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("LoadTemplate(hotfix) error = %v, want ErrNotFound", err)
+	}
+	if _, err := ListTemplates(fogitDir); err == nil {
+		t.Error("ListTemplates() listed a template whose name differs from its file")
+	}
+}
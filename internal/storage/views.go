@@ -0,0 +1,139 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+// ViewsDir returns the directory holding saved views
+func ViewsDir(fogitDir string) string {
+	return filepath.Join(fogitDir, "views")
+}
+
+// ListViews loads all views in .fogit/views, sorted by name.
+// A missing views directory yields no views.
+func ListViews(fogitDir string) ([]*fogit.SavedView, error) {
+	entries, err := os.ReadDir(ViewsDir(fogitDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read views directory: %w", err)
+	}
+
+	var views []*fogit.SavedView
+	for _, entry := range entries {
+		if entry.IsDir() || !isYAMLFile(entry.Name()) {
+			continue
+		}
+		view, err := readViewFile(filepath.Join(ViewsDir(fogitDir), entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, view)
+	}
+
+	sort.Slice(views, func(i, j int) bool {
+		return views[i].Name < views[j].Name
+	})
+	return views, nil
+}
+
+// LoadView loads the view with the given name
+func LoadView(fogitDir, name string) (*fogit.SavedView, error) {
+	path, err := viewPath(fogitDir, name)
+	if err != nil {
+		return nil, err
+	}
+	return readViewFile(path)
+}
+
+// SaveView writes a view to .fogit/views/<name>.yml.
+// Existing views are only replaced when overwrite is set.
+func SaveView(fogitDir string, view *fogit.SavedView, overwrite bool) (string, error) {
+	if err := view.Validate(); err != nil {
+		return "", fmt.Errorf("invalid view: %w", err)
+	}
+
+	path := filepath.Join(ViewsDir(fogitDir), view.Name+".yml")
+	if existing, err := viewPath(fogitDir, view.Name); err == nil {
+		if !overwrite {
+			return "", fmt.Errorf("view '%s' already exists (use --force to replace it)", view.Name)
+		}
+		path = existing
+	}
+
+	data, err := yaml.Marshal(view)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal view: %w", err)
+	}
+
+	if err := os.MkdirAll(ViewsDir(fogitDir), 0755); err != nil {
+		return "", fmt.Errorf("failed to create views directory: %w", err)
+	}
+
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, data, 0600); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath) // Clean up temp file on error
+		return "", fmt.Errorf("failed to rename temp file: %w", err)
+	}
+
+	return path, nil
+}
+
+// DeleteView removes the view with the given name
+func DeleteView(fogitDir, name string) error {
+	path, err := viewPath(fogitDir, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to delete view: %w", err)
+	}
+	return nil
+}
+
+// viewPath returns the file of an existing view
+func viewPath(fogitDir, name string) (string, error) {
+	if !fogit.IsValidViewName(name) {
+		return "", fogit.NewNotFoundError("view", name)
+	}
+	for _, ext := range []string{".yml", ".yaml"} {
+		path := filepath.Join(ViewsDir(fogitDir), name+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fogit.NewNotFoundError("view", name)
+}
+
+// readViewFile parses a view, naming it after its file when the name is omitted
+func readViewFile(path string) (*fogit.SavedView, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read view: %w", err)
+	}
+
+	var view fogit.SavedView
+	if err := yaml.Unmarshal(data, &view); err != nil {
+		return nil, fmt.Errorf("failed to parse view %s: %w", filepath.Base(path), err)
+	}
+	if view.Name == "" {
+		view.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if err := view.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid view %s: %w", filepath.Base(path), err)
+	}
+
+	return &view, nil
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/eg3r/fogit/pkg/fogit"
+)
+
+func TestViews_SaveLoadListDelete(t *testing.T) {
+	fogitDir := t.TempDir()
+
+	view := &fogit.SavedView{
+		Name:   "my-sprint",
+		Filter: "state IN (open, in-progress) AND depends-on.state=open",
+		Team:   "backend",
+		Tags:   []string{"api"},
+		Sort:   "priority",
+		Order:  "desc",
+		Format: "json",
+	}
+	if _, err := SaveView(fogitDir, view, false); err != nil {
+		t.Fatalf("SaveView() error = %v", err)
+	}
+	if _, err := SaveView(fogitDir, view, false); err == nil {
+		t.Error("SaveView() overwrote an existing view without overwrite")
+	}
+
+	// Name falls back to the file name
+	if err := os.WriteFile(filepath.Join(ViewsDir(fogitDir), "hot.yaml"), []byte("priority: high\n"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	loaded, err := LoadView(fogitDir, "my-sprint")
+	if err != nil {
+		t.Fatalf("LoadView() error = %v", err)
+	}
+	if loaded.Filter != view.Filter || loaded.Team != "backend" || loaded.Order != "desc" || len(loaded.Tags) != 1 {
+		t.Errorf("LoadView() = %+v", loaded)
+	}
+
+	views, err := ListViews(fogitDir)
+	if err != nil {
+		t.Fatalf("ListViews() error = %v", err)
+	}
+	if len(views) != 2 || views[0].Name != "hot" || views[1].Name != "my-sprint" {
+		t.Errorf("ListViews() = %v, want hot and my-sprint", views)
+	}
+
+	// Replacing keeps the existing file
+	hot := &fogit.SavedView{Name: "hot", Priority: "critical"}
+	if path, err := SaveView(fogitDir, hot, true); err != nil || filepath.Base(path) != "hot.yaml" {
+		t.Errorf("SaveView(overwrite) = %q, %v; want hot.yaml replaced", path, err)
+	}
+
+	if err := DeleteView(fogitDir, "my-sprint"); err != nil {
+		t.Fatalf("DeleteView() error = %v", err)
+	}
+	if _, err := LoadView(fogitDir, "my-sprint"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("LoadView() after delete error = %v, want ErrNotFound", err)
+	}
+	if err := DeleteView(fogitDir, "../config"); !errors.Is(err, fogit.ErrNotFound) {
+		t.Errorf("DeleteView(../config) error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestSaveView_Invalid(t *testing.T) {
+	tests := []*fogit.SavedView{
+		{Name: "bad name"},
+		{Name: "bad-filter", Filter: "(state:open"},
+		{Name: "bad-sort", Sort: "size"},
+		{Name: "bad-order", Order: "up"},
+	}
+
+	for _, view := range tests {
+		t.Run(view.Name, func(t *testing.T) {
+			if _, err := SaveView(t.TempDir(), view, false); err == nil {
+				t.Errorf("SaveView(%+v) should fail", view)
+			}
+		})
+	}
+}
+
+func TestListViews_NoDirectory(t *testing.T) {
+	views, err := ListViews(t.TempDir())
+	if err != nil || views != nil {
+		t.Errorf("ListViews() = %v, %v; want no views", views, err)
+	}
+}
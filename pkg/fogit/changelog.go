@@ -0,0 +1,51 @@
+package fogit
+
+import (
+	"fmt"
+	"text/template"
+)
+
+// DefaultChangelogEntryTemplate renders one closed feature version in Markdown release notes
+const DefaultChangelogEntryTemplate = `- **{{.Name}}**{{if .Version}} (v{{.Version}}){{end}}{{if .Notes}}: {{.Notes}}{{end}}`
+
+// ChangelogConfig customizes release notes generated by 'fogit changelog'
+// Without sections, entries are grouped by GroupBy (type, category, priority or a metadata key).
+type ChangelogConfig struct {
+	GroupBy       string             `yaml:"group_by,omitempty"`       // Default: type
+	EntryTemplate string             `yaml:"entry_template,omitempty"` // text/template for each entry
+	Sections      []ChangelogSection `yaml:"sections,omitempty"`
+}
+
+// ChangelogSection is a titled part of the release notes.
+// An entry goes into the first section whose filter expression matches its feature.
+type ChangelogSection struct {
+	Title    string `yaml:"title"`
+	Filter   string `yaml:"filter,omitempty"`   // Filter expression; empty matches everything
+	Template string `yaml:"template,omitempty"` // Overrides entry_template for this section
+}
+
+// validate checks that section filters and templates parse. Relationship
+// types in section filters are resolved with relationType.
+func (c *ChangelogConfig) validate(relationType func(string) (string, bool)) error {
+	if c.EntryTemplate != "" {
+		if _, err := template.New("entry").Parse(c.EntryTemplate); err != nil {
+			return fmt.Errorf("changelog.entry_template: %w", err)
+		}
+	}
+	for i, section := range c.Sections {
+		if section.Title == "" {
+			return fmt.Errorf("changelog.sections[%d]: title is required", i)
+		}
+		if section.Filter != "" {
+			if _, err := ParseFilterExprWith(section.Filter, FilterParseOptions{RelationType: relationType}); err != nil {
+				return fmt.Errorf("changelog.sections[%d].filter: %w", i, err)
+			}
+		}
+		if section.Template != "" {
+			if _, err := template.New("entry").Parse(section.Template); err != nil {
+				return fmt.Errorf("changelog.sections[%d].template: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
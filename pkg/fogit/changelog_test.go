@@ -0,0 +1,45 @@
+package fogit
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConfig_Validate_Changelog(t *testing.T) {
+	tests := []struct {
+		name      string
+		changelog *ChangelogConfig
+		wantErr   string
+	}{
+		{
+			name: "valid",
+			changelog: &ChangelogConfig{
+				EntryTemplate: "- {{.Name}}",
+				Sections:      []ChangelogSection{{Title: "Fixes", Filter: "type:bugfix", Template: "- Fixed {{.Name}}"}},
+			},
+		},
+		{name: "bad entry template", changelog: &ChangelogConfig{EntryTemplate: "{{.Name"}, wantErr: "changelog.entry_template"},
+		{name: "missing title", changelog: &ChangelogConfig{Sections: []ChangelogSection{{Filter: "type:bugfix"}}}, wantErr: "title is required"},
+		{name: "bad filter", changelog: &ChangelogConfig{Sections: []ChangelogSection{{Title: "X", Filter: "(type:bugfix"}}}, wantErr: "changelog.sections[0].filter"},
+		{name: "unknown field or relationship type", changelog: &ChangelogConfig{Sections: []ChangelogSection{{Title: "X", Filter: "stat:open"}}}, wantErr: "changelog.sections[0].filter"},
+		{name: "relationship predicate", changelog: &ChangelogConfig{Sections: []ChangelogSection{{Title: "X", Filter: "incoming(blocked-by) > 0 AND depends-on.state=closed"}}}},
+		{name: "bad section template", changelog: &ChangelogConfig{Sections: []ChangelogSection{{Title: "X", Template: "{{end}}"}}}, wantErr: "changelog.sections[0].template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Changelog = tt.changelog
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package fogit
 
 import (
 	"fmt"
+	"regexp"
 	"time"
 )
 
@@ -31,10 +32,54 @@ type Config struct {
 	Workflow        WorkflowConfig      `yaml:"workflow"`
 	AutoCommit      bool                `yaml:"auto_commit"`
 	CommitTemplate  string              `yaml:"commit_template"`
+	CommitTrailers  CommitTrailers      `yaml:"commit_trailers"`
 	AutoPush        bool                `yaml:"auto_push"`
 	Relationships   RelationshipsConfig `yaml:"relationships"`
 	FeatureSearch   FeatureSearchConfig `yaml:"feature_search"`
 	DefaultPriority string              `yaml:"default_priority,omitempty"` // Optional default priority for new features
+	MetadataSchema  *MetadataSchema     `yaml:"metadata_schema,omitempty"`  // Optional typed metadata fields
+	Changelog       *ChangelogConfig    `yaml:"changelog,omitempty"`        // Optional release notes sections
+}
+
+// CommitTrailers configures the trailers FoGit appends to commit messages
+// to link commits to features (e.g. "Fogit-Feature: <id>")
+type CommitTrailers struct {
+	Enabled    *bool  `yaml:"enabled,omitempty"` // Append trailers (nil = true)
+	FeatureKey string `yaml:"feature_key"`       // Trailer holding the feature ID
+	VersionKey string `yaml:"version_key"`       // Trailer holding the feature version; "-" omits it
+}
+
+// Default commit trailer keys
+const (
+	DefaultFeatureTrailer = "Fogit-Feature"
+	DefaultVersionTrailer = "Fogit-Version"
+)
+
+var trailerKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9-]*$`)
+
+// IsEnabled reports whether trailers are appended to commits (the default)
+func (t CommitTrailers) IsEnabled() bool {
+	return t.Enabled == nil || *t.Enabled
+}
+
+// FeatureTrailerKey returns the trailer key for feature IDs
+func (t CommitTrailers) FeatureTrailerKey() string {
+	if t.FeatureKey == "" {
+		return DefaultFeatureTrailer
+	}
+	return t.FeatureKey
+}
+
+// VersionTrailerKey returns the trailer key for versions, or "" when omitted
+func (t CommitTrailers) VersionTrailerKey() string {
+	switch t.VersionKey {
+	case "":
+		return DefaultVersionTrailer
+	case "-":
+		return ""
+	default:
+		return t.VersionKey
+	}
 }
 
 // RepositoryConfig contains repository metadata
@@ -52,11 +97,12 @@ type UIConfig struct {
 
 // WorkflowConfig contains Git workflow settings
 type WorkflowConfig struct {
-	Mode                string `yaml:"mode"`                  // "branch-per-feature" or "trunk-based"
-	BaseBranch          string `yaml:"base_branch"`           // Trunk branch name (default: "main")
-	CreateBranchFrom    string `yaml:"create_branch_from"`    // Where to create feature branches from: "trunk", "warn", "current"
-	AllowSharedBranches bool   `yaml:"allow_shared_branches"` // Allow --same flag for shared branches
-	VersionFormat       string `yaml:"version_format"`        // "simple" (1, 2, 3) or "semantic" (1.0.0, 1.1.0, 2.0.0)
+	Mode                string        `yaml:"mode"`                  // "branch-per-feature" or "trunk-based"
+	BaseBranch          string        `yaml:"base_branch"`           // Trunk branch name (default: "main")
+	CreateBranchFrom    string        `yaml:"create_branch_from"`    // Where to create feature branches from: "trunk", "warn", "current"
+	AllowSharedBranches bool          `yaml:"allow_shared_branches"` // Allow --same flag for shared branches
+	VersionFormat       string        `yaml:"version_format"`        // "simple" (1, 2, 3) or "semantic" (1.0.0, 1.1.0, 2.0.0)
+	States              []StateConfig `yaml:"states,omitempty"`      // Custom workflow states (built-ins always exist)
 }
 
 // RelationshipsConfig contains relationship system configuration
@@ -128,6 +174,10 @@ func DefaultConfig() *Config {
 		AutoCommit:     true,
 		CommitTemplate: "feat: {title} ({id})",
 		AutoPush:       false,
+		CommitTrailers: CommitTrailers{
+			FeatureKey: DefaultFeatureTrailer,
+			VersionKey: DefaultVersionTrailer,
+		},
 		FeatureSearch: FeatureSearchConfig{
 			FuzzyMatch:     true,
 			MinSimilarity:  60.0,
@@ -274,6 +324,21 @@ func DefaultConfig() *Config {
 	}
 }
 
+// ResolveRelationshipType returns the configured relationship type for a type name or alias
+func (c *Config) ResolveRelationshipType(name string) (string, bool) {
+	if _, exists := c.Relationships.Types[name]; exists {
+		return name, true
+	}
+	for typeName, typeDef := range c.Relationships.Types {
+		for _, alias := range typeDef.Aliases {
+			if alias == name {
+				return typeName, true
+			}
+		}
+	}
+	return "", false
+}
+
 // Validate checks the configuration for integrity and consistency
 func (c *Config) Validate() error {
 	// 1. Validate defaults reference existing types and categories
@@ -362,6 +427,10 @@ func (c *Config) Validate() error {
 			c.Workflow.CreateBranchFrom)
 	}
 
+	if err := c.Workflow.StateMachine().validateConfigs(c.Workflow.States); err != nil {
+		return err
+	}
+
 	// 5. Validate default priority if set
 	if c.DefaultPriority != "" {
 		validPriorities := []string{"low", "medium", "high", "critical"}
@@ -378,5 +447,27 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// 6. Validate metadata schema field definitions
+	if c.MetadataSchema != nil {
+		if err := c.MetadataSchema.validate(); err != nil {
+			return err
+		}
+	}
+
+	// 7. Validate commit trailer keys (empty uses the default)
+	if key := c.CommitTrailers.FeatureKey; key != "" && !trailerKeyPattern.MatchString(key) {
+		return fmt.Errorf("commit_trailers.feature_key '%s' is invalid (must contain only letters, digits and dashes)", key)
+	}
+	if key := c.CommitTrailers.VersionKey; key != "" && key != "-" && !trailerKeyPattern.MatchString(key) {
+		return fmt.Errorf("commit_trailers.version_key '%s' is invalid (must contain only letters, digits and dashes, or '-' to omit)", key)
+	}
+
+	// 8. Validate changelog sections
+	if c.Changelog != nil {
+		if err := c.Changelog.validate(c.ResolveRelationshipType); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -155,6 +155,30 @@ func TestDefaultConfigImmutability(t *testing.T) {
 	}
 }
 
+func TestConfig_ResolveRelationshipType(t *testing.T) {
+	cfg := DefaultConfig()
+
+	tests := []struct {
+		name   string
+		want   string
+		wantOK bool
+	}{
+		{"depends-on", "depends-on", true},
+		{"requires", "depends-on", true},
+		{"required-by", "required-by", true},
+		{"owned-by", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := cfg.ResolveRelationshipType(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ResolveRelationshipType(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
 func TestConfigValidation(t *testing.T) {
 	tests := []struct {
 		name    string
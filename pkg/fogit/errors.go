@@ -23,6 +23,7 @@ var (
 	ErrRepositoryNotInitialized = errors.New("fogit repository not initialized")
 	ErrFeatureAlreadyExists     = errors.New("feature already exists")
 	ErrNotFound                 = errors.New("not found")
+	ErrReadOnly                 = errors.New("repository is read-only")
 )
 
 // NotFoundError provides detailed context for not found errors
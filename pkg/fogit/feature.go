@@ -15,12 +15,14 @@ import (
 // Per spec 06-data-model.md: Each version tracks its own timestamps for state derivation,
 // which branch it was on, who worked on it, and optional notes
 type FeatureVersion struct {
-	CreatedAt  time.Time  `yaml:"created_at"`            // When this version was started
-	ModifiedAt time.Time  `yaml:"modified_at,omitempty"` // When this version was last modified (for state derivation)
-	ClosedAt   *time.Time `yaml:"closed_at,omitempty"`   // When this version was completed (null if open)
-	Branch     string     `yaml:"branch,omitempty"`      // Git branch name (e.g., feature/login-endpoint-v2)
-	Authors    []string   `yaml:"authors,omitempty"`     // All unique authors in this version
-	Notes      string     `yaml:"notes,omitempty"`       // Optional description/rationale for version
+	CreatedAt    time.Time  `yaml:"created_at"`              // When this version was started
+	ModifiedAt   time.Time  `yaml:"modified_at,omitempty"`   // When this version was last modified (for state derivation)
+	ClosedAt     *time.Time `yaml:"closed_at,omitempty"`     // When this version was completed (null if open)
+	Branch       string     `yaml:"branch,omitempty"`        // Git branch name (e.g., feature/login-endpoint-v2)
+	ParentBranch string     `yaml:"parent_branch,omitempty"` // Branch this version's branch is stacked on (empty = base branch)
+	Authors      []string   `yaml:"authors,omitempty"`       // All unique authors in this version
+	Notes        string     `yaml:"notes,omitempty"`         // Optional description/rationale for version
+	State        string     `yaml:"state,omitempty"`         // Custom workflow state (empty = derived from timestamps)
 }
 
 // Feature represents a trackable item in FoGit
@@ -59,7 +61,8 @@ type Feature struct {
 // - open: closed_at == null AND created_at == modified_at (no changes since creation)
 // - in-progress: closed_at == null AND created_at < modified_at (has been modified)
 // - closed: closed_at != null (feature complete, merged)
-// The State field is kept for backward compatibility but should match DeriveState()
+// Custom workflow states configured in workflow.states are stored on the current
+// version and take precedence over the derived state.
 type State string
 
 const (
@@ -120,6 +123,8 @@ func (f *Feature) GetCurrentVersion() *FeatureVersion {
 //	if version.created_at == version.modified_at: state = "open"
 //	elif version.closed_at == null: state = "in-progress"
 //	else: state = "closed"
+//
+// A custom workflow state stored on the current version overrides the derivation.
 func (f *Feature) DeriveState() State {
 	// Get current version
 	currentVersion := f.GetCurrentVersion()
@@ -129,6 +134,11 @@ func (f *Feature) DeriveState() State {
 		return StateOpen
 	}
 
+	// Custom workflow state (e.g. review, blocked)
+	if currentVersion.State != "" {
+		return State(currentVersion.State)
+	}
+
 	// Per spec: derive state from current version timestamps
 	if currentVersion.ClosedAt != nil {
 		return StateClosed
@@ -139,6 +149,13 @@ func (f *Feature) DeriveState() State {
 	return StateInProgress
 }
 
+// IsClosed reports whether the current version is closed, regardless of which
+// closed workflow state it is in
+func (f *Feature) IsClosed() bool {
+	currentVersion := f.GetCurrentVersion()
+	return currentVersion != nil && currentVersion.ClosedAt != nil
+}
+
 // Priority represents feature priority
 type Priority string
 
@@ -318,7 +335,8 @@ func (f *Feature) Validate() error {
 	return nil
 }
 
-// IsValid checks if the state is valid (per spec: only open, in-progress, closed)
+// IsValid checks if the state is one of the built-in states (open, in-progress, closed).
+// Use StateMachine.IsValid to include configured workflow states.
 func (s State) IsValid() bool {
 	switch s {
 	case StateOpen, StateInProgress, StateClosed:
@@ -336,42 +354,29 @@ func (p Priority) IsValid() bool {
 	return false
 }
 
-// CanTransitionTo checks if state transition is allowed
+// CanTransitionTo checks if state transition is allowed in the built-in workflow
 // Per spec 02-concepts.md: open -> in-progress -> closed (and closed -> open for reopen)
 func (s State) CanTransitionTo(target State) bool {
-	// Define allowed transitions per spec
-	transitions := map[State][]State{
-		StateOpen:       {StateInProgress, StateClosed},
-		StateInProgress: {StateClosed},
-		StateClosed:     {StateOpen}, // Can reopen (create new version)
-	}
-
-	allowed, ok := transitions[s]
-	if !ok {
-		return false
-	}
+	return DefaultStateMachine().CanTransition(s, target)
+}
 
-	for _, allowedStatus := range allowed {
-		if allowedStatus == target {
-			return true
-		}
-	}
-	return false
+// UpdateState updates the feature state using the built-in workflow.
+// See TransitionState for configured workflows.
+func (f *Feature) UpdateState(newState State) error {
+	return f.TransitionState(DefaultStateMachine(), newState)
 }
 
-// UpdateState updates the feature state by manipulating timestamps
+// TransitionState moves the feature to newState if the workflow allows it.
 // Per spec 06-data-model.md, state is derived from current version timestamps:
-//   - To transition to closed: set ClosedAt timestamp on current version
+//   - To transition to a closed state: set ClosedAt timestamp on current version
 //   - To transition to in-progress: ensure ModifiedAt > CreatedAt on current version
 //   - To transition to open: clear ClosedAt (only valid for reopening via new version)
-func (f *Feature) UpdateState(newState State) error {
-	if !newState.IsValid() {
-		return ErrInvalidState
-	}
-
+//
+// Custom states are additionally recorded on the current version.
+func (f *Feature) TransitionState(sm *StateMachine, newState State) error {
 	currentState := f.DeriveState()
-	if !currentState.CanTransitionTo(newState) {
-		return errors.New("invalid state transition")
+	if err := sm.ValidateTransition(currentState, newState); err != nil {
+		return err
 	}
 
 	now := time.Now().UTC()
@@ -380,21 +385,27 @@ func (f *Feature) UpdateState(newState State) error {
 		return errors.New("no version exists to update state")
 	}
 
-	switch newState {
-	case StateClosed:
+	switch {
+	case sm.IsClosed(newState):
 		// Set closed_at to mark as closed
 		currentVersion.ClosedAt = &now
 		currentVersion.ModifiedAt = now
-	case StateInProgress:
+	case newState == StateOpen:
+		// This typically only happens via ReopenFeature (new version)
+		currentVersion.ClosedAt = nil
+	default:
 		// Ensure modified_at > created_at to derive in-progress state
 		if !currentVersion.CreatedAt.Before(currentVersion.ModifiedAt) {
 			// Add 1 nanosecond to guarantee ModifiedAt > CreatedAt
 			currentVersion.ModifiedAt = currentVersion.CreatedAt.Add(time.Nanosecond)
 		}
 		currentVersion.ClosedAt = nil
-	case StateOpen:
-		// This typically only happens via ReopenFeature (new version)
-		currentVersion.ClosedAt = nil
+	}
+
+	// Built-in states are derived from timestamps, custom states are stored
+	currentVersion.State = ""
+	if !isBuiltinState(newState) {
+		currentVersion.State = string(newState)
 	}
 
 	return nil
@@ -466,7 +477,7 @@ func IncrementVersion(current string, format string, increment VersionIncrement)
 // - Transitions to in-progress after first commit/modification
 // Returns error if feature is not closed
 func (f *Feature) ReopenFeature(currentVersionStr string, newVersionStr string, branch string, notes string) error {
-	if f.DeriveState() != StateClosed {
+	if !f.IsClosed() {
 		return errors.New("can only reopen closed features")
 	}
 
@@ -87,11 +87,23 @@ func (s SortOrder) IsValid() bool {
 	}
 }
 
-// Validate validates the filter criteria.
+// Validate validates the filter criteria against the built-in states.
 func (f *Filter) Validate() error {
+	return f.ValidateWith(nil)
+}
+
+// ValidateWith validates the filter criteria, accepting any state of the given
+// workflow. A nil state machine accepts only the built-in states.
+func (f *Filter) ValidateWith(states *StateMachine) error {
 	// Validate state if specified
-	if f.State != "" && !f.State.IsValid() {
-		return ErrInvalidState
+	if f.State != "" {
+		if states == nil {
+			if !f.State.IsValid() {
+				return ErrInvalidState
+			}
+		} else if err := states.ValidateState(f.State); err != nil {
+			return err
+		}
 	}
 
 	// Validate priority if specified
@@ -115,8 +127,12 @@ func (f *Filter) Validate() error {
 // Matches checks if a feature matches the filter criteria.
 // Uses accessor methods to support both new (metadata) and deprecated (direct field) storage.
 func (f *Filter) Matches(feature *Feature) bool {
-	// State filter - use derived state
-	if f.State != "" && feature.DeriveState() != f.State {
+	// State filter - use derived state; "closed" also matches custom closed states
+	if f.State == StateClosed {
+		if !feature.IsClosed() {
+			return false
+		}
+	} else if f.State != "" && feature.DeriveState() != f.State {
 		return false
 	}
 
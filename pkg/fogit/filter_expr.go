@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -32,27 +33,86 @@ type CompareOp string
 
 const (
 	OpEquals       CompareOp = "="
+	OpNotEquals    CompareOp = "!="
 	OpGreater      CompareOp = ">"
 	OpLess         CompareOp = "<"
 	OpGreaterEqual CompareOp = ">="
 	OpLessEqual    CompareOp = "<="
+	OpMatches      CompareOp = "~"  // Regular expression match
+	OpIn           CompareOp = "IN" // Equals one of Values
 )
 
+// shorthandFields are metadata aliases usable without the metadata. prefix
+var shorthandFields = map[string]bool{
+	"priority": true,
+	"type":     true,
+	"category": true,
+	"domain":   true,
+	"team":     true,
+	"epic":     true,
+	"module":   true,
+}
+
+// coreFields are the feature fields usable in expressions
+var coreFields = map[string]bool{
+	"state":       true,
+	"name":        true,
+	"description": true,
+	"id":          true,
+	"tags":        true,
+	"created":     true,
+	"modified":    true,
+}
+
+// IsFilterField reports whether a field path names a feature field (e.g. "state",
+// "priority", "metadata.jira") rather than a relationship type
+func IsFilterField(field string) bool {
+	field = strings.ToLower(field)
+	if strings.HasPrefix(field, "metadata.") {
+		return len(field) > len("metadata.")
+	}
+	return shorthandFields[field] || coreFields[field]
+}
+
+// FieldValue returns the value of a field path as filter expressions see it.
+// Tags are joined with commas; unknown fields are empty.
+func FieldValue(f *Feature, field string) string {
+	return (&FieldExpr{Field: field}).getFieldValue(f)
+}
+
 // FieldExpr represents a field comparison expression.
 type FieldExpr struct {
 	Field    string    // Field name (e.g., "state", "priority", "metadata.category")
 	Operator CompareOp // Comparison operator
 	Value    string    // Value to compare against
+	Values   []string  // Values for OpIn
+
+	re *regexp.Regexp // Compiled Value for OpMatches
 }
 
 // Matches checks if the feature matches this field expression.
 func (e *FieldExpr) Matches(f *Feature) bool {
-	actual := e.getFieldValue(f)
+	return e.matchValue(e.getFieldValue(f), f)
+}
+
+// matchValue compares a value of the feature's field with the expression
+func (e *FieldExpr) matchValue(actual string, f *Feature) bool {
 	expected := e.Value
 
 	switch e.Operator {
 	case OpEquals:
 		return e.matchEquals(actual, expected)
+	case OpNotEquals:
+		return !e.matchEquals(actual, expected)
+	case OpIn:
+		for _, value := range e.Values {
+			if e.matchEquals(actual, value) {
+				return true
+			}
+		}
+		return false
+	case OpMatches:
+		return e.matchRegex(actual)
 	case OpGreater, OpLess, OpGreaterEqual, OpLessEqual:
 		return e.matchComparison(actual, expected, f)
 	default:
@@ -63,17 +123,6 @@ func (e *FieldExpr) Matches(f *Feature) bool {
 func (e *FieldExpr) getFieldValue(f *Feature) string {
 	field := strings.ToLower(e.Field)
 
-	// Metadata shorthand aliases
-	shorthandFields := map[string]bool{
-		"priority": true,
-		"type":     true,
-		"category": true,
-		"domain":   true,
-		"team":     true,
-		"epic":     true,
-		"module":   true,
-	}
-
 	// Convert shorthand to metadata.* form
 	if shorthandFields[field] {
 		field = "metadata." + field
@@ -138,10 +187,53 @@ func (e *FieldExpr) matchEquals(actual, expected string) bool {
 		return e.matchWildcard(actual, expected)
 	}
 
+	// Numbers are equal by value (3 = 3.0)
+	if a, b, ok := parseNumbers(actual, expected); ok {
+		return a == b
+	}
+
 	// Case-insensitive exact match
 	return strings.EqualFold(actual, expected)
 }
 
+// matchRegex matches the value against the regular expression; tags match if any tag does
+func (e *FieldExpr) matchRegex(actual string) bool {
+	re := e.re
+	if re == nil {
+		var err error
+		if re, err = compileFilterRegex(e.Value); err != nil {
+			return false
+		}
+	}
+	if strings.ToLower(e.Field) == "tags" {
+		for _, tag := range strings.Split(actual, ",") {
+			if re.MatchString(tag) {
+				return true
+			}
+		}
+		return false
+	}
+	return re.MatchString(actual)
+}
+
+// compileFilterRegex compiles a regular expression of a filter; matching is case-insensitive
+func compileFilterRegex(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// parseNumbers parses both values as numbers
+func parseNumbers(a, b string) (float64, float64, bool) {
+	x, err := strconv.ParseFloat(strings.TrimSpace(a), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	y, err := strconv.ParseFloat(strings.TrimSpace(b), 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
 func (e *FieldExpr) matchTags(actual, expected string) bool {
 	tags := strings.Split(actual, ",")
 	expectedLower := strings.ToLower(expected)
@@ -177,6 +269,16 @@ func (e *FieldExpr) matchComparison(actual, expected string, f *Feature) bool {
 		return e.matchPriorityComparison(actual, expected)
 	}
 
+	// A missing value is neither greater nor less than anything
+	if actual == "" {
+		return false
+	}
+
+	// Numeric comparison when both sides are numbers (e.g. metadata.points:>3)
+	if a, b, ok := parseNumbers(actual, expected); ok {
+		return compareOrdered(a, b, e.Operator)
+	}
+
 	// String comparison (lexicographic)
 	actualLower := strings.ToLower(actual)
 	expectedLower := strings.ToLower(expected)
@@ -255,7 +357,36 @@ func (e *FieldExpr) matchPriorityComparison(actual, expected string) bool {
 	}
 }
 
+// compareOrdered applies an ordering operator
+func compareOrdered(a, b float64, op CompareOp) bool {
+	switch op {
+	case OpGreater:
+		return a > b
+	case OpLess:
+		return a < b
+	case OpGreaterEqual:
+		return a >= b
+	case OpLessEqual:
+		return a <= b
+	default:
+		return false
+	}
+}
+
 func (e *FieldExpr) String() string {
+	switch e.Operator {
+	case OpNotEquals:
+		return fmt.Sprintf("%s!=%s", e.Field, quoteFilterValue(e.Value))
+	case OpMatches:
+		return fmt.Sprintf("%s~/%s/", e.Field, strings.ReplaceAll(e.Value, "/", "\\/"))
+	case OpIn:
+		values := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			values[i] = quoteFilterValue(v)
+		}
+		return fmt.Sprintf("%s IN (%s)", e.Field, strings.Join(values, ", "))
+	}
+
 	op := ":"
 	switch e.Operator {
 	case OpGreater:
@@ -267,7 +398,28 @@ func (e *FieldExpr) String() string {
 	case OpLessEqual:
 		op = ":<="
 	}
-	return fmt.Sprintf("%s%s%s", e.Field, op, e.Value)
+	return fmt.Sprintf("%s%s%s", e.Field, op, quoteFilterValue(e.Value))
+}
+
+// quoteFilterValue quotes a value that would not parse back as a single word
+func quoteFilterValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\n\r(),\":=!<>~") {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// HasExpr matches features that have a non-empty value for a field (e.g. has(metadata.jira)).
+type HasExpr struct {
+	Field string
+}
+
+func (e *HasExpr) Matches(f *Feature) bool {
+	return FieldValue(f, e.Field) != ""
+}
+
+func (e *HasExpr) String() string {
+	return fmt.Sprintf("has(%s)", e.Field)
 }
 
 // AndExpr represents a logical AND expression.
@@ -321,266 +473,3 @@ func (e *TrueExpr) Matches(f *Feature) bool {
 func (e *TrueExpr) String() string {
 	return "true"
 }
-
-// ParseFilterExpr parses a filter expression string into a FilterExpr.
-func ParseFilterExpr(expr string) (FilterExpr, error) {
-	expr = strings.TrimSpace(expr)
-	if expr == "" {
-		return &TrueExpr{}, nil
-	}
-
-	parser := &exprParser{input: expr, pos: 0}
-	result, err := parser.parseOr()
-	if err != nil {
-		return nil, err
-	}
-
-	// Ensure all input was consumed
-	parser.skipWhitespace()
-	if parser.pos < len(parser.input) {
-		return nil, fmt.Errorf("%w: unexpected token at position %d", ErrInvalidExpression, parser.pos)
-	}
-
-	return result, nil
-}
-
-// exprParser is a recursive descent parser for filter expressions.
-type exprParser struct {
-	input string
-	pos   int
-}
-
-func (p *exprParser) parseOr() (FilterExpr, error) {
-	left, err := p.parseAnd()
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		p.skipWhitespace()
-		if p.matchKeyword("OR") {
-			right, err := p.parseAnd()
-			if err != nil {
-				return nil, err
-			}
-			left = &OrExpr{Left: left, Right: right}
-		} else {
-			break
-		}
-	}
-
-	return left, nil
-}
-
-func (p *exprParser) parseAnd() (FilterExpr, error) {
-	left, err := p.parseNot()
-	if err != nil {
-		return nil, err
-	}
-
-	for {
-		p.skipWhitespace()
-		if p.matchKeyword("AND") {
-			right, err := p.parseNot()
-			if err != nil {
-				return nil, err
-			}
-			left = &AndExpr{Left: left, Right: right}
-		} else {
-			break
-		}
-	}
-
-	return left, nil
-}
-
-func (p *exprParser) parseNot() (FilterExpr, error) {
-	p.skipWhitespace()
-	if p.matchKeyword("NOT") {
-		expr, err := p.parseNot()
-		if err != nil {
-			return nil, err
-		}
-		return &NotExpr{Expr: expr}, nil
-	}
-	return p.parsePrimary()
-}
-
-func (p *exprParser) parsePrimary() (FilterExpr, error) {
-	p.skipWhitespace()
-
-	// Handle parentheses
-	if p.pos < len(p.input) && p.input[p.pos] == '(' {
-		p.pos++ // consume '('
-		expr, err := p.parseOr()
-		if err != nil {
-			return nil, err
-		}
-		p.skipWhitespace()
-		if p.pos >= len(p.input) || p.input[p.pos] != ')' {
-			return nil, ErrUnmatchedParen
-		}
-		p.pos++ // consume ')'
-		return expr, nil
-	}
-
-	// Parse field expression
-	return p.parseFieldExpr()
-}
-
-func (p *exprParser) parseFieldExpr() (FilterExpr, error) {
-	p.skipWhitespace()
-
-	// Parse field name
-	field := p.parseField()
-	if field == "" {
-		return nil, fmt.Errorf("%w: expected field name at position %d", ErrInvalidExpression, p.pos)
-	}
-
-	// Parse operator
-	op, err := p.parseOperator()
-	if err != nil {
-		return nil, err
-	}
-
-	// Parse value
-	value, err := p.parseValue()
-	if err != nil {
-		return nil, err
-	}
-
-	return &FieldExpr{
-		Field:    field,
-		Operator: op,
-		Value:    value,
-	}, nil
-}
-
-func (p *exprParser) parseField() string {
-	start := p.pos
-	for p.pos < len(p.input) {
-		ch := p.input[p.pos]
-		if ch == ':' || ch == ' ' || ch == '\t' || ch == '(' || ch == ')' {
-			break
-		}
-		p.pos++
-	}
-	return p.input[start:p.pos]
-}
-
-func (p *exprParser) parseOperator() (CompareOp, error) {
-	if p.pos >= len(p.input) || p.input[p.pos] != ':' {
-		return "", fmt.Errorf("%w: expected ':' at position %d", ErrInvalidExpression, p.pos)
-	}
-	p.pos++ // consume ':'
-
-	// Check for comparison operators
-	if p.pos < len(p.input) {
-		switch {
-		case strings.HasPrefix(p.input[p.pos:], ">="):
-			p.pos += 2
-			return OpGreaterEqual, nil
-		case strings.HasPrefix(p.input[p.pos:], "<="):
-			p.pos += 2
-			return OpLessEqual, nil
-		case p.input[p.pos] == '>':
-			p.pos++
-			return OpGreater, nil
-		case p.input[p.pos] == '<':
-			p.pos++
-			return OpLess, nil
-		}
-	}
-
-	return OpEquals, nil
-}
-
-func (p *exprParser) parseValue() (string, error) {
-	p.skipWhitespace()
-
-	if p.pos >= len(p.input) {
-		return "", fmt.Errorf("%w: expected value at position %d", ErrInvalidExpression, p.pos)
-	}
-
-	// Handle quoted values
-	if p.input[p.pos] == '"' {
-		return p.parseQuotedValue()
-	}
-
-	// Parse unquoted value
-	start := p.pos
-	for p.pos < len(p.input) {
-		ch := p.input[p.pos]
-		// Stop at whitespace or logical operators
-		if ch == ' ' || ch == '\t' || ch == '(' || ch == ')' {
-			break
-		}
-		// Check for logical operators
-		remaining := p.input[p.pos:]
-		if strings.HasPrefix(strings.ToUpper(remaining), " AND ") ||
-			strings.HasPrefix(strings.ToUpper(remaining), " OR ") {
-			break
-		}
-		p.pos++
-	}
-
-	if p.pos == start {
-		return "", fmt.Errorf("%w: expected value at position %d", ErrInvalidExpression, p.pos)
-	}
-
-	return p.input[start:p.pos], nil
-}
-
-func (p *exprParser) parseQuotedValue() (string, error) {
-	p.pos++ // consume opening quote
-	var result strings.Builder
-	escaped := false
-
-	for p.pos < len(p.input) {
-		ch := p.input[p.pos]
-		if escaped {
-			result.WriteByte(ch)
-			escaped = false
-		} else if ch == '\\' {
-			escaped = true
-		} else if ch == '"' {
-			p.pos++ // consume closing quote
-			return result.String(), nil
-		} else {
-			result.WriteByte(ch)
-		}
-		p.pos++
-	}
-
-	return "", fmt.Errorf("%w: unterminated quoted value", ErrInvalidExpression)
-}
-
-func (p *exprParser) skipWhitespace() {
-	for p.pos < len(p.input) && (p.input[p.pos] == ' ' || p.input[p.pos] == '\t') {
-		p.pos++
-	}
-}
-
-func (p *exprParser) matchKeyword(keyword string) bool {
-	if p.pos+len(keyword) > len(p.input) {
-		return false
-	}
-
-	// Check if keyword matches (case-insensitive)
-	substr := p.input[p.pos : p.pos+len(keyword)]
-	if !strings.EqualFold(substr, keyword) {
-		return false
-	}
-
-	// Ensure it's followed by whitespace or end of input (not part of field name)
-	endPos := p.pos + len(keyword)
-	if endPos < len(p.input) {
-		next := p.input[endPos]
-		if next != ' ' && next != '\t' && next != '(' {
-			return false
-		}
-	}
-
-	p.pos += len(keyword)
-	return true
-}
@@ -1,6 +1,7 @@
 package fogit
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -755,6 +756,18 @@ func TestFieldExpr_String(t *testing.T) {
 			expr: &FieldExpr{Field: "priority", Operator: OpGreaterEqual, Value: "medium"},
 			want: "priority:>=medium",
 		},
+		{
+			expr: &FieldExpr{Field: "name", Operator: OpNotEquals, Value: "Old API"},
+			want: `name!="Old API"`,
+		},
+		{
+			expr: &FieldExpr{Field: "name", Operator: OpMatches, Value: "^api/v[0-9]"},
+			want: `name~/^api\/v[0-9]/`,
+		},
+		{
+			expr: &FieldExpr{Field: "state", Operator: OpIn, Values: []string{"open", "in-progress"}},
+			want: "state IN (open, in-progress)",
+		},
 	}
 
 	for _, tt := range tests {
@@ -765,3 +778,133 @@ func TestFieldExpr_String(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFilterExpr_Operators(t *testing.T) {
+	feature := NewFeature("API Gateway")
+	feature.Description = "Routes requests/responses"
+	feature.Tags = []string{"backend", "edge"}
+	feature.SetPriority(PriorityHigh)
+	feature.SetMetadata("points", 8)
+	feature.SetMetadata("jira", "GW-12")
+	feature.SetMetadata("ratio", "0.5")
+
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"state!=closed", true},
+		{"state != open", false},
+		{"priority:!=low", true},
+		{"NOT state:closed", true},
+		{"!state:open", false},
+		{"not (state:open OR priority:low)", false},
+		{"state IN (open, in-progress)", true},
+		{"priority in (low, medium)", false},
+		{"priority NOT IN (low, medium)", true},
+		{`name IN ("API Gateway", "Other")`, true},
+		{"tags IN (edge, frontend)", true},
+		{"name~/^api/", true},
+		{"name ~ /gate(way)?$/", true},
+		{`description~/requests\/responses/`, true},
+		{"name:~gate", true},
+		{"tags~/^fro/", false},
+		{"has(metadata.jira)", true},
+		{"has(metadata.owner)", false},
+		{"NOT has(description)", false},
+		{"metadata.points>5", true},
+		{"metadata.points:>=10", false},
+		{"metadata.points=8.0", true},
+		{"metadata.ratio<1", true},
+		{"metadata.missing<1", false},
+		{"priority>medium AND metadata.jira~/^GW-/", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseFilterExpr(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseFilterExpr() error = %v", err)
+			}
+			if got := expr.Matches(feature); got != tt.want {
+				t.Errorf("Matches() = %v, want %v for expression %q", got, tt.want, tt.expression)
+			}
+		})
+	}
+}
+
+func TestParseFilterExpr_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		expression string
+		wantErr    error
+		wantPos    int
+	}{
+		{"(priority:high", ErrUnmatchedParen, 0},
+		{"priority:high)", ErrUnmatchedParen, 13},
+		{"state:open AND", ErrMissingOperand, 14},
+		{"OR state:open", ErrMissingOperand, 0},
+		{"state:open AND NOT", ErrMissingOperand, 18},
+		{"priority high", ErrInvalidExpression, 9},
+		{"state IN open", ErrInvalidExpression, 9},
+		{"state IN (open, ", ErrInvalidExpression, 16},
+		{`name~/unterminated`, ErrInvalidExpression, 5},
+		{"name~/(/", ErrInvalidExpression, 5},
+		{"created>yesterday", ErrInvalidDateFormat, 0},
+		{"incoming(depends-on) > many", ErrInvalidExpression, 23},
+		{"nothing(x)", ErrInvalidExpression, 0},
+		{"depends-on>Auth", ErrInvalidOperator, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			_, err := ParseFilterExpr(tt.expression)
+			var syntaxErr *FilterSyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseFilterExpr() error = %v, want a FilterSyntaxError", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseFilterExpr() error = %v, want %v", err, tt.wantErr)
+			}
+			if syntaxErr.Pos != tt.wantPos {
+				t.Errorf("error position = %d, want %d (%v)", syntaxErr.Pos, tt.wantPos, err)
+			}
+		})
+	}
+}
+
+func TestFilterSyntaxError_Pointer(t *testing.T) {
+	_, err := ParseFilterExpr("state:open AND (priority:high")
+	var syntaxErr *FilterSyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Fatalf("ParseFilterExpr() error = %v, want a FilterSyntaxError", err)
+	}
+	want := "state:open AND (priority:high\n               ^"
+	if got := syntaxErr.Pointer(); got != want {
+		t.Errorf("Pointer() = %q, want %q", got, want)
+	}
+	if got := err.Error(); got != "unmatched parenthesis: unmatched '(' at column 16" {
+		t.Errorf("Error() = %q", got)
+	}
+}
+
+func TestParseFilterExprWith_RelationTypes(t *testing.T) {
+	opts := FilterParseOptions{RelationType: func(name string) (string, bool) {
+		switch name {
+		case "depends-on", "requires":
+			return "depends-on", true
+		}
+		return "", false
+	}}
+
+	expr, err := ParseFilterExprWith(`requires:"Auth"`, opts)
+	if err != nil {
+		t.Fatalf("ParseFilterExprWith() error = %v", err)
+	}
+	if got := expr.String(); got != "depends-on:Auth" {
+		t.Errorf("alias resolved to %q, want depends-on:Auth", got)
+	}
+
+	_, err = ParseFilterExprWith("owner:alice", opts)
+	if !errors.Is(err, ErrInvalidField) {
+		t.Errorf("unknown field error = %v, want %v", err, ErrInvalidField)
+	}
+}
@@ -0,0 +1,194 @@
+package fogit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// filterGraph resolves relationship targets and incoming relationships for
+// graph predicates. It is built from every known feature by BindFilterGraph.
+type filterGraph struct {
+	byID     map[string]*Feature
+	incoming map[string][]IncomingEdge
+}
+
+// newFilterGraph builds a graph over the given features
+func newFilterGraph(features []*Feature) *filterGraph {
+	g := &filterGraph{
+		byID:     make(map[string]*Feature, len(features)),
+		incoming: make(map[string][]IncomingEdge),
+	}
+	for _, f := range features {
+		g.byID[f.ID] = f
+	}
+	for _, f := range features {
+		for _, rel := range f.Relationships {
+			g.incoming[rel.TargetID] = append(g.incoming[rel.TargetID], IncomingEdge{
+				SourceID:       f.ID,
+				RelationshipID: rel.ID,
+				Type:           string(rel.Type),
+			})
+		}
+	}
+	return g
+}
+
+// target returns the feature a relationship points at. Targets outside the
+// graph are represented by a feature with only the ID and name set.
+func (g *filterGraph) target(rel Relationship) *Feature {
+	if g != nil {
+		if f, ok := g.byID[rel.TargetID]; ok {
+			return f
+		}
+	}
+	return &Feature{ID: rel.TargetID, Name: rel.TargetName}
+}
+
+// RelationExpr matches features with a relationship of a type whose target
+// matches. The target is either compared by name or ID (depends-on:"Auth"),
+// or tested with a predicate on the target feature (blocked-by.state=open).
+// Without Match and Target, any relationship of the type matches
+// (has(depends-on)).
+type RelationExpr struct {
+	Type   string
+	Match  *FieldExpr // Compared against the target's name and ID; Field is the type
+	Target FilterExpr // Predicate on the target feature
+
+	graph *filterGraph
+}
+
+func (e *RelationExpr) Matches(f *Feature) bool {
+	for _, rel := range f.Relationships {
+		if !strings.EqualFold(string(rel.Type), e.Type) {
+			continue
+		}
+		if e.relationMatches(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// relationMatches tests a single relationship of the expression's type
+func (e *RelationExpr) relationMatches(rel Relationship) bool {
+	target := e.graph.target(rel)
+	switch {
+	case e.Match != nil:
+		return e.Match.matchValue(target.Name, target) ||
+			(rel.TargetName != target.Name && e.Match.matchValue(rel.TargetName, target)) ||
+			e.Match.matchValue(rel.TargetID, target)
+	case e.Target != nil:
+		return e.Target.Matches(target)
+	default:
+		return true
+	}
+}
+
+func (e *RelationExpr) String() string {
+	switch {
+	case e.Match != nil:
+		return e.Match.String()
+	case e.Target != nil:
+		target := e.Target.String()
+		if strings.HasPrefix(target, "has(") {
+			return fmt.Sprintf("has(%s.%s", e.Type, strings.TrimPrefix(target, "has("))
+		}
+		return fmt.Sprintf("%s.%s", e.Type, target)
+	default:
+		return fmt.Sprintf("has(%s)", e.Type)
+	}
+}
+
+// CountExpr compares the number of incoming or outgoing relationships of a
+// feature, optionally of one type (e.g. incoming(required-by) > 3).
+type CountExpr struct {
+	Incoming bool
+	Type     string // Empty counts every type
+	Operator CompareOp
+	Value    int
+
+	graph *filterGraph
+}
+
+func (e *CountExpr) Matches(f *Feature) bool {
+	count := 0
+	if e.Incoming {
+		if e.graph != nil {
+			for _, edge := range e.graph.incoming[f.ID] {
+				if e.Type == "" || strings.EqualFold(edge.Type, e.Type) {
+					count++
+				}
+			}
+		}
+	} else {
+		for _, rel := range f.Relationships {
+			if e.Type == "" || strings.EqualFold(string(rel.Type), e.Type) {
+				count++
+			}
+		}
+	}
+
+	switch e.Operator {
+	case OpEquals:
+		return count == e.Value
+	case OpNotEquals:
+		return count != e.Value
+	default:
+		return compareOrdered(float64(count), float64(e.Value), e.Operator)
+	}
+}
+
+func (e *CountExpr) String() string {
+	fn := "outgoing"
+	if e.Incoming {
+		fn = "incoming"
+	}
+	return fmt.Sprintf("%s(%s) %s %d", fn, e.Type, e.Operator, e.Value)
+}
+
+// UsesFilterGraph reports whether an expression needs the other features to
+// be evaluated, i.e. looks at relationship targets or incoming relationships.
+// Such expressions must be bound with BindFilterGraph.
+func UsesFilterGraph(expr FilterExpr) bool {
+	switch e := expr.(type) {
+	case *AndExpr:
+		return UsesFilterGraph(e.Left) || UsesFilterGraph(e.Right)
+	case *OrExpr:
+		return UsesFilterGraph(e.Left) || UsesFilterGraph(e.Right)
+	case *NotExpr:
+		return UsesFilterGraph(e.Expr)
+	case *RelationExpr:
+		return e.Match != nil || e.Target != nil
+	case *CountExpr:
+		return e.Incoming
+	default:
+		return false
+	}
+}
+
+// BindFilterGraph makes the given features available to the graph predicates
+// of an expression. The features should be every known feature, not only the
+// ones being filtered, so relationship targets can be resolved.
+func BindFilterGraph(expr FilterExpr, features []*Feature) {
+	bindFilterGraph(expr, newFilterGraph(features))
+}
+
+func bindFilterGraph(expr FilterExpr, g *filterGraph) {
+	switch e := expr.(type) {
+	case *AndExpr:
+		bindFilterGraph(e.Left, g)
+		bindFilterGraph(e.Right, g)
+	case *OrExpr:
+		bindFilterGraph(e.Left, g)
+		bindFilterGraph(e.Right, g)
+	case *NotExpr:
+		bindFilterGraph(e.Expr, g)
+	case *RelationExpr:
+		e.graph = g
+		if e.Target != nil {
+			bindFilterGraph(e.Target, g)
+		}
+	case *CountExpr:
+		e.graph = g
+	}
+}
@@ -0,0 +1,141 @@
+package fogit
+
+import (
+	"testing"
+)
+
+func TestFilterGraphPredicates(t *testing.T) {
+	auth := NewFeature("Auth")
+	auth.SetMetadata("jira", "SEC-1")
+
+	db := NewFeature("Database")
+	if err := db.UpdateState(StateInProgress); err != nil {
+		t.Fatal(err)
+	}
+
+	api := NewFeature("API")
+	api.Relationships = []Relationship{
+		NewRelationship("depends-on", auth.ID, auth.Name),
+		NewRelationship("blocked-by", db.ID, db.Name),
+	}
+
+	ui := NewFeature("UI")
+	ui.Relationships = []Relationship{
+		NewRelationship("depends-on", auth.ID, auth.Name),
+		NewRelationship("depends-on", api.ID, api.Name),
+	}
+
+	all := []*Feature{auth, db, api, ui}
+
+	tests := []struct {
+		expression string
+		want       []string
+	}{
+		{`depends-on:"Auth"`, []string{"API", "UI"}},
+		{`depends-on:auth AND depends-on:API`, []string{"UI"}},
+		{`depends-on!=API`, []string{"Auth", "Database", "API"}},
+		{`depends-on~/^a/`, []string{"API", "UI"}},
+		{`depends-on IN (API, Other)`, []string{"UI"}},
+		{`depends-on:` + auth.ID, []string{"API", "UI"}},
+		{`blocked-by.state=open`, []string{}},
+		{`blocked-by.state:in-progress`, []string{"API"}},
+		{`depends-on.blocked-by.state=in-progress`, []string{"UI"}},
+		{`has(depends-on.metadata.jira)`, []string{"API", "UI"}},
+		{`has(blocked-by)`, []string{"API"}},
+		{`incoming(depends-on) > 1`, []string{"Auth"}},
+		{`incoming() = 0`, []string{"UI"}},
+		{`outgoing(depends-on) >= 2`, []string{"UI"}},
+		{`NOT has(depends-on) AND incoming(blocked-by) = 1`, []string{"Database"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseFilterExpr(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseFilterExpr() error = %v", err)
+			}
+			BindFilterGraph(expr, all)
+
+			got := []string{}
+			for _, f := range all {
+				if expr.Matches(f) {
+					got = append(got, f.Name)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("matched %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterGraph_Unbound(t *testing.T) {
+	auth := NewFeature("Auth")
+	api := NewFeature("API")
+	api.Relationships = []Relationship{NewRelationship("depends-on", auth.ID, "Auth")}
+
+	// Targets fall back to the name stored on the relationship
+	expr, _ := ParseFilterExpr(`depends-on:Auth`)
+	if !expr.Matches(api) {
+		t.Error("depends-on:Auth should match without a graph")
+	}
+
+	// Incoming relationships are unknown without a graph
+	expr, _ = ParseFilterExpr(`incoming(depends-on) > 0`)
+	if expr.Matches(auth) {
+		t.Error("incoming() should count nothing without a graph")
+	}
+}
+
+func TestUsesFilterGraph(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       bool
+	}{
+		{"state:open", false},
+		{"has(depends-on)", false},
+		{"outgoing(depends-on) > 1", false},
+		{"state:open AND NOT depends-on:Auth", true},
+		{"blocked-by.state=open", true},
+		{"incoming(required-by) > 3", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			expr, err := ParseFilterExpr(tt.expression)
+			if err != nil {
+				t.Fatalf("ParseFilterExpr() error = %v", err)
+			}
+			if got := UsesFilterGraph(expr); got != tt.want {
+				t.Errorf("UsesFilterGraph() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelationExpr_String(t *testing.T) {
+	tests := []string{
+		"depends-on:Auth",
+		"blocked-by.state:open",
+		"has(depends-on.metadata.jira)",
+		"has(blocked-by)",
+		"incoming(required-by) > 3",
+	}
+
+	for _, expression := range tests {
+		t.Run(expression, func(t *testing.T) {
+			expr, err := ParseFilterExpr(expression)
+			if err != nil {
+				t.Fatalf("ParseFilterExpr() error = %v", err)
+			}
+			if got := expr.String(); got != expression {
+				t.Errorf("String() = %q, want %q", got, expression)
+			}
+		})
+	}
+}
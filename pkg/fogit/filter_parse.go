@@ -0,0 +1,606 @@
+package fogit
+
+import (
+	"errors"
+	"fmt"
+	"regexp/syntax"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// FilterSyntaxError is returned for an expression that cannot be parsed.
+// It wraps one of the expression errors (ErrInvalidExpression, ErrUnmatchedParen, ...).
+type FilterSyntaxError struct {
+	Expr string // The expression being parsed
+	Pos  int    // Byte offset of the error in Expr
+	Msg  string
+	Err  error
+}
+
+func (e *FilterSyntaxError) Error() string {
+	return fmt.Sprintf("%v: %s at column %d", e.Err, e.Msg, e.Pos+1)
+}
+
+func (e *FilterSyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Pointer returns the expression with a caret under the error position
+func (e *FilterSyntaxError) Pointer() string {
+	pos := e.Pos
+	if pos > len(e.Expr) {
+		pos = len(e.Expr)
+	}
+	return e.Expr + "\n" + strings.Repeat(" ", utf8.RuneCountInString(e.Expr[:pos])) + "^"
+}
+
+// FilterParseOptions customizes how an expression is parsed
+type FilterParseOptions struct {
+	// RelationType resolves a relationship type or alias to its canonical name.
+	// Names that are neither fields nor relationship types are rejected. When
+	// nil, every name that isn't a field is taken as a relationship type.
+	RelationType func(name string) (string, bool)
+}
+
+// ParseFilterExpr parses a filter expression string into a FilterExpr.
+//
+// Predicates compare a field with a value (state:open, priority>=high,
+// name!="Old", metadata.points>3), match a regular expression
+// (name~/^api/), test a list of values (state IN (open, in-progress)) or
+// existence (has(metadata.jira)). A path starting with a relationship type
+// tests the relationship targets (depends-on:"Auth", blocked-by.state=open),
+// and incoming(type) and outgoing(type) count relationships. Predicates are
+// combined with AND, OR, NOT (or !) and parentheses.
+func ParseFilterExpr(expr string) (FilterExpr, error) {
+	return ParseFilterExprWith(expr, FilterParseOptions{})
+}
+
+// ParseFilterExprWith parses a filter expression with the given options
+func ParseFilterExprWith(expr string, opts FilterParseOptions) (FilterExpr, error) {
+	if strings.TrimSpace(expr) == "" {
+		return &TrueExpr{}, nil
+	}
+
+	p := &filterParser{input: expr, opts: opts}
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure all input was consumed
+	if tok := p.peek(); tok.kind != tokEOF {
+		if tok.kind == tokRParen {
+			return nil, p.errorAt(tok.pos, ErrUnmatchedParen, "unexpected ')'")
+		}
+		return nil, p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("unexpected %s", tok))
+	}
+
+	return result, nil
+}
+
+// filterTokenKind is the kind of a lexed token
+type filterTokenKind int
+
+const (
+	tokEOF filterTokenKind = iota
+	tokWord
+	tokString
+	tokRegex
+	tokLParen
+	tokRParen
+	tokComma
+	tokOp
+	tokBang
+	tokError
+)
+
+// filterToken is a lexed token; pos and end are byte offsets in the input
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+	end  int
+}
+
+func (t filterToken) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// filterParser is a recursive descent parser for filter expressions. Tokens
+// are lexed on demand, since values are lexed differently from the rest
+// (an unquoted value runs up to the next space or parenthesis).
+type filterParser struct {
+	input string
+	pos   int
+	opts  FilterParseOptions
+}
+
+func (p *filterParser) errorAt(pos int, err error, msg string) error {
+	return &FilterSyntaxError{Expr: p.input, Pos: pos, Msg: msg, Err: err}
+}
+
+func (p *filterParser) skipWhitespace() {
+	for p.pos < len(p.input) && strings.IndexByte(" \t\r\n", p.input[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// isWordByte reports whether a byte can be part of a word outside of values
+func isWordByte(ch byte) bool {
+	return strings.IndexByte(" \t\r\n()\",:=!<>~", ch) < 0
+}
+
+// peek returns the next token without consuming it
+func (p *filterParser) peek() filterToken {
+	p.skipWhitespace()
+	start := p.pos
+	if start >= len(p.input) {
+		return filterToken{kind: tokEOF, pos: start, end: start}
+	}
+
+	rest := p.input[start:]
+	switch ch := rest[0]; {
+	case ch == '(':
+		return filterToken{kind: tokLParen, text: "(", pos: start, end: start + 1}
+	case ch == ')':
+		return filterToken{kind: tokRParen, text: ")", pos: start, end: start + 1}
+	case ch == ',':
+		return filterToken{kind: tokComma, text: ",", pos: start, end: start + 1}
+	case ch == '"':
+		return p.lexQuoted(start)
+	case strings.HasPrefix(rest, ">=") || strings.HasPrefix(rest, "<=") || strings.HasPrefix(rest, "!="):
+		return filterToken{kind: tokOp, text: rest[:2], pos: start, end: start + 2}
+	case ch == '!':
+		return filterToken{kind: tokBang, text: "!", pos: start, end: start + 1}
+	case strings.IndexByte(":=<>~", ch) >= 0:
+		return filterToken{kind: tokOp, text: rest[:1], pos: start, end: start + 1}
+	}
+
+	end := start
+	for end < len(p.input) && isWordByte(p.input[end]) {
+		end++
+	}
+	return filterToken{kind: tokWord, text: p.input[start:end], pos: start, end: end}
+}
+
+// next consumes and returns the next token
+func (p *filterParser) next() filterToken {
+	tok := p.peek()
+	p.pos = tok.end
+	return tok
+}
+
+// peekKeyword reports whether the next token is the given keyword (case-insensitive)
+func (p *filterParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokWord && strings.EqualFold(tok.text, keyword)
+}
+
+// lexQuoted lexes a double-quoted string with backslash escapes
+func (p *filterParser) lexQuoted(start int) filterToken {
+	var b strings.Builder
+	for i := start + 1; i < len(p.input); i++ {
+		switch ch := p.input[i]; ch {
+		case '\\':
+			if i+1 < len(p.input) {
+				i++
+				b.WriteByte(p.input[i])
+			}
+		case '"':
+			return filterToken{kind: tokString, text: b.String(), pos: start, end: i + 1}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return filterToken{kind: tokError, text: "unterminated quoted value", pos: start, end: len(p.input)}
+}
+
+// lexRegex lexes a /regex/; \/ stands for a slash, other escapes are kept for the regex
+func (p *filterParser) lexRegex(start int) filterToken {
+	var b strings.Builder
+	for i := start + 1; i < len(p.input); i++ {
+		switch ch := p.input[i]; {
+		case ch == '\\' && i+1 < len(p.input):
+			i++
+			if p.input[i] != '/' {
+				b.WriteByte('\\')
+			}
+			b.WriteByte(p.input[i])
+		case ch == '/':
+			return filterToken{kind: tokRegex, text: b.String(), pos: start, end: i + 1}
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return filterToken{kind: tokError, text: "unterminated regular expression", pos: start, end: len(p.input)}
+}
+
+// nextValue consumes a value: a quoted string, a /regex/ (if allowed) or an
+// unquoted word running up to the next space or parenthesis (or comma, in a list)
+func (p *filterParser) nextValue(allowRegex, inList bool) (filterToken, error) {
+	p.skipWhitespace()
+	start := p.pos
+	if start >= len(p.input) {
+		return filterToken{}, p.errorAt(start, ErrInvalidExpression, "expected value")
+	}
+
+	var tok filterToken
+	switch ch := p.input[start]; {
+	case ch == '"':
+		tok = p.lexQuoted(start)
+	case ch == '/' && allowRegex:
+		tok = p.lexRegex(start)
+	default:
+		end := start
+		for end < len(p.input) && strings.IndexByte(" \t\r\n()", p.input[end]) < 0 && !(inList && p.input[end] == ',') {
+			end++
+		}
+		if end == start {
+			return filterToken{}, p.errorAt(start, ErrInvalidExpression, "expected value")
+		}
+		tok = filterToken{kind: tokWord, text: p.input[start:end], pos: start, end: end}
+	}
+
+	if tok.kind == tokError {
+		return filterToken{}, p.errorAt(tok.pos, ErrInvalidExpression, tok.text)
+	}
+	p.pos = tok.end
+	return tok, nil
+}
+
+func (p *filterParser) parseOr() (FilterExpr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("OR") {
+		tok := p.next()
+		if err := p.expectOperand(tok); err != nil {
+			return nil, err
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &OrExpr{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (FilterExpr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peekKeyword("AND") {
+		tok := p.next()
+		if err := p.expectOperand(tok); err != nil {
+			return nil, err
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &AndExpr{Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+// expectOperand checks that a logical operator is followed by something
+func (p *filterParser) expectOperand(op filterToken) error {
+	if next := p.peek(); next.kind == tokEOF || next.kind == tokRParen {
+		return p.errorAt(next.pos, ErrMissingOperand, fmt.Sprintf("missing operand after %s", strings.ToUpper(op.text)))
+	}
+	return nil
+}
+
+func (p *filterParser) parseNot() (FilterExpr, error) {
+	if tok := p.peek(); tok.kind == tokBang || p.peekKeyword("NOT") {
+		p.next()
+		if err := p.expectOperand(tok); err != nil {
+			return nil, err
+		}
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotExpr{Expr: expr}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (FilterExpr, error) {
+	tok := p.peek()
+	switch tok.kind {
+	case tokLParen:
+		p.next()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, p.errorAt(tok.pos, ErrUnmatchedParen, "unmatched '('")
+		}
+		p.next()
+		return expr, nil
+	case tokWord:
+		if strings.EqualFold(tok.text, "AND") || strings.EqualFold(tok.text, "OR") {
+			return nil, p.errorAt(tok.pos, ErrMissingOperand, fmt.Sprintf("missing operand before %s", strings.ToUpper(tok.text)))
+		}
+	case tokError:
+		return nil, p.errorAt(tok.pos, ErrInvalidExpression, tok.text)
+	default:
+		return nil, p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("expected field name, got %s", tok))
+	}
+
+	p.next()
+	if p.peek().kind == tokLParen {
+		switch strings.ToLower(tok.text) {
+		case "has":
+			return p.parseHas()
+		case "incoming", "outgoing":
+			return p.parseCount(strings.EqualFold(tok.text, "incoming"))
+		}
+		return nil, p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("unknown function %q", tok.text))
+	}
+	return p.parsePredicate(tok)
+}
+
+// parseHas parses the argument of has(path)
+func (p *filterParser) parseHas() (FilterExpr, error) {
+	open := p.next()
+	arg := p.next()
+	if arg.kind != tokWord {
+		return nil, p.errorAt(arg.pos, ErrInvalidExpression, fmt.Sprintf("expected field name, got %s", arg))
+	}
+	if p.peek().kind != tokRParen {
+		return nil, p.errorAt(open.pos, ErrUnmatchedParen, "unmatched '('")
+	}
+	p.next()
+
+	return p.pathExpr(arg.text, arg.pos,
+		func(field string) (FilterExpr, error) { return &HasExpr{Field: field}, nil },
+		func(relType string) (FilterExpr, error) { return &RelationExpr{Type: relType}, nil })
+}
+
+// parseCount parses incoming([type]) op n and outgoing([type]) op n
+func (p *filterParser) parseCount(incoming bool) (FilterExpr, error) {
+	open := p.next()
+	expr := &CountExpr{Incoming: incoming}
+	if arg := p.peek(); arg.kind == tokWord {
+		p.next()
+		relType, err := p.relationType(arg.text, arg.pos)
+		if err != nil {
+			return nil, err
+		}
+		expr.Type = relType
+	}
+	if p.peek().kind != tokRParen {
+		return nil, p.errorAt(open.pos, ErrUnmatchedParen, "unmatched '('")
+	}
+	p.next()
+
+	op, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	if op == OpMatches {
+		return nil, p.errorAt(p.pos-1, ErrInvalidOperator, "relationship counts can't be matched with ~")
+	}
+	value, err := p.nextValue(false, false)
+	if err != nil {
+		return nil, err
+	}
+	n, err := strconv.Atoi(value.text)
+	if err != nil || n < 0 {
+		return nil, p.errorAt(value.pos, ErrInvalidExpression, fmt.Sprintf("expected a count, got %s", value))
+	}
+	expr.Operator = op
+	expr.Value = n
+	return expr, nil
+}
+
+// parseOperator parses a comparison operator. The legacy forms field:value
+// and field:>value are accepted alongside field=value and field>value.
+func (p *filterParser) parseOperator() (CompareOp, error) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("expected operator, got %s", tok))
+	}
+	p.next()
+
+	if tok.text == ":" {
+		// Only an operator directly after the colon counts, so field:=x keeps "=x" as its value
+		after := p.pos
+		if next := p.peek(); next.kind == tokOp && next.pos == after && next.text != ":" && next.text != "=" {
+			p.next()
+			return CompareOp(next.text), nil
+		}
+		p.pos = after
+		return OpEquals, nil
+	}
+	return CompareOp(tok.text), nil
+}
+
+// parsePredicate parses the rest of a predicate after its path
+func (p *filterParser) parsePredicate(path filterToken) (FilterExpr, error) {
+	// path [NOT] IN (a, b)
+	negate := false
+	if p.peekKeyword("NOT") {
+		saved := p.pos
+		p.next()
+		if !p.peekKeyword("IN") {
+			p.pos = saved
+			return nil, p.errorAt(p.peek().pos, ErrInvalidExpression, fmt.Sprintf("expected operator after %q", path.text))
+		}
+		negate = true
+	}
+	if p.peekKeyword("IN") {
+		p.next()
+		values, err := p.parseValueList()
+		if err != nil {
+			return nil, err
+		}
+		expr, err := p.comparison(path, &FieldExpr{Operator: OpIn, Values: values})
+		if err != nil || !negate {
+			return expr, err
+		}
+		return &NotExpr{Expr: expr}, nil
+	}
+
+	if tok := p.peek(); tok.kind != tokOp {
+		return nil, p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("expected operator after %q, got %s", path.text, tok))
+	}
+	op, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+	value, err := p.nextValue(op == OpMatches, false)
+	if err != nil {
+		return nil, err
+	}
+
+	cmp := &FieldExpr{Operator: op, Value: value.text}
+	if op == OpMatches {
+		re, err := compileFilterRegex(value.text)
+		if err != nil {
+			return nil, p.errorAt(value.pos, ErrInvalidExpression, fmt.Sprintf("invalid regular expression: %s", regexErrorMessage(err)))
+		}
+		cmp.re = re
+	}
+	return p.comparison(path, cmp)
+}
+
+// parseValueList parses (a, b, ...)
+func (p *filterParser) parseValueList() ([]string, error) {
+	open := p.peek()
+	if open.kind != tokLParen {
+		return nil, p.errorAt(open.pos, ErrInvalidExpression, "expected '(' after IN")
+	}
+	p.next()
+
+	var values []string
+	for {
+		value, err := p.nextValue(false, true)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value.text)
+
+		switch tok := p.next(); tok.kind {
+		case tokComma:
+			continue
+		case tokRParen:
+			return values, nil
+		case tokEOF:
+			return nil, p.errorAt(open.pos, ErrUnmatchedParen, "unmatched '('")
+		default:
+			return nil, p.errorAt(tok.pos, ErrInvalidExpression, fmt.Sprintf("expected ',' or ')', got %s", tok))
+		}
+	}
+}
+
+// comparison builds the expression comparing the value at path. cmp holds
+// the operator and value(s).
+func (p *filterParser) comparison(path filterToken, cmp *FieldExpr) (FilterExpr, error) {
+	return p.pathExpr(path.text, path.pos,
+		func(field string) (FilterExpr, error) {
+			expr := *cmp
+			expr.Field = field
+			if err := validateFilterValue(&expr); err != nil {
+				return nil, p.errorAt(path.pos, err, fmt.Sprintf("%s needs a YYYY-MM-DD date", field))
+			}
+			return &expr, nil
+		},
+		func(relType string) (FilterExpr, error) {
+			// depends-on:"Auth" compares the target's name or ID
+			match := *cmp
+			match.Field = relType
+			switch match.Operator {
+			case OpEquals, OpIn, OpMatches:
+				return &RelationExpr{Type: relType, Match: &match}, nil
+			case OpNotEquals:
+				match.Operator = OpEquals
+				return &NotExpr{Expr: &RelationExpr{Type: relType, Match: &match}}, nil
+			default:
+				return nil, p.errorAt(path.pos, ErrInvalidOperator,
+					fmt.Sprintf("relationship %q can only be compared with =, !=, ~ or IN", relType))
+			}
+		})
+}
+
+// validateFilterValue checks values that must have a format
+func validateFilterValue(e *FieldExpr) error {
+	switch e.Operator {
+	case OpGreater, OpLess, OpGreaterEqual, OpLessEqual:
+	default:
+		return nil
+	}
+	if field := strings.ToLower(e.Field); field != "created" && field != "modified" {
+		return nil
+	}
+	if _, err := time.Parse("2006-01-02", e.Value); err != nil {
+		return ErrInvalidDateFormat
+	}
+	return nil
+}
+
+// pathExpr builds the expression for a path. A path naming a field is passed
+// to field. Otherwise the first segment is a relationship type: a bare type
+// is passed to relation, and type.rest tests rest on the relationship targets.
+func (p *filterParser) pathExpr(path string, pos int, field, relation func(string) (FilterExpr, error)) (FilterExpr, error) {
+	if IsFilterField(path) {
+		return field(path)
+	}
+
+	name, rest, nested := strings.Cut(path, ".")
+	relType, err := p.relationType(name, pos)
+	if err != nil {
+		return nil, err
+	}
+	if !nested {
+		return relation(relType)
+	}
+	if rest == "" {
+		return nil, p.errorAt(pos+len(path), ErrInvalidField, fmt.Sprintf("expected field name after %q", path))
+	}
+
+	target, err := p.pathExpr(rest, pos+len(name)+1, field, relation)
+	if err != nil {
+		return nil, err
+	}
+	return &RelationExpr{Type: relType, Target: target}, nil
+}
+
+// relationType resolves a relationship type name
+func (p *filterParser) relationType(name string, pos int) (string, error) {
+	if p.opts.RelationType == nil {
+		return name, nil
+	}
+	if relType, ok := p.opts.RelationType(name); ok {
+		return relType, nil
+	}
+	return "", p.errorAt(pos, ErrInvalidField, fmt.Sprintf("unknown field or relationship type %q", name))
+}
+
+// regexErrorMessage describes a regular expression error without the (?i) prefix added by the parser
+func regexErrorMessage(err error) string {
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) {
+		return syntaxErr.Code.String()
+	}
+	return err.Error()
+}
@@ -413,6 +413,29 @@ func TestFilter_Matches_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestFilter_Matches_CustomClosedState(t *testing.T) {
+	sm := NewStateMachine([]StateConfig{
+		{Name: "open", Transitions: []string{"released"}},
+		{Name: "released", Closed: true},
+	})
+	released := NewFeature("Released")
+	if err := released.TransitionState(sm, "released"); err != nil {
+		t.Fatalf("TransitionState() error = %v", err)
+	}
+	open := NewFeature("Open")
+
+	closed := Filter{State: StateClosed}
+	if !closed.Matches(released) {
+		t.Error("state closed should match a feature in a custom closed state")
+	}
+	if closed.Matches(open) {
+		t.Error("state closed should not match an open feature")
+	}
+	if custom := (Filter{State: "released"}); !custom.Matches(released) {
+		t.Error("state released should match a released feature")
+	}
+}
+
 func TestFilter_Matches_Tags(t *testing.T) {
 	tests := []struct {
 		name    string
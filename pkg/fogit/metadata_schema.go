@@ -0,0 +1,307 @@
+package fogit
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Metadata field types for metadata_schema
+const (
+	MetadataTypeString = "string"
+	MetadataTypeInt    = "int"
+	MetadataTypeDate   = "date" // YYYY-MM-DD or RFC 3339
+	MetadataTypeEnum   = "enum"
+	MetadataTypeList   = "list"
+)
+
+// MetadataSchema declares typed metadata fields in .fogit/config.yml
+// Fields apply to every feature; Types adds or overrides fields for features
+// whose metadata type matches the key.
+type MetadataSchema struct {
+	Fields map[string]MetadataField            `yaml:"fields,omitempty"`
+	Types  map[string]map[string]MetadataField `yaml:"types,omitempty"`
+}
+
+// MetadataField describes one metadata key
+type MetadataField struct {
+	Type        string      `yaml:"type"` // string, int, date, enum, list
+	Description string      `yaml:"description,omitempty"`
+	Required    bool        `yaml:"required,omitempty"`
+	Values      []string    `yaml:"values,omitempty"`  // Allowed values for enum fields and list items
+	Pattern     string      `yaml:"pattern,omitempty"` // Regular expression for string values
+	Default     interface{} `yaml:"default,omitempty"` // Value filled in when the field is missing
+
+	pattern *regexp.Regexp // Pattern compiled when the schema is validated
+}
+
+// MetadataViolation describes a metadata value that does not match the schema
+type MetadataViolation struct {
+	Field   string
+	Message string
+	Missing bool // Field is required but unset
+}
+
+func (v MetadataViolation) Error() string {
+	return fmt.Sprintf("%s %s", v.Field, v.Message)
+}
+
+// IsEmpty reports whether the schema declares no fields
+func (s *MetadataSchema) IsEmpty() bool {
+	return s == nil || (len(s.Fields) == 0 && len(s.Types) == 0)
+}
+
+// FieldsFor returns the fields that apply to a feature type
+func (s *MetadataSchema) FieldsFor(featureType string) map[string]MetadataField {
+	if s.IsEmpty() {
+		return nil
+	}
+	fields := make(map[string]MetadataField, len(s.Fields))
+	for name, field := range s.Fields {
+		fields[name] = field
+	}
+	for name, field := range s.Types[featureType] {
+		fields[name] = field
+	}
+	return fields
+}
+
+// ApplyDefaults fills in default values for missing fields and converts
+// string values (e.g. from --metadata key=value) to the declared int or list type.
+// Returns the names of the fields that were defaulted.
+func (s *MetadataSchema) ApplyDefaults(f *Feature) []string {
+	fields := s.FieldsFor(f.GetType())
+	var defaulted []string
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		value, ok := f.Metadata[name]
+		if !ok || isEmptyMetadataValue(value) {
+			if field.Default != nil {
+				f.SetMetadata(name, field.Default)
+				defaulted = append(defaulted, name)
+			}
+			continue
+		}
+		f.Metadata[name] = field.coerce(value)
+	}
+	return defaulted
+}
+
+// Validate checks the feature's metadata against the schema.
+// Violations are returned in field name order.
+func (s *MetadataSchema) Validate(f *Feature) []MetadataViolation {
+	fields := s.FieldsFor(f.GetType())
+	var violations []MetadataViolation
+	for _, name := range sortedFieldNames(fields) {
+		field := fields[name]
+		value, ok := f.Metadata[name]
+		if !ok || isEmptyMetadataValue(value) {
+			if field.Required {
+				violations = append(violations, MetadataViolation{Field: name, Message: "is required", Missing: true})
+			}
+			continue
+		}
+		if msg := field.check(value); msg != "" {
+			violations = append(violations, MetadataViolation{Field: name, Message: msg})
+		}
+	}
+	return violations
+}
+
+// Enforce fills in defaults and returns an error listing every field that
+// still violates the schema. A nil schema accepts any metadata.
+func (s *MetadataSchema) Enforce(f *Feature) error {
+	if s.IsEmpty() {
+		return nil
+	}
+
+	s.ApplyDefaults(f)
+	violations := s.Validate(f)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, v := range violations {
+		messages[i] = v.Error()
+	}
+	return fmt.Errorf("invalid metadata: %s", strings.Join(messages, "; "))
+}
+
+// validate checks the schema definition itself and compiles field patterns
+func (s *MetadataSchema) validate() error {
+	check := func(scope string, fields map[string]MetadataField) error {
+		for _, name := range sortedFieldNames(fields) {
+			field := fields[name]
+			if err := field.validate(); err != nil {
+				return fmt.Errorf("metadata_schema.%s.%s: %w", scope, name, err)
+			}
+			fields[name] = field
+		}
+		return nil
+	}
+
+	if err := check("fields", s.Fields); err != nil {
+		return err
+	}
+	types := make([]string, 0, len(s.Types))
+	for t := range s.Types {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		if err := check("types."+t, s.Types[t]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validate checks a field definition and its default value, keeping the
+// compiled pattern on the field
+func (field *MetadataField) validate() error {
+	switch field.Type {
+	case MetadataTypeString, MetadataTypeInt, MetadataTypeDate, MetadataTypeList:
+	case MetadataTypeEnum:
+		if len(field.Values) == 0 {
+			return fmt.Errorf("enum fields must list values")
+		}
+	default:
+		return fmt.Errorf("invalid type '%s' (must be: string, int, date, enum, list)", field.Type)
+	}
+	if field.Pattern != "" {
+		pattern, err := regexp.Compile(field.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		field.pattern = pattern
+	}
+	if field.Default != nil {
+		if msg := field.check(field.coerce(field.Default)); msg != "" {
+			return fmt.Errorf("default %s", msg)
+		}
+	}
+	return nil
+}
+
+// coerce converts string input to the field's declared type where unambiguous
+func (field MetadataField) coerce(value interface{}) interface{} {
+	str, ok := value.(string)
+	if !ok {
+		return value
+	}
+	switch field.Type {
+	case MetadataTypeInt:
+		if n, err := strconv.Atoi(strings.TrimSpace(str)); err == nil {
+			return n
+		}
+	case MetadataTypeList:
+		var items []interface{}
+		for _, item := range strings.Split(str, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+	return value
+}
+
+// check returns a description of why value does not match the field, or ""
+func (field MetadataField) check(value interface{}) string {
+	switch field.Type {
+	case MetadataTypeString:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Sprintf("must be a string, got %v", value)
+		}
+		if field.Pattern != "" {
+			pattern := field.pattern
+			if pattern == nil {
+				// Schema built without validation
+				var err error
+				if pattern, err = regexp.Compile(field.Pattern); err != nil {
+					return fmt.Sprintf("has an invalid pattern: %v", err)
+				}
+			}
+			if !pattern.MatchString(str) {
+				return fmt.Sprintf("%q does not match pattern %s", str, field.Pattern)
+			}
+		}
+		return field.checkAllowed(str)
+	case MetadataTypeInt:
+		switch n := value.(type) {
+		case int, int64, uint64:
+			return ""
+		case float64:
+			if n == float64(int64(n)) {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be an integer, got %v", value)
+	case MetadataTypeDate:
+		switch d := value.(type) {
+		case time.Time:
+			return ""
+		case string:
+			if _, err := time.Parse("2006-01-02", d); err == nil {
+				return ""
+			}
+			if _, err := time.Parse(time.RFC3339, d); err == nil {
+				return ""
+			}
+		}
+		return fmt.Sprintf("must be a date (YYYY-MM-DD), got %v", value)
+	case MetadataTypeEnum:
+		return field.checkAllowed(fmt.Sprint(value))
+	case MetadataTypeList:
+		var items []interface{}
+		switch list := value.(type) {
+		case []interface{}:
+			items = list
+		case []string:
+			for _, item := range list {
+				items = append(items, item)
+			}
+		default:
+			return fmt.Sprintf("must be a list, got %v", value)
+		}
+		for _, item := range items {
+			if msg := field.checkAllowed(fmt.Sprint(item)); msg != "" {
+				return msg
+			}
+		}
+	}
+	return ""
+}
+
+// checkAllowed verifies a value is one of the field's allowed values (if any)
+func (field MetadataField) checkAllowed(value string) string {
+	if len(field.Values) == 0 {
+		return ""
+	}
+	for _, allowed := range field.Values {
+		if value == allowed {
+			return ""
+		}
+	}
+	return fmt.Sprintf("%q must be one of %s", value, strings.Join(field.Values, ", "))
+}
+
+// isEmptyMetadataValue treats nil and empty strings as unset
+func isEmptyMetadataValue(value interface{}) bool {
+	return value == nil || value == ""
+}
+
+// sortedFieldNames returns field names in a stable order
+func sortedFieldNames(fields map[string]MetadataField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,161 @@
+package fogit
+
+import (
+	"strings"
+	"testing"
+)
+
+func testMetadataSchema() *MetadataSchema {
+	return &MetadataSchema{
+		Fields: map[string]MetadataField{
+			"owner": {Type: MetadataTypeString, Required: true, Pattern: `^[a-z]+$`},
+		},
+		Types: map[string]map[string]MetadataField{
+			"api-endpoint": {
+				"sla":      {Type: MetadataTypeEnum, Values: []string{"gold", "silver"}, Default: "silver", Required: true},
+				"version":  {Type: MetadataTypeInt},
+				"launch":   {Type: MetadataTypeDate},
+				"scopes":   {Type: MetadataTypeList, Values: []string{"read", "write"}},
+				"deadline": {Type: MetadataTypeDate, Required: true},
+			},
+		},
+	}
+}
+
+func TestMetadataSchema_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata map[string]interface{}
+		want     []string // violating fields
+	}{
+		{
+			name:     "untyped feature only checks global fields",
+			metadata: map[string]interface{}{"owner": "alice"},
+		},
+		{
+			name:     "missing required",
+			metadata: map[string]interface{}{},
+			want:     []string{"owner"},
+		},
+		{
+			name:     "pattern mismatch",
+			metadata: map[string]interface{}{"owner": "Alice"},
+			want:     []string{"owner"},
+		},
+		{
+			name: "valid api endpoint",
+			metadata: map[string]interface{}{
+				"type": "api-endpoint", "owner": "bob", "sla": "gold", "version": 2,
+				"launch": "2025-01-31", "scopes": []interface{}{"read"}, "deadline": "2025-02-01T10:00:00Z",
+			},
+		},
+		{
+			name: "type errors",
+			metadata: map[string]interface{}{
+				"type": "api-endpoint", "owner": "bob", "sla": "bronze", "version": "two",
+				"launch": "31/01/2025", "scopes": []interface{}{"admin"},
+			},
+			want: []string{"deadline", "launch", "scopes", "sla", "version"},
+		},
+	}
+
+	schema := testMetadataSchema()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFeature("Schema")
+			f.Metadata = tt.metadata
+
+			var got []string
+			for _, v := range schema.Validate(f) {
+				got = append(got, v.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Validate() fields = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMetadataSchema_Enforce(t *testing.T) {
+	schema := testMetadataSchema()
+
+	f := NewFeature("Endpoint")
+	f.Metadata = map[string]interface{}{
+		"type": "api-endpoint", "owner": "bob", "version": "3", "scopes": "read, write", "deadline": "2025-03-01",
+	}
+	if err := schema.Enforce(f); err != nil {
+		t.Fatalf("Enforce() error = %v", err)
+	}
+	if f.Metadata["sla"] != "silver" {
+		t.Errorf("sla = %v, want default silver", f.Metadata["sla"])
+	}
+	if f.Metadata["version"] != 3 {
+		t.Errorf("version = %#v, want int 3", f.Metadata["version"])
+	}
+	if scopes, ok := f.Metadata["scopes"].([]interface{}); !ok || len(scopes) != 2 {
+		t.Errorf("scopes = %#v, want two-item list", f.Metadata["scopes"])
+	}
+
+	f = NewFeature("Broken")
+	f.SetType("api-endpoint")
+	err := schema.Enforce(f)
+	if err == nil || !strings.Contains(err.Error(), "deadline is required; owner is required") {
+		t.Errorf("Enforce() error = %v", err)
+	}
+
+	var nilSchema *MetadataSchema
+	if err := nilSchema.Enforce(f); err != nil {
+		t.Errorf("nil schema Enforce() error = %v", err)
+	}
+}
+
+func TestConfig_Validate_MetadataSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   MetadataField
+		wantErr string
+	}{
+		{name: "valid", field: MetadataField{Type: MetadataTypeInt, Default: 1}},
+		{name: "unknown type", field: MetadataField{Type: "bool"}, wantErr: "invalid type 'bool'"},
+		{name: "enum without values", field: MetadataField{Type: MetadataTypeEnum}, wantErr: "must list values"},
+		{name: "bad pattern", field: MetadataField{Type: MetadataTypeString, Pattern: "("}, wantErr: "invalid pattern"},
+		{name: "bad default", field: MetadataField{Type: MetadataTypeEnum, Values: []string{"a"}, Default: "b"}, wantErr: "default"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.MetadataSchema = &MetadataSchema{Types: map[string]map[string]MetadataField{"bug": {"field": tt.field}}}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "metadata_schema.types.bug.field") {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMetadataSchema_Patterns(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MetadataSchema = testMetadataSchema()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if cfg.MetadataSchema.Fields["owner"].pattern == nil {
+		t.Error("Validate() did not compile the owner pattern")
+	}
+
+	// A schema that was never validated reports a bad pattern instead of panicking
+	schema := &MetadataSchema{Fields: map[string]MetadataField{"owner": {Type: MetadataTypeString, Pattern: "("}}}
+	f := NewFeature("Test")
+	f.SetMetadata("owner", "alice")
+	violations := schema.Validate(f)
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, "invalid pattern") {
+		t.Errorf("Validate() = %v, want an invalid pattern violation", violations)
+	}
+}
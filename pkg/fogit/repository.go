@@ -19,3 +19,21 @@ type Repository interface {
 	// Delete removes a feature from the repository
 	Delete(ctx context.Context, id string) error
 }
+
+// IncomingEdge is a relationship seen from its target's point of view
+type IncomingEdge struct {
+	SourceID       string `json:"source_id" yaml:"source_id"`
+	RelationshipID string `json:"relationship_id" yaml:"relationship_id"`
+	Type           string `json:"type" yaml:"type"`
+}
+
+// ReverseIndexer is implemented by repositories that maintain a reverse-relationship
+// index, so incoming relationships can be found without scanning every feature
+type ReverseIndexer interface {
+	// IncomingEdges returns the relationships pointing at the target feature
+	IncomingEdges(ctx context.Context, targetID string) ([]IncomingEdge, error)
+
+	// VerifyReverseIndex compares the index against the stored features and
+	// rebuilds it if it is stale. Returns true if a rebuild was needed.
+	VerifyReverseIndex(ctx context.Context) (bool, error)
+}
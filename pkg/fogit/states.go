@@ -0,0 +1,167 @@
+package fogit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidTransition is returned when a state change is not allowed by the workflow
+var ErrInvalidTransition = errors.New("invalid state transition")
+
+// StateConfig defines a workflow state in .fogit/config.yml
+// The built-in states (open, in-progress, closed) always exist; listing one of
+// them in config overrides its transitions and hook.
+type StateConfig struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Closed      bool     `yaml:"closed,omitempty"`      // Entering this state closes the current version
+	Transitions []string `yaml:"transitions,omitempty"` // States that can be entered from this one
+	OnEnter     string   `yaml:"on_enter,omitempty"`    // Hook script in .fogit/hooks/ run after entering
+}
+
+// defaultStates mirrors the built-in lifecycle per spec 02-concepts.md:
+// open -> in-progress -> closed, and closed -> open for reopen
+func defaultStates() []StateConfig {
+	return []StateConfig{
+		{Name: string(StateOpen), Description: "Not started", Transitions: []string{string(StateInProgress), string(StateClosed)}},
+		{Name: string(StateInProgress), Description: "Being worked on", Transitions: []string{string(StateClosed)}},
+		{Name: string(StateClosed), Description: "Complete", Closed: true, Transitions: []string{string(StateOpen)}},
+	}
+}
+
+// isBuiltinState reports whether the state is derived from version timestamps
+func isBuiltinState(s State) bool {
+	return s == StateOpen || s == StateInProgress || s == StateClosed
+}
+
+// StateMachine describes the configured workflow states and allowed transitions
+type StateMachine struct {
+	order  []State
+	states map[State]StateConfig
+}
+
+// NewStateMachine builds a state machine from the built-in states overlaid with
+// the configured ones. Configured states replace built-ins with the same name.
+func NewStateMachine(configs []StateConfig) *StateMachine {
+	sm := &StateMachine{states: make(map[State]StateConfig)}
+	for _, sc := range append(defaultStates(), configs...) {
+		name := State(sc.Name)
+		if _, exists := sm.states[name]; !exists {
+			sm.order = append(sm.order, name)
+		}
+		sm.states[name] = sc
+	}
+	return sm
+}
+
+// DefaultStateMachine returns the built-in open/in-progress/closed workflow
+func DefaultStateMachine() *StateMachine {
+	return NewStateMachine(nil)
+}
+
+// StateMachine returns the state machine for the configured workflow states
+func (w *WorkflowConfig) StateMachine() *StateMachine {
+	return NewStateMachine(w.States)
+}
+
+// States returns all states in configuration order, built-ins first
+func (sm *StateMachine) States() []State {
+	states := make([]State, len(sm.order))
+	copy(states, sm.order)
+	return states
+}
+
+// Get returns the configuration of a state
+func (sm *StateMachine) Get(s State) (StateConfig, bool) {
+	sc, ok := sm.states[s]
+	return sc, ok
+}
+
+// IsValid reports whether the state is part of the workflow
+func (sm *StateMachine) IsValid(s State) bool {
+	_, ok := sm.states[s]
+	return ok
+}
+
+// IsClosed reports whether the state counts as closed
+func (sm *StateMachine) IsClosed(s State) bool {
+	return sm.states[s].Closed
+}
+
+// CanTransition reports whether the workflow allows moving from one state to another
+func (sm *StateMachine) CanTransition(from, to State) bool {
+	if !sm.IsValid(to) {
+		return false
+	}
+	for _, next := range sm.states[from].Transitions {
+		if State(next) == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateState returns an error naming the valid states if s is not one of them
+func (sm *StateMachine) ValidateState(s State) error {
+	if sm.IsValid(s) {
+		return nil
+	}
+	return NewValidationError("state", string(s), "must be one of "+sm.joinStates(sm.order))
+}
+
+// ValidateTransition returns an error naming the allowed targets if the transition is not allowed
+func (sm *StateMachine) ValidateTransition(from, to State) error {
+	if err := sm.ValidateState(to); err != nil {
+		return err
+	}
+	if sm.CanTransition(from, to) {
+		return nil
+	}
+
+	var allowed []State
+	for _, next := range sm.states[from].Transitions {
+		allowed = append(allowed, State(next))
+	}
+	if len(allowed) == 0 {
+		return fmt.Errorf("%w: %s -> %s (no transitions are allowed from %s)", ErrInvalidTransition, from, to, from)
+	}
+	return fmt.Errorf("%w: %s -> %s (allowed from %s: %s)", ErrInvalidTransition, from, to, from, sm.joinStates(allowed))
+}
+
+// validateConfigs checks that state names are unique and transitions reference known states
+func (sm *StateMachine) validateConfigs(configs []StateConfig) error {
+	seen := make(map[string]bool)
+	for _, sc := range configs {
+		if strings.TrimSpace(sc.Name) == "" {
+			return fmt.Errorf("workflow.states contains a state without a name")
+		}
+		if strings.ContainsAny(sc.Name, " \t,") {
+			return fmt.Errorf("workflow state '%s' must not contain spaces or commas", sc.Name)
+		}
+		if seen[sc.Name] {
+			return fmt.Errorf("workflow state '%s' is defined more than once", sc.Name)
+		}
+		seen[sc.Name] = true
+
+		name := State(sc.Name)
+		if isBuiltinState(name) && sc.Closed != (name == StateClosed) {
+			return fmt.Errorf("workflow state '%s' cannot change whether it counts as closed", sc.Name)
+		}
+		for _, next := range sc.Transitions {
+			if !sm.IsValid(State(next)) {
+				return fmt.Errorf("workflow state '%s' has transition to undefined state '%s'", sc.Name, next)
+			}
+		}
+	}
+	return nil
+}
+
+// joinStates formats states as a comma-separated list
+func (sm *StateMachine) joinStates(states []State) string {
+	names := make([]string, len(states))
+	for i, s := range states {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
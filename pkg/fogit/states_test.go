@@ -0,0 +1,155 @@
+package fogit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// reviewWorkflow adds a review state between in-progress and closed,
+// plus a closed-like rejected state
+func reviewWorkflow() []StateConfig {
+	return []StateConfig{
+		{Name: "in-progress", Transitions: []string{"review"}},
+		{Name: "review", Transitions: []string{"in-progress", "closed", "rejected"}},
+		{Name: "rejected", Closed: true, Transitions: []string{"open"}},
+	}
+}
+
+func TestNewStateMachine(t *testing.T) {
+	sm := NewStateMachine(reviewWorkflow())
+
+	want := []State{StateOpen, StateInProgress, StateClosed, "review", "rejected"}
+	got := sm.States()
+	if len(got) != len(want) {
+		t.Fatalf("States() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("States()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if !sm.IsClosed("rejected") || sm.IsClosed("review") {
+		t.Error("IsClosed() should follow the configured closed flag")
+	}
+	if sm.IsValid("blocked") {
+		t.Error("IsValid(blocked) = true for an undefined state")
+	}
+}
+
+func TestStateMachine_CanTransition(t *testing.T) {
+	sm := NewStateMachine(reviewWorkflow())
+
+	tests := []struct {
+		from, to State
+		want     bool
+	}{
+		{StateOpen, StateInProgress, true},
+		{StateInProgress, "review", true},
+		{StateInProgress, StateClosed, false}, // overridden: must go through review
+		{"review", StateClosed, true},
+		{"review", "rejected", true},
+		{"rejected", StateOpen, true},
+		{StateOpen, "review", false},
+		{"review", "blocked", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			if got := sm.CanTransition(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStateMachine_ValidateTransition(t *testing.T) {
+	sm := NewStateMachine(reviewWorkflow())
+
+	err := sm.ValidateTransition(StateInProgress, StateClosed)
+	if !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("ValidateTransition() error = %v, want ErrInvalidTransition", err)
+	}
+	if !strings.Contains(err.Error(), "allowed from in-progress: review") {
+		t.Errorf("error %q should list the allowed transitions", err)
+	}
+
+	err = sm.ValidateTransition(StateOpen, "blocked")
+	if err == nil || !strings.Contains(err.Error(), "must be one of open, in-progress, closed, review, rejected") {
+		t.Errorf("ValidateTransition() to undefined state error = %v", err)
+	}
+
+	if err := sm.ValidateTransition("review", StateClosed); err != nil {
+		t.Errorf("ValidateTransition(review, closed) error = %v", err)
+	}
+}
+
+func TestConfig_Validate_States(t *testing.T) {
+	tests := []struct {
+		name    string
+		states  []StateConfig
+		wantErr string
+	}{
+		{name: "custom workflow", states: reviewWorkflow()},
+		{name: "missing name", states: []StateConfig{{Name: " "}}, wantErr: "without a name"},
+		{name: "name with space", states: []StateConfig{{Name: "in review"}}, wantErr: "must not contain spaces"},
+		{name: "duplicate", states: []StateConfig{{Name: "review"}, {Name: "review"}}, wantErr: "more than once"},
+		{name: "undefined target", states: []StateConfig{{Name: "review", Transitions: []string{"shipped"}}}, wantErr: "undefined state 'shipped'"},
+		{name: "built-in closed flag", states: []StateConfig{{Name: "open", Closed: true}}, wantErr: "cannot change whether it counts as closed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Workflow.States = tt.states
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFeature_TransitionState(t *testing.T) {
+	sm := NewStateMachine(reviewWorkflow())
+	f := NewFeature("Custom")
+
+	steps := []struct {
+		to         State
+		wantClosed bool
+	}{
+		{StateInProgress, false},
+		{"review", false},
+		{"rejected", true},
+	}
+	for _, step := range steps {
+		if err := f.TransitionState(sm, step.to); err != nil {
+			t.Fatalf("TransitionState(%s) error = %v", step.to, err)
+		}
+		if got := f.DeriveState(); got != step.to {
+			t.Errorf("after TransitionState(%s): DeriveState() = %s", step.to, got)
+		}
+		if got := f.IsClosed(); got != step.wantClosed {
+			t.Errorf("after TransitionState(%s): IsClosed() = %v, want %v", step.to, got, step.wantClosed)
+		}
+	}
+
+	// Leaving a custom state goes back to timestamp derivation
+	if err := f.TransitionState(sm, StateOpen); err != nil {
+		t.Fatalf("TransitionState(open) error = %v", err)
+	}
+	if v := f.GetCurrentVersion(); v.State != "" || f.IsClosed() {
+		t.Errorf("after reopening: stored state = %q, closed = %v; want empty, false", v.State, f.IsClosed())
+	}
+
+	if err := f.TransitionState(sm, StateClosed); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("TransitionState(in-progress -> closed) error = %v, want ErrInvalidTransition", err)
+	}
+}
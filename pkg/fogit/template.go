@@ -0,0 +1,68 @@
+package fogit
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// templateNamePattern restricts template names to safe file names
+var templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// FeatureTemplate holds reusable defaults for new features.
+// Templates are stored in .fogit/templates/<name>.yml
+type FeatureTemplate struct {
+	Name               string                 `yaml:"name" json:"name"`
+	Description        string                 `yaml:"description,omitempty" json:"description,omitempty"`                 // What the template is for
+	Type               string                 `yaml:"type,omitempty" json:"type,omitempty"`                               // Default feature type
+	Priority           string                 `yaml:"priority,omitempty" json:"priority,omitempty"`                       // Default priority
+	FeatureDescription string                 `yaml:"feature_description,omitempty" json:"feature_description,omitempty"` // Description skeleton for new features
+	Tags               []string               `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Metadata           map[string]interface{} `yaml:"metadata,omitempty" json:"metadata,omitempty"`                   // Default metadata values
+	RequiredMetadata   []string               `yaml:"required_metadata,omitempty" json:"required_metadata,omitempty"` // Keys that must be set on creation
+	Relationships      []TemplateRelationship `yaml:"relationships,omitempty" json:"relationships,omitempty"`
+}
+
+// TemplateRelationship is a relationship added to every feature created from a template
+type TemplateRelationship struct {
+	Type        string `yaml:"type" json:"type"`
+	Target      string `yaml:"target" json:"target"` // Feature ID or name
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// IsValidTemplateName reports whether name can be used as a template file name
+func IsValidTemplateName(name string) bool {
+	return templateNamePattern.MatchString(name)
+}
+
+// Validate checks the template for structural errors
+func (t *FeatureTemplate) Validate() error {
+	if !IsValidTemplateName(t.Name) {
+		return NewValidationError("template name", t.Name, "use letters, digits, '-', '_' or '.'")
+	}
+	if t.Priority != "" && !Priority(t.Priority).IsValid() {
+		return ErrInvalidPriority
+	}
+	for i, rel := range t.Relationships {
+		if rel.Type == "" {
+			return fmt.Errorf("template relationship %d: type cannot be empty", i+1)
+		}
+		if rel.Target == "" {
+			return fmt.Errorf("template relationship %d: %w", i+1, ErrEmptyTargetID)
+		}
+	}
+	return nil
+}
+
+// MissingMetadata returns the required metadata keys that are unset or empty in metadata
+func (t *FeatureTemplate) MissingMetadata(metadata map[string]interface{}) []string {
+	var missing []string
+	for _, key := range t.RequiredMetadata {
+		value, ok := metadata[key]
+		if !ok || value == nil || value == "" {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,127 @@
+package fogit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SavedView is a named feature listing: a filter expression together with
+// the 'fogit list' flags, sorting and output format to show it with.
+// Views are stored in .fogit/views/<name>.yml so they are shared through git.
+type SavedView struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Filter expression (see ParseFilterExpr)
+	Filter string `yaml:"filter,omitempty" json:"filter,omitempty"`
+
+	// 'fogit list' flags
+	State         string   `yaml:"state,omitempty" json:"state,omitempty"`
+	Priority      string   `yaml:"priority,omitempty" json:"priority,omitempty"`
+	Type          string   `yaml:"type,omitempty" json:"type,omitempty"`
+	Category      string   `yaml:"category,omitempty" json:"category,omitempty"`
+	Domain        string   `yaml:"domain,omitempty" json:"domain,omitempty"`
+	Team          string   `yaml:"team,omitempty" json:"team,omitempty"`
+	Epic          string   `yaml:"epic,omitempty" json:"epic,omitempty"`
+	Parent        string   `yaml:"parent,omitempty" json:"parent,omitempty"`
+	Tags          []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Contributor   string   `yaml:"contributor,omitempty" json:"contributor,omitempty"`
+	CurrentBranch bool     `yaml:"current_branch,omitempty" json:"current_branch,omitempty"`
+
+	// Presentation
+	Sort    string   `yaml:"sort,omitempty" json:"sort,omitempty"`         // Sort field (default: created)
+	Order   string   `yaml:"order,omitempty" json:"order,omitempty"`       // asc or desc
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty"`   // Table columns (default: the standard table)
+	GroupBy string   `yaml:"group_by,omitempty" json:"group_by,omitempty"` // Field to group features by
+	Format  string   `yaml:"format,omitempty" json:"format,omitempty"`     // Output format or text/template (default: table)
+}
+
+// IsValidViewName reports whether name can be used as a view file name.
+// The rules are the same as for template names.
+func IsValidViewName(name string) bool {
+	return templateNamePattern.MatchString(name)
+}
+
+// Validate checks the view for structural errors. States and relationship
+// types in the filter expression depend on the config and are checked when
+// the view is run.
+func (v *SavedView) Validate() error {
+	if !IsValidViewName(v.Name) {
+		return NewValidationError("view name", v.Name, "use letters, digits, '-', '_' or '.'")
+	}
+	if _, err := ParseFilterExpr(v.Filter); err != nil {
+		return err
+	}
+	filter := v.ListFilter()
+	if filter.Priority != "" && !filter.Priority.IsValid() {
+		return ErrInvalidPriority
+	}
+	if !filter.SortBy.IsValid() {
+		return ErrInvalidSortField
+	}
+	if !filter.SortOrder.IsValid() {
+		return ErrInvalidSortOrder
+	}
+	return nil
+}
+
+// ListFilter returns the filter for the view's list flags
+func (v *SavedView) ListFilter() *Filter {
+	return &Filter{
+		State:       State(v.State),
+		Priority:    Priority(v.Priority),
+		Type:        v.Type,
+		Category:    v.Category,
+		Domain:      v.Domain,
+		Team:        v.Team,
+		Epic:        v.Epic,
+		Parent:      v.Parent,
+		Tags:        v.Tags,
+		Contributor: v.Contributor,
+		SortBy:      SortField(v.Sort),
+		SortOrder:   SortOrder(v.Order),
+	}
+}
+
+// ListArgs returns the 'fogit list' arguments equivalent to the view
+func (v *SavedView) ListArgs() []string {
+	var args []string
+	add := func(flag, value string) {
+		if value != "" {
+			args = append(args, "--"+flag, quoteArg(value))
+		}
+	}
+
+	add("filter", v.Filter)
+	add("state", v.State)
+	add("priority", v.Priority)
+	add("type", v.Type)
+	add("category", v.Category)
+	add("domain", v.Domain)
+	add("team", v.Team)
+	add("epic", v.Epic)
+	add("parent", v.Parent)
+	for _, tag := range v.Tags {
+		add("tag", tag)
+	}
+	add("contributor", v.Contributor)
+	if v.CurrentBranch {
+		args = append(args, "--current-branch")
+	}
+	add("sort", v.Sort)
+	add("order", v.Order)
+	add("columns", strings.Join(v.Columns, ","))
+	add("group-by", v.GroupBy)
+	add("format", v.Format)
+	return args
+}
+
+// quoteArg quotes a shell argument that contains more than plain characters
+func quoteArg(value string) string {
+	if strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_.@/,:=", r))
+	}) < 0 {
+		return value
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(value, "'", `'\''`))
+}
@@ -0,0 +1,45 @@
+package fogit
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSavedView_ListArgs(t *testing.T) {
+	view := &SavedView{
+		Name:          "hot",
+		Filter:        `priority>=high AND name!="Old API"`,
+		Team:          "backend",
+		Tags:          []string{"api", "v2"},
+		CurrentBranch: true,
+		Sort:          "priority",
+		Order:         "desc",
+		Columns:       []string{"name", "metadata.team"},
+		GroupBy:       "state",
+		Format:        `{{.Name}}\t{{.State}}`,
+	}
+
+	want := []string{
+		"--filter", `'priority>=high AND name!="Old API"'`,
+		"--team", "backend",
+		"--tag", "api", "--tag", "v2",
+		"--current-branch",
+		"--sort", "priority", "--order", "desc",
+		"--columns", "name,metadata.team", "--group-by", "state",
+		"--format", `'{{.Name}}\t{{.State}}'`,
+	}
+	if got := view.ListArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ListArgs() = %q, want %q", got, want)
+	}
+}
+
+func TestSavedView_ListFilter(t *testing.T) {
+	view := &SavedView{Name: "open", State: "open", Priority: "high", Tags: []string{"api"}, Sort: "name", Order: "desc"}
+	filter := view.ListFilter()
+	if filter.State != StateOpen || filter.Priority != PriorityHigh || filter.SortBy != SortByName || filter.SortOrder != SortDescending {
+		t.Errorf("ListFilter() = %+v", filter)
+	}
+	if err := view.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
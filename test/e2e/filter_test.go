@@ -19,6 +19,7 @@ import (
 // - NOT expression: NOT state:closed
 // - Combined: (priority:high OR priority:critical) AND state:open
 // - Wildcard: name:*auth*
+// - IN lists, regular expressions and relationship predicates with --filter on list
 func TestE2E_FilterExpressions(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping end-to-end test in short mode")
@@ -263,5 +264,62 @@ func TestE2E_FilterExpressions(t *testing.T) {
 	}
 	t.Logf("Sorted results:\n%s", output)
 
+	// Step 14: Test IN and regular expressions with list --filter
+	t.Log("Step 14: Testing list --filter with IN and regex...")
+	output, err = runFogit(t, projectDir, "list", "--filter", "priority IN (low, critical) AND name~/^(admin|user)/")
+	if err != nil {
+		t.Fatalf("Failed to list with filter: %v\nOutput: %s", err, output)
+	}
+	t.Logf("IN and regex results:\n%s", output)
+
+	if !strings.Contains(output, "Admin Panel") || !strings.Contains(output, "User Dashboard") {
+		t.Error("IN/regex filter should include Admin Panel and User Dashboard")
+	}
+	if strings.Contains(output, "Security Audit") {
+		t.Error("IN/regex filter should NOT include Security Audit (name doesn't match)")
+	}
+
+	// Step 15: Test relationship predicates
+	t.Log("Step 15: Testing relationship predicates...")
+	output, err = runFogit(t, projectDir, "link", "Auth Login", "API Gateway", "depends-on")
+	if err != nil {
+		t.Fatalf("Failed to link: %v\nOutput: %s", err, output)
+	}
+	output, err = runFogit(t, projectDir, "link", "Admin Panel", "API Gateway", "depends-on")
+	if err != nil {
+		t.Fatalf("Failed to link: %v\nOutput: %s", err, output)
+	}
+
+	output, err = runFogit(t, projectDir, "list", "--filter", `depends-on:"API Gateway" AND depends-on.priority=high`)
+	if err != nil {
+		t.Fatalf("Failed to list with relationship filter: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(output, "Auth Login") || !strings.Contains(output, "Admin Panel") || strings.Contains(output, "Database Migration") {
+		t.Errorf("depends-on filter should only include the dependents of API Gateway:\n%s", output)
+	}
+
+	output, err = runFogit(t, projectDir, "list", "--filter", "incoming(depends-on) >= 2")
+	if err != nil {
+		t.Fatalf("Failed to list with incoming filter: %v\nOutput: %s", err, output)
+	}
+	if !strings.Contains(output, "API Gateway") || strings.Contains(output, "Auth Login") {
+		t.Errorf("incoming filter should only include API Gateway:\n%s", output)
+	}
+
+	// Step 16: Test position-aware syntax errors
+	t.Log("Step 16: Testing syntax errors...")
+	output, err = runFogit(t, projectDir, "list", "--filter", "state:open AND (priority:high")
+	if err == nil {
+		t.Fatalf("Expected an error for an unmatched parenthesis, got:\n%s", output)
+	}
+	if !strings.Contains(output, "column 16") || !strings.Contains(output, "^") {
+		t.Errorf("Syntax error should point at the unmatched parenthesis:\n%s", output)
+	}
+
+	output, err = runFogit(t, projectDir, "list", "--filter", "owner:alice")
+	if err == nil || !strings.Contains(output, "unknown field or relationship type") {
+		t.Errorf("Expected an unknown field error, got: %v\n%s", err, output)
+	}
+
 	t.Log("✅ Filter expressions test completed successfully!")
 }
@@ -0,0 +1,58 @@
+package e2e
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestE2E_ListColumnsAndGroups tests --columns, --group-by and template formats of fogit list
+func TestE2E_ListColumnsAndGroups(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping end-to-end test in short mode")
+	}
+
+	projectDir := setupSharedBranchProject(t, "E2E_ListColumnsAndGroups")
+
+	for _, f := range []struct{ name, priority, team string }{
+		{"Checkout Flow", "high", "payments"},
+		{"Refunds", "low", "payments"},
+		{"Search Page", "critical", "discovery"},
+	} {
+		if output, err := runFogit(t, projectDir, "create", f.name, "--same", "-p", f.priority, "--team", f.team); err != nil {
+			t.Fatalf("Failed to create %s: %v\nOutput: %s", f.name, err, output)
+		}
+	}
+
+	t.Log("Listing columns grouped by team...")
+	output, err := runFogit(t, projectDir, "list", "--columns", "name,priority,relationships.count", "--group-by", "metadata.team", "--sort", "name")
+	if err != nil {
+		t.Fatalf("Failed to list: %v\nOutput: %s", err, output)
+	}
+	discovery, payments := strings.Index(output, "TEAM: discovery  (1 feature: 1 open)"), strings.Index(output, "TEAM: payments  (2 features: 2 open)")
+	if discovery < 0 || payments < 0 || discovery > payments {
+		t.Errorf("expected discovery and payments groups with counts:\n%s", output)
+	}
+	if !strings.Contains(output, "RELATIONSHIPS.COUNT") || strings.Contains(output, "CATEGORY") {
+		t.Errorf("expected only the requested columns:\n%s", output)
+	}
+	if !strings.Contains(output, "Total: 3 features in 2 groups") {
+		t.Errorf("expected totals:\n%s", output)
+	}
+
+	t.Log("Listing with a template...")
+	output, err = runFogit(t, projectDir, "list", "--format", `{{.Name}}\t{{.GetPriority}}`, "--sort", "name")
+	if err != nil {
+		t.Fatalf("Failed to list with template: %v\nOutput: %s", err, output)
+	}
+	if want := "Checkout Flow\thigh\nRefunds\tlow\nSearch Page\tcritical\n"; output != want {
+		t.Errorf("template output = %q, want %q", output, want)
+	}
+
+	t.Log("Rejecting invalid presentation flags...")
+	if output, err = runFogit(t, projectDir, "list", "--columns", "name,bogus"); err == nil {
+		t.Errorf("Unknown column should fail:\n%s", output)
+	}
+	if output, err = runFogit(t, projectDir, "list", "--format", "{{.Name}}", "--group-by", "team"); err == nil {
+		t.Errorf("Template with --group-by should fail:\n%s", output)
+	}
+}
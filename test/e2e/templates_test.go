@@ -0,0 +1,112 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// setupSharedBranchProject creates a git repository with an initial commit,
+// initializes fogit and enables shared branches with fuzzy matching disabled
+func setupSharedBranchProject(t *testing.T, name string) string {
+	t.Helper()
+
+	projectDir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	repo, err := gogit.PlainInit(projectDir, false)
+	if err != nil {
+		t.Fatalf("Failed to init Git: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Failed to get worktree: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "README.md"), []byte("# "+name+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to create README: %v", err)
+	}
+	if _, err := worktree.Add("README.md"); err != nil {
+		t.Fatalf("Failed to stage: %v", err)
+	}
+	if _, err := worktree.Commit("Initial commit", &gogit.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@test.com", When: time.Now()},
+	}); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+
+	for _, args := range [][]string{
+		{"init"},
+		{"config", "set", "workflow.allow_shared_branches", "true"},
+		{"config", "set", "feature_search.fuzzy_match", "false"},
+	} {
+		if output, err := runFogit(t, projectDir, args...); err != nil {
+			t.Fatalf("fogit %v failed: %v\nOutput: %s", args, err, output)
+		}
+	}
+
+	return projectDir
+}
+
+// TestE2E_FeatureTemplates tests creating templates and features from them
+func TestE2E_FeatureTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping end-to-end test in short mode")
+	}
+
+	projectDir := setupSharedBranchProject(t, "E2E_FeatureTemplates")
+
+	output, err := runFogit(t, projectDir, "create", "API Platform", "--same")
+	if err != nil {
+		t.Fatalf("Failed to create epic: %v\nOutput: %s", err, output)
+	}
+
+	t.Log("Creating template...")
+	output, err = runFogit(t, projectDir, "template", "create", "api-endpoint",
+		"-d", "REST endpoint", "--type", "api-endpoint", "-p", "high", "--tags", "api",
+		"--metadata", "sla=99.9", "--require", "owner", "--link", "contained-by:API Platform")
+	if err != nil {
+		t.Fatalf("Failed to create template: %v\nOutput: %s", err, output)
+	}
+
+	output, err = runFogit(t, projectDir, "template", "list")
+	if err != nil || !strings.Contains(output, "api-endpoint") || !strings.Contains(output, "REST endpoint") {
+		t.Fatalf("template list missing template: %v\nOutput: %s", err, output)
+	}
+
+	output, err = runFogit(t, projectDir, "template", "show", "api-endpoint")
+	if err != nil || !strings.Contains(output, "Required metadata: owner") {
+		t.Fatalf("template show: %v\nOutput: %s", err, output)
+	}
+
+	t.Log("Creating feature without required metadata...")
+	output, err = runFogit(t, projectDir, "create", "Get Orders", "--same", "--template", "api-endpoint")
+	if err == nil {
+		t.Fatalf("Expected missing metadata error\nOutput: %s", output)
+	}
+	if !strings.Contains(output, "requires metadata: owner") {
+		t.Errorf("Unexpected error output: %s", output)
+	}
+
+	t.Log("Creating feature from template...")
+	output, err = runFogit(t, projectDir, "create", "Get Orders", "--same", "--template", "api-endpoint", "--metadata", "owner=alice")
+	if err != nil {
+		t.Fatalf("Failed to create feature from template: %v\nOutput: %s", err, output)
+	}
+
+	output, err = runFogit(t, projectDir, "show", "Get Orders", "--format", "json")
+	if err != nil {
+		t.Fatalf("Failed to show feature: %v\nOutput: %s", err, output)
+	}
+	for _, want := range []string{`"api-endpoint"`, `"high"`, `"alice"`, `"99.9"`, `"contained-by"`} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Feature JSON missing %s\nOutput: %s", want, output)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package e2e
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestE2E_SavedViews tests saving, running, listing and deleting views
+func TestE2E_SavedViews(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping end-to-end test in short mode")
+	}
+
+	projectDir := setupSharedBranchProject(t, "E2E_SavedViews")
+
+	for _, f := range []struct{ name, priority string }{
+		{"Checkout Flow", "high"},
+		{"Search Page", "critical"},
+		{"Legacy Reports", "low"},
+	} {
+		if output, err := runFogit(t, projectDir, "create", f.name, "--same", "-p", f.priority); err != nil {
+			t.Fatalf("Failed to create %s: %v\nOutput: %s", f.name, err, output)
+		}
+	}
+
+	t.Log("Saving view...")
+	output, err := runFogit(t, projectDir, "view", "save", "hot", "-d", "Important work",
+		"--filter", "priority>=high", "--sort", "name", "--order", "desc", "--format", "csv")
+	if err != nil {
+		t.Fatalf("Failed to save view: %v\nOutput: %s", err, output)
+	}
+	if _, err := os.Stat(filepath.Join(projectDir, ".fogit", "views", "hot.yml")); err != nil {
+		t.Fatalf("View file not written: %v", err)
+	}
+
+	t.Log("Running view...")
+	output, err = runFogit(t, projectDir, "view", "run", "hot")
+	if err != nil {
+		t.Fatalf("Failed to run view: %v\nOutput: %s", err, output)
+	}
+	search, checkout := strings.Index(output, "Search Page"), strings.Index(output, "Checkout Flow")
+	if search < 0 || checkout < 0 || search > checkout || strings.Contains(output, "Legacy Reports") {
+		t.Errorf("view run should list Search Page before Checkout Flow only:\n%s", output)
+	}
+	if !strings.HasPrefix(output, "ID,Name") {
+		t.Errorf("view run should use the view's csv format:\n%s", output)
+	}
+
+	output, err = runFogit(t, projectDir, "view", "run", "hot", "--format", "json")
+	if err != nil || !strings.HasPrefix(strings.TrimSpace(output), "[") {
+		t.Errorf("view run --format json should override the format: %v\n%s", err, output)
+	}
+
+	output, err = runFogit(t, projectDir, "view", "list")
+	if err != nil || !strings.Contains(output, "Important work") || !strings.Contains(output, "fogit list --filter 'priority>=high'") {
+		t.Errorf("view list: %v\n%s", err, output)
+	}
+
+	t.Log("Rejecting invalid views...")
+	if output, err = runFogit(t, projectDir, "view", "save", "hot", "--state", "open"); err == nil {
+		t.Errorf("Saving over an existing view without --force should fail:\n%s", output)
+	}
+	if output, err = runFogit(t, projectDir, "view", "save", "broken", "--filter", "state:open AND"); err == nil {
+		t.Errorf("Saving a view with a broken filter should fail:\n%s", output)
+	}
+
+	t.Log("Deleting view...")
+	if output, err = runFogit(t, projectDir, "view", "delete", "hot"); err != nil {
+		t.Fatalf("Failed to delete view: %v\nOutput: %s", err, output)
+	}
+	if output, err = runFogit(t, projectDir, "view", "run", "hot"); err == nil || !strings.Contains(output, "not found") {
+		t.Errorf("Running a deleted view should fail: %v\n%s", err, output)
+	}
+}